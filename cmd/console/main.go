@@ -12,6 +12,8 @@ import (
 	mb "github.com/gflydev/db"
 	dbPSQL "github.com/gflydev/db/psql"
 	notificationMail "github.com/gflydev/notification/mail"
+	"github.com/gflydev/storage"
+	storageLocal "github.com/gflydev/storage/local"
 	"os"
 )
 
@@ -22,6 +24,9 @@ func main() {
 	// Register mail notification
 	notificationMail.AutoRegister()
 
+	// Register Local storage
+	storage.Register(storageLocal.Type, storageLocal.New())
+
 	// Register Redis cache
 	cache.Register(cacheRedis.New())
 
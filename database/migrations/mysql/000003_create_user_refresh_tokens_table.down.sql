@@ -0,0 +1,3 @@
+ALTER TABLE user_sessions ADD COLUMN refresh_token VARCHAR(64) NOT NULL DEFAULT '';
+
+DROP TABLE IF EXISTS user_refresh_tokens;
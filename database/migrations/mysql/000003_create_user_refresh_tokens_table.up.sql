@@ -0,0 +1,24 @@
+-- -----------------------------------------------------
+-- Table user_refresh_tokens
+-- Every refresh token belongs to a family (the session it was issued for).
+-- -----------------------------------------------------
+CREATE TABLE user_refresh_tokens (
+                                     id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+                                     session_id VARCHAR(36) NOT NULL,
+                                     token_hash VARCHAR(64) NOT NULL,
+                                     expires_at TIMESTAMP NOT NULL,
+                                     used_at TIMESTAMP NULL,
+                                     replaced_by VARCHAR(64) NULL, -- Hash of the token which rotated this one
+                                     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                     CONSTRAINT fk_user_refresh_tokens_user_sessions
+                                         FOREIGN KEY (session_id)
+                                             REFERENCES user_sessions (id)
+                                             ON DELETE CASCADE
+);
+
+-- Add indexes
+CREATE UNIQUE INDEX user_refresh_tokens_token_hash ON user_refresh_tokens (token_hash);
+CREATE INDEX user_refresh_tokens_session_id ON user_refresh_tokens (session_id);
+
+-- Refresh tokens are now stored per family
+ALTER TABLE user_sessions DROP COLUMN refresh_token;
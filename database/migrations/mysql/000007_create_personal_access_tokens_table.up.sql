@@ -0,0 +1,23 @@
+-- -----------------------------------------------------
+-- Table personal_access_tokens
+-- Long-lived tokens for machine clients. Only the SHA256 hash of a token is stored.
+-- -----------------------------------------------------
+CREATE TABLE personal_access_tokens (
+                                        id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+                                        user_id BIGINT UNSIGNED NOT NULL,
+                                        name VARCHAR(255) NOT NULL,
+                                        token_hash VARCHAR(64) NOT NULL,
+                                        scopes VARCHAR(1000) NOT NULL DEFAULT '',
+                                        expires_at TIMESTAMP NULL,
+                                        last_used_at TIMESTAMP NULL,
+                                        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                        updated_at TIMESTAMP NULL,
+                                        CONSTRAINT fk_personal_access_tokens_users
+                                            FOREIGN KEY (user_id)
+                                                REFERENCES users (id)
+                                                ON DELETE CASCADE
+);
+
+-- Add indexes
+CREATE UNIQUE INDEX personal_access_tokens_token_hash ON personal_access_tokens (token_hash);
+CREATE INDEX personal_access_tokens_user_id ON personal_access_tokens (user_id);
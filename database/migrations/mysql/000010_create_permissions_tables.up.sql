@@ -0,0 +1,56 @@
+-- -----------------------------------------------------
+-- Table permissions
+-- Fine-grained abilities checked by routes. Ex: `users.read`
+-- -----------------------------------------------------
+CREATE TABLE permissions (
+                             id INT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+                             name VARCHAR(100) NOT NULL,
+                             slug VARCHAR(100) NOT NULL,
+                             description VARCHAR(255) NULL,
+                             created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                             updated_at TIMESTAMP NULL
+);
+
+-- Add indexes
+CREATE UNIQUE INDEX permissions_slug ON permissions (slug);
+
+-- -----------------------------------------------------
+-- Table role_permissions
+-- -----------------------------------------------------
+CREATE TABLE role_permissions (
+                                  id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+                                  role_id INT UNSIGNED NOT NULL,
+                                  permission_id INT UNSIGNED NOT NULL,
+                                  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                  CONSTRAINT fk_role_permissions_roles
+                                      FOREIGN KEY (role_id)
+                                          REFERENCES roles (id)
+                                          ON DELETE CASCADE,
+                                  CONSTRAINT fk_role_permissions_permissions
+                                      FOREIGN KEY (permission_id)
+                                          REFERENCES permissions (id)
+                                          ON DELETE CASCADE
+);
+
+-- Add indexes
+CREATE UNIQUE INDEX role_permissions_role_permission ON role_permissions (role_id, permission_id);
+
+-- --------------------------------------------------------------------------------------
+-- ------------------------------------ Initial data ------------------------------------
+-- --------------------------------------------------------------------------------------
+
+-- Insert data
+INSERT INTO permissions (name, slug, description) VALUES('Read users', 'users.read', 'List and view user accounts');
+INSERT INTO permissions (name, slug, description) VALUES('Create users', 'users.create', 'Create user accounts');
+INSERT INTO permissions (name, slug, description) VALUES('Update users', 'users.update', 'Update user accounts and their status');
+INSERT INTO permissions (name, slug, description) VALUES('Delete users', 'users.delete', 'Delete user accounts');
+INSERT INTO permissions (name, slug, description) VALUES('Impersonate users', 'users.impersonate', 'Sign in as another user');
+INSERT INTO permissions (name, slug, description) VALUES('Manage roles', 'roles.manage', 'Manage roles and their permissions');
+
+-- Admin: all permissions
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT roles.id, permissions.id FROM roles, permissions WHERE roles.slug = 'admin';
+
+-- Moderator: read users
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT roles.id, permissions.id FROM roles, permissions WHERE roles.slug = 'moderator' AND permissions.slug = 'users.read';
@@ -0,0 +1 @@
+DROP INDEX roles_slug ON roles;
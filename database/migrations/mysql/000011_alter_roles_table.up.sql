@@ -0,0 +1,7 @@
+-- -----------------------------------------------------
+-- Table roles
+-- Roles are created at runtime, so the slug identifies a role (Ex: scope `role:<slug>`).
+-- -----------------------------------------------------
+
+-- Add indexes
+CREATE UNIQUE INDEX roles_slug ON roles (slug);
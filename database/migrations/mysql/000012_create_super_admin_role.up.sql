@@ -0,0 +1,9 @@
+-- --------------------------------------------------------------------------------------
+-- ------------------------------------ Initial data ------------------------------------
+-- --------------------------------------------------------------------------------------
+
+-- Super Admin: passes every policy (see `internal/policies`) and has all permissions
+INSERT INTO roles (name, slug) VALUES('Super Admin', 'super-admin');
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT roles.id, permissions.id FROM roles, permissions WHERE roles.slug = 'super-admin';
@@ -0,0 +1,17 @@
+-- -----------------------------------------------------
+-- Table roles
+-- A role inherits all roles and permissions of its parent. Ex: `admin` > `moderator` > `member`
+-- -----------------------------------------------------
+ALTER TABLE roles ADD COLUMN parent_id INT UNSIGNED NULL;
+ALTER TABLE roles ADD CONSTRAINT fk_roles_parents
+    FOREIGN KEY (parent_id)
+        REFERENCES roles (id)
+        ON DELETE SET NULL;
+
+-- --------------------------------------------------------------------------------------
+-- ------------------------------------ Initial data ------------------------------------
+-- --------------------------------------------------------------------------------------
+
+UPDATE roles child JOIN roles parent ON parent.slug = 'member' SET child.parent_id = parent.id WHERE child.slug = 'moderator';
+UPDATE roles child JOIN roles parent ON parent.slug = 'moderator' SET child.parent_id = parent.id WHERE child.slug = 'admin';
+UPDATE roles child JOIN roles parent ON parent.slug = 'admin' SET child.parent_id = parent.id WHERE child.slug = 'super-admin';
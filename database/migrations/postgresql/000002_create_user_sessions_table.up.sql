@@ -0,0 +1,22 @@
+-- -----------------------------------------------------
+-- Table user_sessions
+-- -----------------------------------------------------
+CREATE TABLE user_sessions (
+                               id VARCHAR(36) PRIMARY KEY,
+                               user_id INT NOT NULL,
+                               refresh_token VARCHAR(64) NOT NULL,
+                               user_agent VARCHAR(255) NULL,
+                               ip_address VARCHAR(45) NULL,
+                               expires_at TIMESTAMP NOT NULL,
+                               last_used_at TIMESTAMP NULL,
+                               revoked_at TIMESTAMP NULL,
+                               created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                               updated_at TIMESTAMP NULL,
+                               CONSTRAINT fk_user_sessions_users
+                                   FOREIGN KEY (user_id)
+                                       REFERENCES users (id)
+                                       ON DELETE CASCADE
+);
+
+-- Add indexes
+CREATE INDEX user_sessions_user_id ON user_sessions (user_id);
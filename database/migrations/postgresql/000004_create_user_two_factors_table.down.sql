@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS user_recovery_codes CASCADE;
+DROP TABLE IF EXISTS user_two_factors CASCADE;
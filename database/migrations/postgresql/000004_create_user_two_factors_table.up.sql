@@ -0,0 +1,39 @@
+-- -----------------------------------------------------
+-- Table user_two_factors
+-- -----------------------------------------------------
+CREATE TABLE user_two_factors (
+                                  id SERIAL PRIMARY KEY,
+                                  user_id INT NOT NULL,
+                                  secret VARCHAR(64) NOT NULL,
+                                  confirmed_at TIMESTAMP NULL,
+                                  last_used_step BIGINT NULL,
+                                  last_used_claim VARCHAR(64) NULL,
+                                  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                  updated_at TIMESTAMP NULL,
+                                  CONSTRAINT fk_user_two_factors_users
+                                      FOREIGN KEY (user_id)
+                                          REFERENCES users (id)
+                                          ON DELETE CASCADE
+);
+
+-- Add indexes
+CREATE UNIQUE INDEX user_two_factors_user_id ON user_two_factors (user_id);
+
+-- -----------------------------------------------------
+-- Table user_recovery_codes
+-- -----------------------------------------------------
+CREATE TABLE user_recovery_codes (
+                                     id SERIAL PRIMARY KEY,
+                                     user_id INT NOT NULL,
+                                     code_hash VARCHAR(64) NOT NULL,
+                                     used_at TIMESTAMP NULL,
+                                     claim VARCHAR(64) NULL,
+                                     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                     CONSTRAINT fk_user_recovery_codes_users
+                                         FOREIGN KEY (user_id)
+                                             REFERENCES users (id)
+                                             ON DELETE CASCADE
+);
+
+-- Add indexes
+CREATE INDEX user_recovery_codes_user_id ON user_recovery_codes (user_id);
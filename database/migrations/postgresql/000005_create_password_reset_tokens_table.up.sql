@@ -0,0 +1,21 @@
+-- -----------------------------------------------------
+-- Table password_reset_tokens
+-- Only the SHA256 hash of a reset token is stored. A token can be used only once.
+-- -----------------------------------------------------
+CREATE TABLE password_reset_tokens (
+                                       id SERIAL PRIMARY KEY,
+                                       user_id INT NOT NULL,
+                                       token_hash VARCHAR(64) NOT NULL,
+                                       expires_at TIMESTAMP NOT NULL,
+                                       used_at TIMESTAMP NULL,
+                                       claim VARCHAR(64) NULL,
+                                       created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                       CONSTRAINT fk_password_reset_tokens_users
+                                           FOREIGN KEY (user_id)
+                                               REFERENCES users (id)
+                                               ON DELETE CASCADE
+);
+
+-- Add indexes
+CREATE UNIQUE INDEX password_reset_tokens_token_hash ON password_reset_tokens (token_hash);
+CREATE INDEX password_reset_tokens_user_id ON password_reset_tokens (user_id);
@@ -0,0 +1,17 @@
+-- -----------------------------------------------------
+-- Table password_histories
+-- Previous password hashes of a user, to block reusing them.
+-- -----------------------------------------------------
+CREATE TABLE password_histories (
+                                    id SERIAL PRIMARY KEY,
+                                    user_id INT NOT NULL,
+                                    password VARCHAR(255) NOT NULL,
+                                    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                    CONSTRAINT fk_password_histories_users
+                                        FOREIGN KEY (user_id)
+                                            REFERENCES users (id)
+                                            ON DELETE CASCADE
+);
+
+-- Add indexes
+CREATE INDEX password_histories_user_id ON password_histories (user_id);
@@ -0,0 +1,21 @@
+-- -----------------------------------------------------
+-- Table user_identities
+-- External accounts (OAuth2 / OpenID Connect) linked to users.
+-- -----------------------------------------------------
+CREATE TABLE user_identities (
+                                 id SERIAL PRIMARY KEY,
+                                 user_id INT NOT NULL,
+                                 provider VARCHAR(50) NOT NULL,
+                                 subject VARCHAR(255) NOT NULL,
+                                 email VARCHAR(255) NOT NULL,
+                                 created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                 updated_at TIMESTAMP NULL,
+                                 CONSTRAINT fk_user_identities_users
+                                     FOREIGN KEY (user_id)
+                                         REFERENCES users (id)
+                                         ON DELETE CASCADE
+);
+
+-- Add indexes
+CREATE UNIQUE INDEX user_identities_provider_subject ON user_identities (provider, subject);
+CREATE INDEX user_identities_user_id ON user_identities (user_id);
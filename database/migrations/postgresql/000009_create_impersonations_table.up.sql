@@ -0,0 +1,32 @@
+-- -----------------------------------------------------
+-- Table impersonations
+-- Audit trail of admins signed in as another user. One row per impersonation token.
+-- The trail outlives the users: their emails are kept when they are deleted.
+-- -----------------------------------------------------
+CREATE TABLE impersonations (
+                                id SERIAL PRIMARY KEY,
+                                session_id VARCHAR(36) NOT NULL,
+                                actor_id INT NULL,
+                                actor_email VARCHAR(255) NOT NULL,
+                                user_id INT NULL,
+                                user_email VARCHAR(255) NOT NULL,
+                                reason VARCHAR(255) NOT NULL DEFAULT '',
+                                user_agent VARCHAR(255) NULL,
+                                ip_address VARCHAR(45) NULL,
+                                started_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                                expires_at TIMESTAMP NOT NULL,
+                                ended_at TIMESTAMP NULL,
+                                CONSTRAINT fk_impersonations_actors
+                                    FOREIGN KEY (actor_id)
+                                        REFERENCES users (id)
+                                        ON DELETE SET NULL,
+                                CONSTRAINT fk_impersonations_users
+                                    FOREIGN KEY (user_id)
+                                        REFERENCES users (id)
+                                        ON DELETE SET NULL
+);
+
+-- Add indexes
+CREATE UNIQUE INDEX impersonations_session_id ON impersonations (session_id);
+CREATE INDEX impersonations_actor_id ON impersonations (actor_id);
+CREATE INDEX impersonations_user_id ON impersonations (user_id);
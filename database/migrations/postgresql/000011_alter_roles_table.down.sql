@@ -0,0 +1 @@
+DROP INDEX IF EXISTS roles_slug;
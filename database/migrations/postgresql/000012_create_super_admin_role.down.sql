@@ -0,0 +1 @@
+DELETE FROM roles WHERE slug = 'super-admin';
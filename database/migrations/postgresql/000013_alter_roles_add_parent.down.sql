@@ -0,0 +1,2 @@
+ALTER TABLE roles DROP CONSTRAINT IF EXISTS fk_roles_parents;
+ALTER TABLE roles DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,17 @@
+-- -----------------------------------------------------
+-- Table roles
+-- A role inherits all roles and permissions of its parent. Ex: `admin` > `moderator` > `member`
+-- -----------------------------------------------------
+ALTER TABLE roles ADD COLUMN parent_id INT NULL;
+ALTER TABLE roles ADD CONSTRAINT fk_roles_parents
+    FOREIGN KEY (parent_id)
+        REFERENCES roles (id)
+        ON DELETE SET NULL;
+
+-- --------------------------------------------------------------------------------------
+-- ------------------------------------ Initial data ------------------------------------
+-- --------------------------------------------------------------------------------------
+
+UPDATE roles SET parent_id = (SELECT parent.id FROM roles parent WHERE parent.slug = 'member') WHERE slug = 'moderator';
+UPDATE roles SET parent_id = (SELECT parent.id FROM roles parent WHERE parent.slug = 'moderator') WHERE slug = 'admin';
+UPDATE roles SET parent_id = (SELECT parent.id FROM roles parent WHERE parent.slug = 'admin') WHERE slug = 'super-admin';
@@ -0,0 +1,27 @@
+-- -----------------------------------------------------
+-- Table user_imports
+-- Bulk imports of users from CSV/XLSX files, processed by the queue worker.
+-- -----------------------------------------------------
+CREATE TABLE user_imports (
+                              id SERIAL PRIMARY KEY,
+                              actor_id INT NULL,
+                              file_name VARCHAR(255) NOT NULL,
+                              file_path VARCHAR(255) NOT NULL,
+                              report_path VARCHAR(255) NULL,
+                              status VARCHAR(20) NOT NULL DEFAULT 'pending',
+                              total_rows INT NOT NULL DEFAULT 0,
+                              processed_rows INT NOT NULL DEFAULT 0,
+                              created_rows INT NOT NULL DEFAULT 0,
+                              failed_rows INT NOT NULL DEFAULT 0,
+                              error VARCHAR(255) NULL,
+                              created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                              started_at TIMESTAMP NULL,
+                              finished_at TIMESTAMP NULL,
+                              CONSTRAINT fk_user_imports_actors
+                                  FOREIGN KEY (actor_id)
+                                      REFERENCES users (id)
+                                      ON DELETE SET NULL
+);
+
+-- Add indexes
+CREATE INDEX user_imports_actor_id ON user_imports (actor_id);
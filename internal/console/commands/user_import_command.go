@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"gfly/internal/console/queues"
+	"gfly/internal/services"
+	"path/filepath"
+	"time"
+
+	"github.com/gflydev/console"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/storage"
+)
+
+// ---------------------------------------------------------------
+//                        Register command.
+// ./artisan cmd:run user-import --file=storage/tmp/users.csv
+// ---------------------------------------------------------------
+
+// Auto-register command.
+func init() {
+	console.RegisterCommand(&userImportCommand{}, "user-import")
+}
+
+// ---------------------------------------------------------------
+//                     userImportCommand
+// ---------------------------------------------------------------
+
+// userImportCommand imports users from a CSV or XLSX file, like the upload API
+// `POST /users/imports` but without the queue worker.
+type userImportCommand struct {
+	console.Command
+	file string
+}
+
+// Validate checks the `--file` parameter.
+func (c *userImportCommand) Validate(parameters console.CommandParameter) error {
+	file, _ := parameters["file"].(string)
+	if file == "" {
+		return errors.New("missing parameter --file=<path of the .csv or .xlsx file>")
+	}
+
+	c.file = file
+
+	return nil
+}
+
+// Handle stores the file, imports its users then logs the result.
+func (c *userImportCommand) Handle() {
+	log.Infof("=== user-import: importing %s ===", c.file)
+
+	userImport, err := services.CreateUserImport(0, filepath.Base(c.file), c.file)
+	if err != nil {
+		log.Errorf("user-import: %v", err)
+		return
+	}
+
+	if err = queues.ProcessUserImport(userImport.ID); err != nil {
+		log.Errorf("user-import: %v", err)
+		return
+	}
+
+	userImport, _ = services.GetUserImport(userImport.ID)
+	if userImport.Error.Valid {
+		log.Errorf("user-import: %s", userImport.Error.String)
+		return
+	}
+
+	log.Infof("user-import: %d rows, %d users created, %d rows failed",
+		userImport.TotalRows, userImport.CreatedRows, userImport.FailedRows)
+
+	if userImport.ReportPath.Valid {
+		log.Infof("user-import: error report %s", storage.Instance().Url(userImport.ReportPath.String))
+	}
+
+	log.Infof("=== user-import: done at %s ===", time.Now().Format("2006-01-02 15:04:05"))
+}
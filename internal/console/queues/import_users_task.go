@@ -0,0 +1,544 @@
+package queues
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	userEvents "gfly/internal/domain/events"
+	"gfly/internal/domain/models"
+	"gfly/internal/domain/models/types"
+	"gfly/internal/domain/repository"
+	"gfly/internal/dto"
+	"gfly/pkg/modules/auth/password"
+	"gfly/pkg/utils"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gflydev/console"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/core/log"
+	coreUtils "github.com/gflydev/core/utils"
+	mb "github.com/gflydev/db"
+	dbNull "github.com/gflydev/db/null"
+	"github.com/gflydev/event"
+	"github.com/gflydev/http"
+	"github.com/gflydev/storage"
+)
+
+const (
+	// ImportMaxRows env key of the maximum number of rows of an import file.
+	ImportMaxRows = "USER_IMPORT_MAX_ROWS"
+	// ImportChunkSize env key of the number of users created by a transaction.
+	ImportChunkSize = "USER_IMPORT_CHUNK_SIZE"
+)
+
+// importColumns columns of an import file. The header row names them, in any order.
+var importColumns = []string{"email", "password", "fullname", "phone", "avatar", "status", "roles"}
+
+// importRequiredColumns columns which must be in the header row.
+var importRequiredColumns = []string{"email", "password", "fullname", "phone"}
+
+// ---------------------------------------------------------------
+//                        Register task.
+// ---------------------------------------------------------------
+
+// Auto-register task into queue.
+func init() {
+	console.RegisterTask(&ImportUsersTask{}, "import-users")
+}
+
+// ---------------------------------------------------------------
+//                        Task info.
+// ---------------------------------------------------------------
+
+// NewImportUsersTask creates a new queued task payload for importing users from an uploaded file.
+//
+// Parameters:
+//   - importID (int): The ID of the pending user import.
+//
+// Returns:
+//   - (ImportUsersPayload, string): The task payload and the registered task name.
+func NewImportUsersTask(importID int) (ImportUsersPayload, string) {
+	return ImportUsersPayload{
+		ImportID: importID,
+	}, "import-users"
+}
+
+// ImportUsersPayload holds the data required to import users.
+type ImportUsersPayload struct {
+	ImportID int `json:"import_id"`
+}
+
+// ImportUsersTask processes the import-users queue task.
+type ImportUsersTask struct {
+	console.Task
+}
+
+// Dequeue handles the queued user import.
+// A returned error makes the queue worker retry the task later. The retry resumes after
+// the last chunk saved by the previous attempt.
+//
+// Parameters:
+//   - task (*console.TaskPayload): The task payload from the queue.
+//
+// Returns:
+//   - error: Non-nil if the task fails to process.
+func (t ImportUsersTask) Dequeue(task *console.TaskPayload) error {
+	var payload ImportUsersPayload
+	if err := task.BindPayload(&payload); err != nil {
+		return errors.New("ImportUsersTask: failed to bind payload: %v", err)
+	}
+
+	return ProcessUserImport(payload.ImportID)
+}
+
+// ---------------------------------------------------------------
+//                        Processing.
+// ---------------------------------------------------------------
+
+// importRoles the roles which the rows of an import can grant.
+type importRoles struct {
+	bySlug     map[types.Role]models.Role // All roles by slug
+	all        []models.Role              // All roles (the role graph)
+	actorRoles []models.Role              // Roles of the uploader including inherited roles
+	restricted bool                       // Only roles below the uploader can be granted, false from the console
+}
+
+// importRow a data row of an import file.
+type importRow struct {
+	line int            // Line number in the file, starts from 1 with the header row
+	data dto.CreateUser // The user to create
+	err  string         // Why the row was not imported
+}
+
+// ProcessUserImport imports the users of a pending import file.
+//
+// Each row is validated with the rules of dto.CreateUser, then valid rows are created
+// with their roles in chunks (one transaction per chunk). The roles of a row must be below
+// the roles of the administrator who uploaded the file (see models.OutranksRoles), imports
+// from the console are not restricted. The progress is saved after each chunk, with a CSV
+// report of the rows which were not imported. An import which is already processing (retry)
+// resumes after its last saved chunk.
+//
+// Parameters:
+//   - importID (int): The ID of the user import.
+//
+// Returns:
+//   - error: Non-nil when the import can not be loaded or saved. An invalid file is not an error,
+//     the import is marked as failed.
+func ProcessUserImport(importID int) error {
+	userImport := repository.Pool.GetUserImportByID(importID)
+	if userImport == nil {
+		return errors.New("ImportUsersTask: import %d not found", importID)
+	}
+
+	// Already processed
+	if userImport.Status == types.UserImportStatusCompleted || userImport.Status == types.UserImportStatusFailed {
+		log.Infof("[Queue] ImportUsers: import %d is already %s", importID, userImport.Status)
+
+		return nil
+	}
+
+	rows, err := readImportFile(*userImport)
+	if err != nil {
+		return failUserImport(userImport, err)
+	}
+
+	// Roles can be created at runtime, so they are loaded for each import
+	roles := importRoles{
+		bySlug: make(map[types.Role]models.Role),
+		all:    repository.Pool.GetRoles(),
+	}
+	for _, role := range roles.all {
+		roles.bySlug[role.Slug] = role
+	}
+
+	if userImport.ActorID.Valid {
+		roles.restricted = true
+		roles.actorRoles = models.InheritRoles(roles.all, repository.Pool.GetRolesByUserID(int(userImport.ActorID.Int64)))
+	}
+
+	seenEmails := make(map[string]int)
+
+	// Users created by a previous attempt which stopped before saving its progress
+	var resumedSince sql.NullTime
+
+	if userImport.Status == types.UserImportStatusProcessing {
+		// Resume after the last saved chunk
+		userImport.ProcessedRows = min(userImport.ProcessedRows, len(rows))
+		restoreImportRows(*userImport, rows[:userImport.ProcessedRows], roles, seenEmails)
+		resumedSince = userImport.StartedAt
+	} else {
+		userImport.Status = types.UserImportStatusProcessing
+		userImport.TotalRows = len(rows)
+		userImport.StartedAt = dbNull.TimeNow()
+		if err = mb.UpdateModel(userImport); err != nil {
+			return errors.New("ImportUsersTask: failed to start import %d: %v", importID, err)
+		}
+	}
+
+	chunkSize := max(coreUtils.Getenv(ImportChunkSize, 100), 1)
+
+	for chunk := range slices.Chunk(rows[userImport.ProcessedRows:], chunkSize) {
+		importChunk(chunk, roles, seenEmails, resumedSince)
+
+		failed := false
+		for _, row := range chunk {
+			userImport.ProcessedRows++
+			if row.err != "" {
+				userImport.FailedRows++
+				failed = true
+			} else {
+				userImport.CreatedRows++
+			}
+		}
+
+		// The report is saved with the progress, a retry reads the errors of the previous chunks from it
+		if failed {
+			reportPath, err := writeImportReport(*userImport, rows)
+			if err != nil {
+				return errors.New("ImportUsersTask: failed to write report of import %d: %v", importID, err)
+			}
+			userImport.ReportPath = dbNull.String(reportPath)
+		}
+
+		if err = mb.UpdateModel(userImport); err != nil {
+			return errors.New("ImportUsersTask: failed to save progress of import %d: %v", importID, err)
+		}
+	}
+
+	userImport.Status = types.UserImportStatusCompleted
+	userImport.FinishedAt = dbNull.TimeNow()
+	if err = mb.UpdateModel(userImport); err != nil {
+		return errors.New("ImportUsersTask: failed to complete import %d: %v", importID, err)
+	}
+
+	log.Infof("[Queue] ImportUsers: import %d completed, %d created, %d failed",
+		importID, userImport.CreatedRows, userImport.FailedRows)
+
+	return nil
+}
+
+// importChunk validates the rows of a chunk then creates the valid users in a single transaction.
+// Rows which were not imported get their error.
+//
+// A previous attempt may have created the users of the chunk then stopped before saving its
+// progress. When resuming, an existing user created since the start of the import is counted
+// as created by the row, not as a duplicate.
+func importChunk(chunk []*importRow, roles importRoles, seenEmails map[string]int, resumedSince sql.NullTime) {
+	valid := checkImportRows(chunk, roles, seenEmails)
+	if len(valid) == 0 {
+		return
+	}
+
+	// Users which already exist
+	emails := make([]string, 0, len(valid))
+	for _, row := range valid {
+		emails = append(emails, strings.ToLower(row.data.Email))
+	}
+
+	existing := make(map[string]models.User)
+	for _, user := range repository.Pool.GetUsersByEmails(emails) {
+		existing[strings.ToLower(user.Email)] = user
+	}
+
+	var (
+		toCreate  []*importRow
+		users     []*models.User
+		userRoles [][]models.Role
+	)
+	for _, row := range valid {
+		if user, ok := existing[strings.ToLower(row.data.Email)]; ok {
+			if !resumedSince.Valid || user.CreatedAt.Before(resumedSince.Time) {
+				row.err = "email: the user with given email already exist"
+			}
+			continue
+		}
+
+		toCreate = append(toCreate, row)
+		users = append(users, newImportUser(row.data))
+
+		var rowRoles []models.Role
+		for _, slug := range row.data.Roles {
+			rowRoles = append(rowRoles, roles.bySlug[slug])
+		}
+		userRoles = append(userRoles, rowRoles)
+	}
+
+	if len(users) == 0 {
+		return
+	}
+
+	if err := repository.Pool.CreateUsers(users, userRoles); err != nil {
+		log.Errorf("[Queue] ImportUsers: error while creating users from line %d: %v", toCreate[0].line, err)
+
+		for _, row := range toCreate {
+			row.err = "error occurs while creating new user"
+		}
+
+		return
+	}
+
+	// Same side effects as a user created by an administrator
+	for _, user := range users {
+		_ = event.Dispatch(userEvents.UserRegistered{User: user})
+	}
+}
+
+// checkImportRows validates the rows with the rules of dto.CreateUser and checks that their
+// email addresses are unique in the file. It returns the valid rows, the others get their error.
+func checkImportRows(rows []*importRow, roles importRoles, seenEmails map[string]int) []*importRow {
+	var valid []*importRow
+	for _, row := range rows {
+		if row.err = validateImportRow(row.data, roles); row.err != "" {
+			continue
+		}
+
+		email := strings.ToLower(row.data.Email)
+		if line, ok := seenEmails[email]; ok {
+			row.err = fmt.Sprintf("email: duplicates the email of line %d", line)
+			continue
+		}
+		seenEmails[email] = row.line
+
+		valid = append(valid, row)
+	}
+
+	return valid
+}
+
+// restoreImportRows restores the rows processed by a previous attempt of an import.
+// Their email addresses are checked again for the next rows, and their errors are read
+// from the report of the previous attempt.
+func restoreImportRows(userImport models.UserImport, rows []*importRow, roles importRoles, seenEmails map[string]int) {
+	checkImportRows(rows, roles, seenEmails)
+
+	if !userImport.ReportPath.Valid {
+		return
+	}
+
+	content, err := storage.Instance().Get(userImport.ReportPath.String)
+	if err != nil {
+		log.Errorf("[Queue] ImportUsers: failed to read %s: %v", userImport.ReportPath.String, err)
+
+		return
+	}
+
+	lines, err := utils.ReadSpreadsheet(userImport.ReportPath.String, content)
+	if err != nil || len(lines) == 0 {
+		return
+	}
+
+	// Columns: line, email, error
+	reported := make(map[int]string)
+	for _, line := range lines[1:] {
+		if len(line) < 3 {
+			continue
+		}
+		if number, err := strconv.Atoi(line[0]); err == nil {
+			reported[number] = line[2]
+		}
+	}
+
+	for _, row := range rows {
+		if reason, ok := reported[row.line]; ok {
+			row.err = reason
+		}
+	}
+}
+
+// validateImportRow checks a row with the rules of dto.CreateUser.
+// It returns the validation messages, or an empty string for a valid row.
+func validateImportRow(data dto.CreateUser, roles importRoles) string {
+	if errData := http.Validate(data, password.MsgForTag); errData != nil {
+		fields := make([]string, 0, len(errData.Data))
+		for field := range errData.Data {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
+		messages := make([]string, 0, len(fields))
+		for _, field := range fields {
+			messages = append(messages, fmt.Sprintf("%s: %v", field, errData.Data[field]))
+		}
+
+		return strings.Join(messages, "; ")
+	}
+
+	if data.Status != "" && !slices.Contains(types.UserStatusList, types.UserStatus(data.Status)) {
+		return fmt.Sprintf("status: must be one of %s", strings.Join(types.UserStatusArrStr(types.UserStatusList...), ", "))
+	}
+
+	for _, slug := range data.Roles {
+		role, ok := roles.bySlug[slug]
+		if !ok {
+			return fmt.Sprintf("roles: role %v not found", slug)
+		}
+
+		// Same rule as services.CreateUser: the role and its ancestors are below the uploader
+		if roles.restricted && !models.OutranksRoles(roles.actorRoles, models.InheritRoles(roles.all, []models.Role{role})) {
+			return fmt.Sprintf("roles: role %v is not below the roles of the uploader", slug)
+		}
+	}
+
+	return ""
+}
+
+// newImportUser creates the model of an imported user (see services.CreateUser).
+func newImportUser(data dto.CreateUser) *models.User {
+	user := &models.User{
+		Status:       types.UserStatusActive,
+		Email:        strings.ToLower(data.Email),
+		Password:     coreUtils.GeneratePassword(data.Password),
+		Fullname:     data.Fullname,
+		Phone:        data.Phone,
+		Token:        dbNull.String(""),
+		CreatedAt:    time.Now(),
+		UpdatedAt:    dbNull.TimeNow(),
+		LastAccessAt: dbNull.TimeNow(),
+		Avatar:       dbNull.String(data.Avatar),
+	}
+
+	if data.Status != "" {
+		user.Status = types.UserStatus(data.Status)
+	}
+
+	return user
+}
+
+// readImportFile reads the data rows of an import file. Empty rows are skipped.
+func readImportFile(userImport models.UserImport) ([]*importRow, error) {
+	content, err := storage.Instance().Get(userImport.FilePath)
+	if err != nil {
+		log.Errorf("[Queue] ImportUsers: failed to read %s: %v", userImport.FilePath, err)
+
+		return nil, errors.New("the file could not be read")
+	}
+
+	lines, err := utils.ReadSpreadsheet(userImport.FileName, content)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lines) == 0 {
+		return nil, errors.New("the file is empty")
+	}
+
+	// Position of the columns in the header row
+	header := make(map[string]int)
+	for idx, name := range lines[0] {
+		name = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "_")
+		if slices.Contains(importColumns, name) {
+			header[name] = idx
+		}
+	}
+
+	for _, name := range importRequiredColumns {
+		if _, ok := header[name]; !ok {
+			return nil, errors.New("missing column %q", name)
+		}
+	}
+
+	var rows []*importRow
+	for idx, line := range lines[1:] {
+		if isEmptyLine(line) {
+			continue
+		}
+
+		value := func(name string) string {
+			column, ok := header[name]
+			if !ok || column >= len(line) {
+				return ""
+			}
+
+			return strings.TrimSpace(line[column])
+		}
+
+		data := dto.CreateUser{
+			Email:    value("email"),
+			Password: value("password"),
+			Fullname: value("fullname"),
+			Phone:    value("phone"),
+			Avatar:   value("avatar"),
+			Status:   value("status"),
+		}
+
+		// Sanitize row data
+		http.SanitizeStruct(&data)
+
+		// Many roles are separated by `,` or `|`
+		for _, role := range strings.FieldsFunc(value("roles"), func(r rune) bool { return r == ',' || r == '|' }) {
+			if role = strings.TrimSpace(role); role != "" {
+				data.Roles = append(data.Roles, types.Role(role))
+			}
+		}
+
+		rows = append(rows, &importRow{
+			line: idx + 2,
+			data: data,
+		})
+	}
+
+	if maxRows := coreUtils.Getenv(ImportMaxRows, 5000); len(rows) > maxRows {
+		return nil, errors.New("the file has %d rows, the maximum is %d", len(rows), maxRows)
+	}
+
+	return rows, nil
+}
+
+// writeImportReport writes the rows which were not imported to a CSV file in the storage.
+// It returns the path of the report.
+func writeImportReport(userImport models.UserImport, rows []*importRow) (string, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+
+	_ = writer.Write([]string{"line", "email", "error"})
+	for _, row := range rows {
+		if row.err != "" {
+			_ = writer.Write([]string{strconv.Itoa(row.line), row.data.Email, row.err})
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", err
+	}
+
+	reportPath := strings.TrimSuffix(userImport.FilePath, ".csv")
+	reportPath = strings.TrimSuffix(reportPath, ".xlsx") + "-report.csv"
+
+	if !storage.Instance().PutData(reportPath, buffer.Bytes()) {
+		return "", errors.New("failed to store %s", reportPath)
+	}
+
+	return reportPath, nil
+}
+
+// failUserImport marks an import as failed because its file could not be processed.
+func failUserImport(userImport *models.UserImport, reason error) error {
+	log.Warnf("[Queue] ImportUsers: import %d failed: %v", userImport.ID, reason)
+
+	userImport.Status = types.UserImportStatusFailed
+	userImport.Error = dbNull.String(reason.Error())
+	userImport.FinishedAt = dbNull.TimeNow()
+	if err := mb.UpdateModel(userImport); err != nil {
+		return errors.New("ImportUsersTask: failed to save import %d: %v", userImport.ID, err)
+	}
+
+	return nil
+}
+
+// isEmptyLine checks if all the cells of a line are blank.
+func isEmptyLine(line []string) bool {
+	for _, cell := range line {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,89 @@
+package queues
+
+import (
+	"gfly/internal/domain/models"
+	"gfly/internal/domain/repository"
+
+	"github.com/gflydev/console"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/core/log"
+	mb "github.com/gflydev/db"
+)
+
+// ---------------------------------------------------------------
+//                        Register task.
+// ---------------------------------------------------------------
+
+// Auto-register task into queue.
+func init() {
+	console.RegisterTask(&SyncUserIndexTask{}, "sync-user-index")
+}
+
+// ---------------------------------------------------------------
+//                        Task info.
+// ---------------------------------------------------------------
+
+// NewSyncUserIndexTask creates a new queued task payload for syncing a user into the search index.
+//
+// Parameters:
+//   - userID (int): The ID of the created, updated or deleted user.
+//
+// Returns:
+//   - (SyncUserIndexPayload, string): The task payload and the registered task name.
+func NewSyncUserIndexTask(userID int) (SyncUserIndexPayload, string) {
+	return SyncUserIndexPayload{
+		UserID: userID,
+	}, "sync-user-index"
+}
+
+// SyncUserIndexPayload holds the data required to sync a user into the search index.
+type SyncUserIndexPayload struct {
+	UserID int `json:"user_id"`
+}
+
+// SyncUserIndexTask processes the sync-user-index queue task.
+//
+// The task indexes the current state of the user from the database, so it does not
+// depend on the order of the events. Deleted users are removed from the index.
+type SyncUserIndexTask struct {
+	console.Task
+}
+
+// Dequeue handles the queued search index sync.
+// A returned error makes the queue worker retry the task later (e.g. Elasticsearch is down).
+//
+// Parameters:
+//   - task (*console.TaskPayload): The task payload from the queue.
+//
+// Returns:
+//   - error: Non-nil if the task fails to process.
+func (t SyncUserIndexTask) Dequeue(task *console.TaskPayload) error {
+	var payload SyncUserIndexPayload
+	if err := task.BindPayload(&payload); err != nil {
+		return errors.New("SyncUserIndexTask: failed to bind payload: %v", err)
+	}
+
+	user, err := mb.GetModelByID[models.User](payload.UserID)
+	if err != nil && !errors.Is(err, errors.ItemNotFound) {
+		return errors.New("SyncUserIndexTask: failed to load user %d: %v", payload.UserID, err)
+	}
+
+	// Purged or soft-deleted users are not searchable
+	if err != nil || user.DeletedAt.Valid {
+		if err = repository.Pool.RemoveUserIndex(payload.UserID); err != nil {
+			return errors.New("SyncUserIndexTask: failed to remove user %d from index: %v", payload.UserID, err)
+		}
+
+		log.Infof("[Queue] SyncUserIndex: removed user %d from index", payload.UserID)
+
+		return nil
+	}
+
+	if err = repository.Pool.IndexUser(*user); err != nil {
+		return errors.New("SyncUserIndexTask: failed to index user %d: %v", payload.UserID, err)
+	}
+
+	log.Infof("[Queue] SyncUserIndex: indexed user %d (%s)", user.ID, user.Email)
+
+	return nil
+}
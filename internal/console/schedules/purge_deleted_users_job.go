@@ -0,0 +1,43 @@
+package schedules
+
+import (
+	"gfly/internal/services"
+	"github.com/gflydev/console"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	"time"
+)
+
+// ---------------------------------------------------------------
+//                        Register job.
+// ---------------------------------------------------------------
+
+// Auto-register job into scheduler.
+func init() {
+	console.RegisterJob(&purgeDeletedUsersJob{})
+}
+
+// ---------------------------------------------------------------
+//                   PurgeDeletedUsersJob struct.
+// ---------------------------------------------------------------
+
+// purgeDeletedUsersJob struct permanently deletes users which stayed in the trash
+// longer than the retention period `USER_PURGE_RETENTION_DAYS`.
+type purgeDeletedUsersJob struct{}
+
+// GetTime Get time format. Runs daily at 03:00.
+func (c *purgeDeletedUsersJob) GetTime() string {
+	return "0 0 3 * * *"
+}
+
+// Handle Process the job.
+func (c *purgeDeletedUsersJob) Handle() {
+	retentionDays := utils.Getenv(services.PurgeRetentionDays, 30)
+
+	count, err := services.PurgeDeletedUsers(time.Duration(retentionDays) * 24 * time.Hour)
+	if err != nil {
+		log.Errorf("PurgeDeletedUsersJob :: Error '%v'", err)
+	}
+
+	log.Infof("PurgeDeletedUsersJob :: Purged %d users deleted more than %d days ago", count, retentionDays)
+}
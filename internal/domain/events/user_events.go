@@ -1,4 +1,6 @@
-package user
+// Package events defines the domain events of the users. The events are kept apart from
+// their listeners (gfly/internal/events/user), so that queue tasks can dispatch them too.
+package events
 
 import (
 	"gfly/internal/domain/models"
@@ -15,7 +17,8 @@ const (
 	// EventUserUpdated fires when an existing user's profile data has been modified.
 	EventUserUpdated = "user.updated"
 
-	// EventUserDeleted fires when a user has been removed from the system.
+	// EventUserDeleted fires when a user has been permanently removed from the system (purged).
+	// A soft delete fires EventUserUpdated.
 	EventUserDeleted = "user.deleted"
 )
 
@@ -41,7 +44,7 @@ type UserUpdated struct {
 // EventName returns the unique event identifier.
 func (e UserUpdated) EventName() string { return EventUserUpdated }
 
-// UserDeleted is dispatched after a user has been deleted from the system.
+// UserDeleted is dispatched after a user has been permanently deleted from the system.
 type UserDeleted struct {
 	// UserID is the ID of the deleted user.
 	UserID int
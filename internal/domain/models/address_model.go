@@ -0,0 +1,43 @@
+package models
+
+import (
+	"database/sql"
+	"gfly/internal/domain/models/types"
+	mb "github.com/gflydev/db"
+	"time"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// N/A
+
+// ====================================================================
+// ============================== Table ===============================
+// ====================================================================
+
+// TableAddress Table name
+const TableAddress = "address"
+
+// Address struct to describe an address of a user.
+type Address struct {
+	// Table meta data
+	MetaData mb.MetaData `db:"-" model:"table:address"`
+
+	// Table fields
+	ID           int               `db:"id" model:"name:id; type:serial,primary"`
+	UserID       sql.NullInt64     `db:"user_id" model:"name:user_id"`
+	Type         types.AddressType `db:"type" model:"name:type"`
+	IsDefault    bool              `db:"is_default" model:"name:is_default"`
+	AddressLine1 string            `db:"address_line1" model:"name:address_line1"`
+	AddressLine2 sql.NullString    `db:"address_line2" model:"name:address_line2"`
+	Ward         sql.NullString    `db:"ward" model:"name:ward"`
+	District     sql.NullString    `db:"district" model:"name:district"`
+	City         sql.NullString    `db:"city" model:"name:city"`
+	State        sql.NullString    `db:"state" model:"name:state"`
+	Country      sql.NullString    `db:"country" model:"name:country"`
+	CreatedAt    time.Time         `db:"created_at" model:"name:created_at"`
+	UpdatedAt    sql.NullTime      `db:"updated_at" model:"name:updated_at"`
+	DeletedAt    sql.NullTime      `db:"deleted_at" model:"name:deleted_at"`
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"database/sql"
+	mb "github.com/gflydev/db"
+	"time"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// N/A
+
+// ====================================================================
+// ============================== Table ===============================
+// ====================================================================
+
+// TableImpersonation Table name
+const TableImpersonation = "impersonations"
+
+// Impersonation struct to describe an admin (the actor) signed in as another user.
+// The IDs become NULL when the users are deleted, their emails are kept for the audit trail.
+type Impersonation struct {
+	// Table meta data
+	MetaData mb.MetaData `db:"-" model:"table:impersonations"`
+
+	// Table fields
+	ID         int            `db:"id" model:"name:id; type:serial,primary"`
+	SessionID  string         `db:"session_id" model:"name:session_id"` // The `sid` claim of the impersonation token
+	ActorID    sql.NullInt64  `db:"actor_id" model:"name:actor_id"`
+	ActorEmail string         `db:"actor_email" model:"name:actor_email"`
+	UserID     sql.NullInt64  `db:"user_id" model:"name:user_id"`
+	UserEmail  string         `db:"user_email" model:"name:user_email"`
+	Reason     string         `db:"reason" model:"name:reason"`
+	UserAgent  sql.NullString `db:"user_agent" model:"name:user_agent"`
+	IPAddress  sql.NullString `db:"ip_address" model:"name:ip_address"`
+	StartedAt  time.Time      `db:"started_at" model:"name:started_at"`
+	ExpiresAt  time.Time      `db:"expires_at" model:"name:expires_at"`
+	EndedAt    sql.NullTime   `db:"ended_at" model:"name:ended_at"`
+}
+
+// ====================================================================
+// ============================= Methods ==============================
+// ====================================================================
+
+// IsActive reports whether the impersonation was neither stopped nor expired.
+func (i Impersonation) IsActive() bool {
+	return !i.EndedAt.Valid && i.ExpiresAt.After(time.Now())
+}
+
+// IsBetween reports whether the impersonation is of the user `userID` by the admin `actorID`.
+// It is false once one of them was deleted.
+func (i Impersonation) IsBetween(userID, actorID int) bool {
+	return i.UserID.Valid && i.UserID.Int64 == int64(userID) &&
+		i.ActorID.Valid && i.ActorID.Int64 == int64(actorID)
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	mb "github.com/gflydev/db"
+	"time"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// N/A
+
+// ====================================================================
+// ============================== Table ===============================
+// ====================================================================
+
+// TablePasswordHistory Table name
+const TablePasswordHistory = "password_histories"
+
+// PasswordHistory struct to describe a previous password of a user.
+type PasswordHistory struct {
+	// Table meta data
+	MetaData mb.MetaData `db:"-" model:"table:password_histories"`
+
+	// Table fields
+	ID        int       `db:"id" model:"name:id; type:serial,primary"`
+	UserID    int       `db:"user_id" model:"name:user_id"`
+	Password  string    `db:"password" model:"name:password"` // #nosec G117 -- Hashed previous password
+	CreatedAt time.Time `db:"created_at" model:"name:created_at"`
+}
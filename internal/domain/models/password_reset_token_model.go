@@ -0,0 +1,41 @@
+package models
+
+import (
+	"database/sql"
+	mb "github.com/gflydev/db"
+	"time"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// N/A
+
+// ====================================================================
+// ============================== Table ===============================
+// ====================================================================
+
+// TablePasswordResetToken Table name
+const TablePasswordResetToken = "password_reset_tokens"
+
+// PasswordResetToken struct to describe a password reset token of a user.
+// A token expires and can be used only once.
+type PasswordResetToken struct {
+	// Table meta data
+	MetaData mb.MetaData `db:"-" model:"table:password_reset_tokens"`
+
+	// Table fields
+	ID        int            `db:"id" model:"name:id; type:serial,primary"`
+	UserID    int            `db:"user_id" model:"name:user_id"`
+	TokenHash string         `db:"token_hash" model:"name:token_hash"` // SHA256 hash of the reset token
+	ExpiresAt time.Time      `db:"expires_at" model:"name:expires_at"`
+	UsedAt    sql.NullTime   `db:"used_at" model:"name:used_at"`
+	Claim     sql.NullString `db:"claim" model:"name:claim"` // Random value of the request which used the token
+	CreatedAt time.Time      `db:"created_at" model:"name:created_at"`
+}
+
+// IsUsable reports whether the token was neither used nor expired.
+func (t PasswordResetToken) IsUsable() bool {
+	return !t.UsedAt.Valid && time.Now().Before(t.ExpiresAt)
+}
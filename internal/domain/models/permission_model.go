@@ -0,0 +1,35 @@
+package models
+
+import (
+	"database/sql"
+	"gfly/internal/domain/models/types"
+	mb "github.com/gflydev/db"
+	"time"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// TBD
+
+// ====================================================================
+// ============================== Table ===============================
+// ====================================================================
+
+// TablePermission Table name
+const TablePermission = "permissions"
+
+// Permission struct to describe a permission object.
+type Permission struct {
+	// Table meta data
+	MetaData mb.MetaData `db:"-" model:"table:permissions"`
+
+	// Table fields
+	ID          int              `db:"id" model:"name:id; type:serial,primary"`
+	Name        string           `db:"name" model:"name:name"`
+	Slug        types.Permission `db:"slug" model:"name:slug"`
+	Description sql.NullString   `db:"description" model:"name:description"`
+	CreatedAt   time.Time        `db:"created_at" model:"name:created_at"`
+	UpdatedAt   sql.NullTime     `db:"updated_at" model:"name:updated_at"`
+}
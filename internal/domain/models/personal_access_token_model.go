@@ -0,0 +1,48 @@
+package models
+
+import (
+	"database/sql"
+	mb "github.com/gflydev/db"
+	"strings"
+	"time"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// N/A
+
+// ====================================================================
+// ============================== Table ===============================
+// ====================================================================
+
+// TablePersonalAccessToken Table name
+const TablePersonalAccessToken = "personal_access_tokens"
+
+// PersonalAccessToken struct to describe a long-lived API token of a user.
+type PersonalAccessToken struct {
+	// Table meta data
+	MetaData mb.MetaData `db:"-" model:"table:personal_access_tokens"`
+
+	// Table fields
+	ID         int          `db:"id" model:"name:id; type:serial,primary"`
+	UserID     int          `db:"user_id" model:"name:user_id"`
+	Name       string       `db:"name" model:"name:name"`
+	TokenHash  string       `db:"token_hash" model:"name:token_hash"` // SHA256 hash of the token
+	Scopes     string       `db:"scopes" model:"name:scopes"`         // Space separated scopes
+	ExpiresAt  sql.NullTime `db:"expires_at" model:"name:expires_at"` // NULL never expires
+	LastUsedAt sql.NullTime `db:"last_used_at" model:"name:last_used_at"`
+	CreatedAt  time.Time    `db:"created_at" model:"name:created_at"`
+	UpdatedAt  sql.NullTime `db:"updated_at" model:"name:updated_at"`
+}
+
+// IsActive reports whether the token is not expired.
+func (t PersonalAccessToken) IsActive() bool {
+	return !t.ExpiresAt.Valid || t.ExpiresAt.Time.After(time.Now())
+}
+
+// ScopeList returns the scopes of the token.
+func (t PersonalAccessToken) ScopeList() []string {
+	return strings.Fields(t.Scopes)
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"gfly/internal/domain/models/types"
 	mb "github.com/gflydev/db"
+	"slices"
 	"time"
 )
 
@@ -26,9 +27,72 @@ type Role struct {
 	MetaData mb.MetaData `db:"-" model:"table:roles"`
 
 	// Table fields
-	ID        int          `db:"id" model:"name:id; type:serial,primary"`
-	Name      string       `db:"name" model:"name:name"`
-	Slug      types.Role   `db:"slug" model:"name:slug"`
-	CreatedAt time.Time    `db:"created_at" model:"name:created_at"`
-	UpdatedAt sql.NullTime `db:"updated_at" model:"name:updated_at"`
+	ID        int           `db:"id" model:"name:id; type:serial,primary"`
+	Name      string        `db:"name" model:"name:name"`
+	Slug      types.Role    `db:"slug" model:"name:slug"`
+	ParentID  sql.NullInt32 `db:"parent_id" model:"name:parent_id"`
+	CreatedAt time.Time     `db:"created_at" model:"name:created_at"`
+	UpdatedAt sql.NullTime  `db:"updated_at" model:"name:updated_at"`
+}
+
+// ====================================================================
+// ========================== Role hierarchy ==========================
+// ====================================================================
+
+// InheritRoles resolves the roles inherited through parents by the granted roles.
+// A broken hierarchy (cycle) does not loop forever: each role is visited once.
+//
+// Parameters:
+//   - roles ([]Role): All roles (the role graph)
+//   - granted ([]Role): The roles granted to a user
+//
+// Returns:
+//   - []Role: Granted roles followed by their ancestors, without duplicates
+func InheritRoles(roles []Role, granted []Role) []Role {
+	roleByID := make(map[int]Role, len(roles))
+	for _, role := range roles {
+		roleByID[role.ID] = role
+	}
+
+	visited := make(map[int]bool)
+	result := make([]Role, 0, len(granted))
+
+	for _, role := range granted {
+		for current, ok := role, true; ok && !visited[current.ID]; current, ok = roleByID[int(current.ParentID.Int32)] {
+			visited[current.ID] = true
+			result = append(result, current)
+		}
+	}
+
+	return result
+}
+
+// OutranksRoles checks if the actor roles are strictly above the target roles: the actor holds
+// every role of the target and at least one more. Ex: `admin` outranks `moderator` (it inherits it)
+// but neither another `admin` nor a `super-admin`.
+//
+// Parameters:
+//   - actorRoles ([]Role): The roles of the actor, including inherited roles
+//   - targetRoles ([]Role): The roles of the target, including inherited roles
+//
+// Returns:
+//   - bool: True if the actor roles outrank the target roles, false otherwise
+func OutranksRoles(actorRoles, targetRoles []Role) bool {
+	actorRoleIDs := make([]int, 0, len(actorRoles))
+	for _, role := range actorRoles {
+		actorRoleIDs = append(actorRoleIDs, role.ID)
+	}
+
+	targetRoleIDs := make([]int, 0, len(targetRoles))
+	for _, role := range targetRoles {
+		if !slices.Contains(actorRoleIDs, role.ID) {
+			return false
+		}
+
+		if !slices.Contains(targetRoleIDs, role.ID) {
+			targetRoleIDs = append(targetRoleIDs, role.ID)
+		}
+	}
+
+	return len(actorRoleIDs) > len(targetRoleIDs)
 }
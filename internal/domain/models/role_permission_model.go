@@ -0,0 +1,31 @@
+package models
+
+import (
+	mb "github.com/gflydev/db"
+	"time"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// TBD
+
+// ====================================================================
+// ============================== Table ===============================
+// ====================================================================
+
+// TableRolePermission Table name
+const TableRolePermission = "role_permissions"
+
+// RolePermission struct to describe a role permission object.
+type RolePermission struct {
+	// Table meta data
+	MetaData mb.MetaData `db:"-" model:"table:role_permissions"`
+
+	// Table fields
+	ID           int       `db:"id" model:"name:id; type:serial,primary"`
+	RoleID       int       `db:"role_id" model:"name:role_id; type:int"`
+	PermissionID int       `db:"permission_id" model:"name:permission_id; type:int"`
+	CreatedAt    time.Time `db:"created_at" model:"name:created_at"`
+}
@@ -0,0 +1,14 @@
+package types
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+type AddressType string
+
+// Address property types
+const (
+	AddressTypeAddress  AddressType = "address"
+	AddressTypeBilling  AddressType = "billing"
+	AddressTypeShipping AddressType = "shipping"
+)
@@ -0,0 +1,28 @@
+package types
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// ------------------------- Permission slug --------------------------
+
+type Permission string
+
+// Permission slugs seeded by the migration `create_permissions_tables`
+const (
+	PermissionUsersRead        Permission = "users.read"
+	PermissionUsersCreate      Permission = "users.create"
+	PermissionUsersUpdate      Permission = "users.update"
+	PermissionUsersDelete      Permission = "users.delete"
+	PermissionUsersImpersonate Permission = "users.impersonate"
+	PermissionRolesManage      Permission = "roles.manage"
+)
+
+var PermissionList = []Permission{
+	PermissionUsersRead,
+	PermissionUsersCreate,
+	PermissionUsersUpdate,
+	PermissionUsersDelete,
+	PermissionUsersImpersonate,
+	PermissionRolesManage,
+}
@@ -0,0 +1,15 @@
+package types
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+type UserImportStatus string
+
+// User import property types
+const (
+	UserImportStatusPending    UserImportStatus = "pending"    // Waiting for the queue worker
+	UserImportStatusProcessing UserImportStatus = "processing" // Rows are being imported
+	UserImportStatusCompleted  UserImportStatus = "completed"  // All rows were processed, some of them may have failed
+	UserImportStatusFailed     UserImportStatus = "failed"     // The file could not be processed
+)
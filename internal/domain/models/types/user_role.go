@@ -1,5 +1,7 @@
 package types
 
+import "slices"
+
 // ====================================================================
 // ============================ Data Types ============================
 // ====================================================================
@@ -8,54 +10,31 @@ package types
 
 type Role string
 
-// User property types
+// Built-in roles. They are seeded by the migration `create_init_tables` and protected:
+// a system role can not be deleted. Other roles are created at runtime via `/roles` API.
 const (
-	RoleNA        Role = "na"
-	RoleAdmin     Role = "admin"
-	RoleModerator Role = "moderator"
-	RoleMember    Role = "member"
-	RoleGuest     Role = "guest"
+	RoleSuperAdmin Role = "super-admin"
+	RoleAdmin      Role = "admin"
+	RoleModerator  Role = "moderator"
+	RoleMember     Role = "member"
+	RoleGuest      Role = "guest"
 )
 
-var RoleList = []Role{
-	RoleNA,
+var SystemRoleList = []Role{
+	RoleSuperAdmin,
 	RoleAdmin,
 	RoleModerator,
 	RoleMember,
 	RoleGuest,
 }
 
-// ----------------------------- Role ID ------------------------------
-
-type RoleID uint
-
-// Role ID and id match with the database.
-const (
-	RoleNAID RoleID = iota
-	RoleAdminID
-	RoleModeratorID
-	RoleMemberID
-	RoleGuestID
-)
-
-var RoleMap = map[Role]RoleID{
-	RoleNA:        RoleNAID,
-	RoleAdmin:     RoleAdminID,
-	RoleModerator: RoleModeratorID,
-	RoleMember:    RoleMemberID,
-	RoleGuest:     RoleGuestID,
-}
-
 // ====================================================================
 // ============================= Methods ==============================
 // ====================================================================
 
-func (e RoleID) Name() Role {
-	return RoleList[e]
-}
-
-func (e Role) ID() RoleID {
-	return RoleMap[e]
+// IsSystem checks if the role is a built-in (protected) role.
+func (e Role) IsSystem() bool {
+	return slices.Contains(SystemRoleList, e)
 }
 
 // roleCollection represents a collection of Role values.
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"database/sql"
+	mb "github.com/gflydev/db"
+	"time"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// N/A
+
+// ====================================================================
+// ============================== Table ===============================
+// ====================================================================
+
+// TableUserIdentity Table name
+const TableUserIdentity = "user_identities"
+
+// UserIdentity struct to describe an external account (OAuth2 / OpenID Connect) linked to a user.
+type UserIdentity struct {
+	// Table meta data
+	MetaData mb.MetaData `db:"-" model:"table:user_identities"`
+
+	// Table fields
+	ID        int          `db:"id" model:"name:id; type:serial,primary"`
+	UserID    int          `db:"user_id" model:"name:user_id"`
+	Provider  string       `db:"provider" model:"name:provider"` // Provider name. Ex: `oidc`
+	Subject   string       `db:"subject" model:"name:subject"`   // The `sub` claim of the ID token
+	Email     string       `db:"email" model:"name:email"`
+	CreatedAt time.Time    `db:"created_at" model:"name:created_at"`
+	UpdatedAt sql.NullTime `db:"updated_at" model:"name:updated_at"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"database/sql"
+	"gfly/internal/domain/models/types"
+	mb "github.com/gflydev/db"
+	"time"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// N/A
+
+// ====================================================================
+// ============================== Table ===============================
+// ====================================================================
+
+// TableUserImport Table name
+const TableUserImport = "user_imports"
+
+// UserImport struct to describe a bulk import of users from a CSV or XLSX file.
+// The progress is updated by the queue worker after each chunk of rows.
+type UserImport struct {
+	// Table meta data
+	MetaData mb.MetaData `db:"-" model:"table:user_imports"`
+
+	// Table fields
+	ID            int                    `db:"id" model:"name:id; type:serial,primary"`
+	ActorID       sql.NullInt64          `db:"actor_id" model:"name:actor_id"` // The admin who uploaded the file, NULL from the console
+	FileName      string                 `db:"file_name" model:"name:file_name"`
+	FilePath      string                 `db:"file_path" model:"name:file_path"`     // Path of the uploaded file in the storage
+	ReportPath    sql.NullString         `db:"report_path" model:"name:report_path"` // Path of the per-row error report in the storage
+	Status        types.UserImportStatus `db:"status" model:"name:status"`
+	TotalRows     int                    `db:"total_rows" model:"name:total_rows"`
+	ProcessedRows int                    `db:"processed_rows" model:"name:processed_rows"`
+	CreatedRows   int                    `db:"created_rows" model:"name:created_rows"`
+	FailedRows    int                    `db:"failed_rows" model:"name:failed_rows"`
+	Error         sql.NullString         `db:"error" model:"name:error"` // Why the file could not be processed
+	CreatedAt     time.Time              `db:"created_at" model:"name:created_at"`
+	StartedAt     sql.NullTime           `db:"started_at" model:"name:started_at"`
+	FinishedAt    sql.NullTime           `db:"finished_at" model:"name:finished_at"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"database/sql"
+	mb "github.com/gflydev/db"
+	"time"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// N/A
+
+// ====================================================================
+// ============================== Table ===============================
+// ====================================================================
+
+// TableUserRefreshToken Table name
+const TableUserRefreshToken = "user_refresh_tokens"
+
+// UserRefreshToken struct to describe a refresh token of a session.
+// All tokens of a session form a family; a token can be used only once.
+type UserRefreshToken struct {
+	// Table meta data
+	MetaData mb.MetaData `db:"-" model:"table:user_refresh_tokens"`
+
+	// Table fields
+	ID         int            `db:"id" model:"name:id; type:serial,primary"`
+	SessionID  string         `db:"session_id" model:"name:session_id"`
+	TokenHash  string         `db:"token_hash" model:"name:token_hash"` // SHA256 hash of the refresh token
+	ExpiresAt  time.Time      `db:"expires_at" model:"name:expires_at"`
+	UsedAt     sql.NullTime   `db:"used_at" model:"name:used_at"`
+	ReplacedBy sql.NullString `db:"replaced_by" model:"name:replaced_by"` // Hash of the token which rotated this one
+	CreatedAt  time.Time      `db:"created_at" model:"name:created_at"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"database/sql"
+	mb "github.com/gflydev/db"
+	"time"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// N/A
+
+// ====================================================================
+// ============================== Table ===============================
+// ====================================================================
+
+// TableUserSession Table name
+const TableUserSession = "user_sessions"
+
+// UserSession struct to describe a signed-in device of a user.
+// Each sign-in creates its own session which is the family of the refresh tokens issued for the device.
+type UserSession struct {
+	// Table meta data
+	MetaData mb.MetaData `db:"-" model:"table:user_sessions"`
+
+	// Table fields
+	ID         string         `db:"id" model:"name:id; type:varchar,primary"`
+	UserID     int            `db:"user_id" model:"name:user_id; type:int"`
+	UserAgent  sql.NullString `db:"user_agent" model:"name:user_agent"`
+	IPAddress  sql.NullString `db:"ip_address" model:"name:ip_address"`
+	ExpiresAt  time.Time      `db:"expires_at" model:"name:expires_at"`
+	LastUsedAt sql.NullTime   `db:"last_used_at" model:"name:last_used_at"`
+	RevokedAt  sql.NullTime   `db:"revoked_at" model:"name:revoked_at"`
+	CreatedAt  time.Time      `db:"created_at" model:"name:created_at"`
+	UpdatedAt  sql.NullTime   `db:"updated_at" model:"name:updated_at"`
+}
+
+// ====================================================================
+// ============================= Methods ==============================
+// ====================================================================
+
+// IsActive reports whether the session was neither revoked nor expired.
+func (s UserSession) IsActive() bool {
+	return !s.RevokedAt.Valid && s.ExpiresAt.After(time.Now())
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"database/sql"
+	mb "github.com/gflydev/db"
+	"time"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// N/A
+
+// ====================================================================
+// ============================== Table ===============================
+// ====================================================================
+
+// TableUserTwoFactor Table name
+const TableUserTwoFactor = "user_two_factors"
+
+// UserTwoFactor struct to describe the TOTP (RFC 6238) enrollment of a user.
+// Two-factor authentication is enabled once the enrollment is confirmed.
+type UserTwoFactor struct {
+	// Table meta data
+	MetaData mb.MetaData `db:"-" model:"table:user_two_factors"`
+
+	// Table fields
+	ID            int            `db:"id" model:"name:id; type:serial,primary"`
+	UserID        int            `db:"user_id" model:"name:user_id; type:int"`
+	Secret        string         `db:"secret" model:"name:secret"` // Base32 TOTP secret
+	ConfirmedAt   sql.NullTime   `db:"confirmed_at" model:"name:confirmed_at"`
+	LastUsedStep  sql.NullInt64  `db:"last_used_step" model:"name:last_used_step"`   // Time step of the last accepted code
+	LastUsedClaim sql.NullString `db:"last_used_claim" model:"name:last_used_claim"` // Random value of the request which used the step
+	CreatedAt     time.Time      `db:"created_at" model:"name:created_at"`
+	UpdatedAt     sql.NullTime   `db:"updated_at" model:"name:updated_at"`
+}
+
+// TableUserRecoveryCode Table name
+const TableUserRecoveryCode = "user_recovery_codes"
+
+// UserRecoveryCode struct to describe a one-time recovery code of the two-factor authentication.
+type UserRecoveryCode struct {
+	// Table meta data
+	MetaData mb.MetaData `db:"-" model:"table:user_recovery_codes"`
+
+	// Table fields
+	ID        int            `db:"id" model:"name:id; type:serial,primary"`
+	UserID    int            `db:"user_id" model:"name:user_id; type:int"`
+	CodeHash  string         `db:"code_hash" model:"name:code_hash"` // SHA256 hash of the recovery code
+	UsedAt    sql.NullTime   `db:"used_at" model:"name:used_at"`
+	Claim     sql.NullString `db:"claim" model:"name:claim"` // Random value of the request which used the code
+	CreatedAt time.Time      `db:"created_at" model:"name:created_at"`
+}
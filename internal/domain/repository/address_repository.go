@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"gfly/internal/domain/models"
+	"github.com/gflydev/core/log"
+	mb "github.com/gflydev/db" // Model builder
+)
+
+// ====================================================================
+// ======================= Repository Interface =======================
+// ====================================================================
+
+// IAddressRepository defines the interface for managing the addresses of users.
+//
+// Methods:
+//   - GetAddressesByUserID(userID int) []models.Address: Retrieves the addresses of a user.
+//   - GetAddressesByUserIDs(userIDs []int) map[int][]models.Address: Retrieves the addresses of many users at once.
+type IAddressRepository interface {
+	// GetAddressesByUserID retrieves the addresses (which are not deleted) of a user.
+	//
+	// Parameters:
+	//   - userID (int): The ID of the user
+	//
+	// Returns:
+	//   - ([]models.Address): The addresses, the default ones first.
+	GetAddressesByUserID(userID int) []models.Address
+
+	// GetAddressesByUserIDs retrieves the addresses (which are not deleted) of many users with a single query.
+	//
+	// Parameters:
+	//   - userIDs ([]int): The IDs of the users
+	//
+	// Returns:
+	//   - (map[int][]models.Address): The addresses keyed by user ID, the default ones first.
+	GetAddressesByUserIDs(userIDs []int) map[int][]models.Address
+}
+
+// ====================================================================
+// ====================== Repository Implement ========================
+// ====================================================================
+
+// addressRepository struct for queries from an Address model.
+// The struct is an implementation of interface IAddressRepository
+type addressRepository struct{}
+
+// GetAddressesByUserID retrieves the addresses of a user.
+func (r *addressRepository) GetAddressesByUserID(userID int) []models.Address {
+	return r.GetAddressesByUserIDs([]int{userID})[userID]
+}
+
+// GetAddressesByUserIDs retrieves the addresses of many users at once.
+func (r *addressRepository) GetAddressesByUserIDs(userIDs []int) map[int][]models.Address {
+	result := make(map[int][]models.Address, len(userIDs))
+	if len(userIDs) == 0 {
+		return result
+	}
+
+	var addresses []models.Address
+
+	_, err := mb.Instance().
+		Where("user_id", mb.In, userIDs).
+		Where("deleted_at", mb.Null, nil).
+		OrderBy("is_default", mb.Desc).
+		OrderBy("id", mb.Asc).
+		Find(&addresses)
+
+	if err != nil {
+		log.Error(err)
+
+		return result
+	}
+
+	for _, address := range addresses {
+		userID := int(address.UserID.Int64)
+		result[userID] = append(result[userID], address)
+	}
+
+	return result
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"gfly/internal/domain/models"
+	mb "github.com/gflydev/db" // Model builder
+)
+
+// ====================================================================
+// ======================= Repository Interface =======================
+// ====================================================================
+
+// IImpersonationRepository defines the interface for managing the impersonation audit trail.
+//
+// Methods:
+//   - GetImpersonationBySessionID(sessionID string) *models.Impersonation: Retrieves the impersonation of a token.
+type IImpersonationRepository interface {
+	// GetImpersonationBySessionID retrieves the impersonation of a token.
+	//
+	// Parameters:
+	//   - sessionID (string): The `sid` claim of the impersonation token
+	//
+	// Returns:
+	//   - (*models.Impersonation): The impersonation, or nil if not found.
+	GetImpersonationBySessionID(sessionID string) *models.Impersonation
+}
+
+// ====================================================================
+// ====================== Repository Implement ========================
+// ====================================================================
+
+// impersonationRepository struct for queries from an Impersonation model.
+// The struct is an implementation of interface IImpersonationRepository
+type impersonationRepository struct{}
+
+// GetImpersonationBySessionID retrieves the impersonation of a token.
+func (r *impersonationRepository) GetImpersonationBySessionID(sessionID string) *models.Impersonation {
+	impersonation, err := mb.GetModelBy[models.Impersonation]("session_id", sessionID)
+	if err != nil {
+		return nil
+	}
+
+	return impersonation
+}
@@ -7,11 +7,35 @@ package repository
 // Repositories struct for collect all app repositories.
 type Repositories struct {
 	IRoleRepository
+	IPermissionRepository
 	IUserRepository
+	IUserSessionRepository
+	IUserRefreshTokenRepository
+	IUserTwoFactorRepository
+	IPasswordResetTokenRepository
+	IPasswordHistoryRepository
+	IPersonalAccessTokenRepository
+	IUserIdentityRepository
+	IImpersonationRepository
+	IUserIndexRepository
+	IUserImportRepository
+	IAddressRepository
 }
 
 // Pool a repository pool to store all
 var Pool = &Repositories{
 	&roleRepository{},
+	&permissionRepository{},
 	&userRepository{},
+	&userSessionRepository{},
+	&userRefreshTokenRepository{},
+	&userTwoFactorRepository{},
+	&passwordResetTokenRepository{},
+	&passwordHistoryRepository{},
+	&personalAccessTokenRepository{},
+	&userIdentityRepository{},
+	&impersonationRepository{},
+	&userIndexRepository{},
+	&userImportRepository{},
+	&addressRepository{},
 }
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"gfly/internal/domain/models"
+	"github.com/gflydev/core/log"
+	mb "github.com/gflydev/db" // Model builder
+)
+
+// ====================================================================
+// ======================= Repository Interface =======================
+// ====================================================================
+
+// IPasswordHistoryRepository defines the interface for managing previous passwords of users.
+//
+// Methods:
+//   - GetPasswordHistories(userID, limit int) []models.PasswordHistory: Retrieves the latest previous passwords of a user.
+//   - PrunePasswordHistories(userID, keep int) error: Removes the previous passwords of a user except the latest ones.
+type IPasswordHistoryRepository interface {
+	// GetPasswordHistories retrieves the latest previous passwords of a user.
+	//
+	// Parameters:
+	//   - userID (int): The unique identifier of the user
+	//   - limit (int): The maximum number of passwords
+	//
+	// Returns:
+	//   - ([]models.PasswordHistory): Previous passwords, the most recent first.
+	GetPasswordHistories(userID, limit int) []models.PasswordHistory
+
+	// PrunePasswordHistories removes the previous passwords of a user except the latest ones.
+	//
+	// Parameters:
+	//   - userID (int): The unique identifier of the user
+	//   - keep (int): The number of latest passwords to keep
+	//
+	// Returns:
+	//   - error: Returns nil on success, error on failure
+	PrunePasswordHistories(userID, keep int) error
+}
+
+// ====================================================================
+// ====================== Repository Implement ========================
+// ====================================================================
+
+// passwordHistoryRepository struct for queries from a PasswordHistory model.
+// The struct is an implementation of interface IPasswordHistoryRepository
+type passwordHistoryRepository struct{}
+
+// GetPasswordHistories retrieves the latest previous passwords of a user.
+func (r *passwordHistoryRepository) GetPasswordHistories(userID, limit int) []models.PasswordHistory {
+	var histories []models.PasswordHistory
+
+	_, err := mb.Instance().
+		Where("user_id", mb.Eq, userID).
+		OrderBy("id", mb.Desc).
+		Limit(limit, 0).
+		Find(&histories)
+
+	if err != nil {
+		log.Error(err)
+	}
+
+	return histories
+}
+
+// PrunePasswordHistories removes the previous passwords of a user except the latest ones.
+func (r *passwordHistoryRepository) PrunePasswordHistories(userID, keep int) error {
+	var histories []models.PasswordHistory
+
+	if _, err := mb.Instance().
+		Where("user_id", mb.Eq, userID).
+		OrderBy("id", mb.Desc).
+		Find(&histories); err != nil {
+		return err
+	}
+
+	if len(histories) <= keep {
+		return nil
+	}
+
+	ids := make([]int, 0, len(histories)-keep)
+	for _, history := range histories[keep:] {
+		ids = append(ids, history.ID)
+	}
+
+	return mb.Instance().Where("id", mb.In, ids).Delete(&models.PasswordHistory{})
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"gfly/internal/domain/models"
+	"github.com/gflydev/core/utils"
+	mb "github.com/gflydev/db" // Model builder
+	dbNull "github.com/gflydev/db/null"
+)
+
+// ====================================================================
+// ======================= Repository Interface =======================
+// ====================================================================
+
+// IPasswordResetTokenRepository defines the interface for managing password reset tokens.
+//
+// Methods:
+//   - GetPasswordResetTokenByHash(tokenHash string) *models.PasswordResetToken: Retrieves a reset token by its hash.
+//   - UsePasswordResetToken(resetToken *models.PasswordResetToken) (bool, error): Marks a reset token as used once.
+//   - DeletePasswordResetTokensByUserID(userID int) error: Removes all reset tokens of a user.
+type IPasswordResetTokenRepository interface {
+	// GetPasswordResetTokenByHash retrieves a reset token by its SHA256 hash.
+	//
+	// Parameters:
+	//   - tokenHash (string): The hash of the reset token
+	//
+	// Returns:
+	//   - (*models.PasswordResetToken): The reset token, or nil if not found.
+	GetPasswordResetTokenByHash(tokenHash string) *models.PasswordResetToken
+
+	// UsePasswordResetToken marks a reset token as used, if it was not used yet.
+	//
+	// Parameters:
+	//   - resetToken (*models.PasswordResetToken): The reset token
+	//
+	// Returns:
+	//   - (bool): False when the token was already used, also by a concurrent request.
+	//   - error: Returns nil on success, error on failure
+	UsePasswordResetToken(resetToken *models.PasswordResetToken) (bool, error)
+
+	// DeletePasswordResetTokensByUserID removes all reset tokens of a user.
+	//
+	// Parameters:
+	//   - userID (int): The unique identifier of the user
+	//
+	// Returns:
+	//   - error: Returns nil on success, error on failure
+	DeletePasswordResetTokensByUserID(userID int) error
+}
+
+// ====================================================================
+// ====================== Repository Implement ========================
+// ====================================================================
+
+// passwordResetTokenRepository struct for queries from a PasswordResetToken model.
+// The struct is an implementation of interface IPasswordResetTokenRepository
+type passwordResetTokenRepository struct{}
+
+// GetPasswordResetTokenByHash retrieves a reset token by its SHA256 hash.
+func (r *passwordResetTokenRepository) GetPasswordResetTokenByHash(tokenHash string) *models.PasswordResetToken {
+	resetToken, err := mb.GetModelBy[models.PasswordResetToken]("token_hash", tokenHash)
+	if err != nil {
+		return nil
+	}
+
+	return resetToken
+}
+
+// UsePasswordResetToken marks a reset token as used with a conditional update (`used_at IS NULL`).
+// The builder does not report the affected rows, so the token is read back: the call whose
+// claim was stored is the one which used the token.
+func (r *passwordResetTokenRepository) UsePasswordResetToken(resetToken *models.PasswordResetToken) (bool, error) {
+	claim := utils.Token()
+
+	resetToken.UsedAt = dbNull.TimeNow()
+	resetToken.Claim = dbNull.String(claim)
+
+	err := mb.Instance().
+		Where("token_hash", mb.Eq, resetToken.TokenHash).
+		Where("used_at", mb.Null, nil).
+		Update(resetToken)
+	if err != nil {
+		return false, err
+	}
+
+	storedToken, err := mb.GetModelByID[models.PasswordResetToken](resetToken.ID)
+	if err != nil {
+		return false, err
+	}
+
+	return storedToken.Claim.Valid && storedToken.Claim.String == claim, nil
+}
+
+// DeletePasswordResetTokensByUserID removes all reset tokens of a user.
+func (r *passwordResetTokenRepository) DeletePasswordResetTokensByUserID(userID int) error {
+	return mb.Instance().Where("user_id", mb.Eq, userID).Delete(&models.PasswordResetToken{})
+}
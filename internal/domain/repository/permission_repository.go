@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"gfly/internal/domain/models"
+	"gfly/internal/domain/models/types"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/try"
+	"time"
+
+	mb "github.com/gflydev/db" // Model builder
+)
+
+// ====================================================================
+// ======================= Repository Interface =======================
+// ====================================================================
+
+// IPermissionRepository defines the interface for managing permissions of roles.
+//
+// Methods:
+//   - GetPermissions() []models.Permission: Retrieves all permissions.
+//   - GetPermissionsByRoleIDs(roleIDs ...int) []models.Permission: Retrieves permissions granted to roles.
+//   - SyncPermissionsWithRole(roleID int, permissionSlugs ...types.Permission) error:
+//     Synchronizes the permissions of a role.
+type IPermissionRepository interface {
+	// GetPermissions retrieves all permissions.
+	//
+	// Returns:
+	//   - []models.Permission: All permissions ordered by slug
+	GetPermissions() []models.Permission
+
+	// GetPermissionsByRoleIDs retrieves the permissions granted to any of the given roles.
+	//
+	// Parameters:
+	//   - roleIDs (...int): The IDs of the roles
+	//
+	// Returns:
+	//   - []models.Permission: Distinct permissions ordered by slug
+	GetPermissionsByRoleIDs(roleIDs ...int) []models.Permission
+
+	// SyncPermissionsWithRole replaces the permissions of a role.
+	//
+	// Parameters:
+	//   - roleID (int): The unique identifier of the role.
+	//   - permissionSlugs (...types.Permission): The slugs of the permissions to grant.
+	//
+	// Returns:
+	//   - (error): An error if the synchronization fails.
+	SyncPermissionsWithRole(roleID int, permissionSlugs ...types.Permission) (err error)
+}
+
+// ====================================================================
+// ====================== Repository Implement ========================
+// ====================================================================
+
+// permissionRepository struct for queries from a Permission model.
+// The struct is an implementation of interface IPermissionRepository
+type permissionRepository struct{}
+
+// GetPermissions retrieves all permissions.
+func (q *permissionRepository) GetPermissions() []models.Permission {
+	var permissions []models.Permission
+
+	_, err := mb.Instance().
+		OrderBy("slug", mb.Asc).
+		Limit(1000, 0).
+		Find(&permissions)
+
+	if err != nil {
+		log.Error(err)
+	}
+
+	// For case empty list => return an empty list
+	if permissions == nil {
+		permissions = []models.Permission{}
+	}
+
+	return permissions
+}
+
+// GetPermissionsByRoleIDs retrieves the permissions granted to any of the given roles.
+func (q *permissionRepository) GetPermissionsByRoleIDs(roleIDs ...int) []models.Permission {
+	var permissions []models.Permission
+
+	if len(roleIDs) == 0 {
+		return []models.Permission{}
+	}
+
+	_, err := mb.Instance().Select(models.TablePermission+".*").
+		Join(mb.InnerJoin, models.TableRolePermission, mb.Condition{
+			Field: models.TablePermission + ".id",
+			Opt:   mb.Eq,
+			Value: mb.ValueField(models.TableRolePermission + ".permission_id"),
+		}).
+		Where(models.TableRolePermission+".role_id", mb.In, roleIDs).
+		OrderBy(models.TablePermission+".slug", mb.Asc).
+		Find(&permissions)
+
+	if err != nil {
+		log.Error(err)
+	}
+
+	return uniquePermissions(permissions)
+}
+
+// SyncPermissionsWithRole replaces the permissions of a role.
+func (q *permissionRepository) SyncPermissionsWithRole(roleID int, permissionSlugs ...types.Permission) (err error) {
+	// DB Model instance
+	db := mb.Instance()
+
+	try.Perform(func() {
+		db.Begin()
+
+		// Remove old permissions of the role.
+		if err := db.Where("role_id", mb.Eq, roleID).Delete(&models.RolePermission{}); err != nil {
+			try.Throw(err)
+		}
+
+		for _, permission := range q.getPermissionsBySlug(permissionSlugs...) {
+			rolePermission := models.RolePermission{
+				RoleID:       roleID,
+				PermissionID: permission.ID,
+				CreatedAt:    time.Now(),
+			}
+
+			if err := db.Create(&rolePermission); err != nil {
+				try.Throw(err)
+			}
+		}
+
+		// Commit the transaction to the database.
+		err = db.Commit()
+	}).Catch(func(e try.E) {
+		err = e.(error)
+		_ = db.Rollback() // Rollback the transaction in case of failure.
+	})
+
+	// Permissions of the role's users changed
+	FlushRolesCache()
+
+	return err
+}
+
+// getPermissionsBySlug retrieves the permissions matching the given slugs.
+func (q *permissionRepository) getPermissionsBySlug(permissionSlugs ...types.Permission) []models.Permission {
+	var permissions []models.Permission
+
+	if len(permissionSlugs) == 0 {
+		return []models.Permission{}
+	}
+
+	slugs := make([]string, 0, len(permissionSlugs))
+	for _, slug := range permissionSlugs {
+		slugs = append(slugs, string(slug))
+	}
+
+	_, err := mb.Instance().
+		Where("slug", mb.In, slugs).
+		Limit(1000, 0).
+		Find(&permissions)
+
+	if err != nil {
+		log.Error(err)
+	}
+
+	return permissions
+}
+
+// uniquePermissions removes duplicated permissions granted by several roles.
+// The order of the list is kept.
+func uniquePermissions(permissions []models.Permission) []models.Permission {
+	seen := make(map[int]bool, len(permissions))
+	result := make([]models.Permission, 0, len(permissions))
+
+	for _, permission := range permissions {
+		if seen[permission.ID] {
+			continue
+		}
+
+		seen[permission.ID] = true
+		result = append(result, permission)
+	}
+
+	return result
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"gfly/internal/domain/models"
+	"github.com/gflydev/core/log"
+	mb "github.com/gflydev/db" // Model builder
+)
+
+// ====================================================================
+// ======================= Repository Interface =======================
+// ====================================================================
+
+// IPersonalAccessTokenRepository defines the interface for managing personal access tokens.
+//
+// Methods:
+//   - GetAccessTokenByHash(tokenHash string) *models.PersonalAccessToken: Retrieves a token by its hash.
+//   - GetAccessTokensByUserID(userID int) []models.PersonalAccessToken: Retrieves all tokens of a user.
+type IPersonalAccessTokenRepository interface {
+	// GetAccessTokenByHash retrieves a personal access token by its SHA256 hash.
+	//
+	// Parameters:
+	//   - tokenHash (string): The hash of the token
+	//
+	// Returns:
+	//   - (*models.PersonalAccessToken): The token, or nil if not found.
+	GetAccessTokenByHash(tokenHash string) *models.PersonalAccessToken
+
+	// GetAccessTokensByUserID retrieves all personal access tokens of a user.
+	//
+	// Parameters:
+	//   - userID (int): The unique identifier of the user
+	//
+	// Returns:
+	//   - ([]models.PersonalAccessToken): Tokens, the most recent first.
+	GetAccessTokensByUserID(userID int) []models.PersonalAccessToken
+}
+
+// ====================================================================
+// ====================== Repository Implement ========================
+// ====================================================================
+
+// personalAccessTokenRepository struct for queries from a PersonalAccessToken model.
+// The struct is an implementation of interface IPersonalAccessTokenRepository
+type personalAccessTokenRepository struct{}
+
+// GetAccessTokenByHash retrieves a personal access token by its SHA256 hash.
+func (r *personalAccessTokenRepository) GetAccessTokenByHash(tokenHash string) *models.PersonalAccessToken {
+	accessToken, err := mb.GetModelBy[models.PersonalAccessToken]("token_hash", tokenHash)
+	if err != nil {
+		return nil
+	}
+
+	return accessToken
+}
+
+// GetAccessTokensByUserID retrieves all personal access tokens of a user.
+func (r *personalAccessTokenRepository) GetAccessTokensByUserID(userID int) []models.PersonalAccessToken {
+	var accessTokens []models.PersonalAccessToken
+
+	_, err := mb.Instance().
+		Where("user_id", mb.Eq, userID).
+		OrderBy("id", mb.Desc).
+		Find(&accessTokens)
+
+	if err != nil {
+		log.Error(err)
+	}
+
+	// For case empty list => return an empty list
+	if accessTokens == nil {
+		accessTokens = []models.PersonalAccessToken{}
+	}
+
+	return accessTokens
+}
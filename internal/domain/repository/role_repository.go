@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"gfly/internal/domain/models"
 	"gfly/internal/domain/models/types"
+	"github.com/gflydev/cache"
 	"github.com/gflydev/core/errors"
 	"github.com/gflydev/core/log"
 	"github.com/gflydev/core/try"
@@ -18,17 +19,56 @@ import (
 
 // IRoleRepository defines the interface for managing user roles.
 // It provides methods for retrieving and assigning roles to users.
+// Roles are identified by their slug, so roles created at runtime work the same as built-in roles.
 //
 // Methods:
+//   - GetRoles() []models.Role: Retrieves all roles.
+//   - GetRoleByID(roleID int) *models.Role: Retrieves a role by its ID.
+//   - GetRoleBySlug(roleSlug types.Role) *models.Role: Retrieves a role by its slug.
+//   - CountUsersByRole() map[int]int: Counts the users of each role.
 //   - GetRolesByUserID(userID int) []models.Role: Retrieves roles of specific user.
 //     Retrieves all roles associated with a specific user ID.
+//   - GetRolesByUserIDs(userIDs []int) map[int][]models.Role: Retrieves roles of many users at once.
 //   - GetRolesBySlug(roleSlugs ...types.Role) []models.Role:
 //     Retrieves a list of roles that match the specified slug values.
 //   - AddRoleForUserID(userID int, roleSlug types.Role) error: Add a role via slug for user.
 //     Creates a new user-role mapping in the database.
 //   - SyncRolesWithUser(userID int, roleSlugs ...types.Role) (err error):
 //     Synchronizes roles for a given user, associating the user with the specified roles.
+//   - AssignRoleToUser(userID, roleID int) error: Assigns a role to a user.
+//   - RevokeRoleFromUser(userID, roleID int) error: Revokes a role from a user.
+//   - DeleteRole(roleID, reassignRoleID int) error: Deletes a role and moves its users to another role.
 type IRoleRepository interface {
+	// GetRoles retrieves all roles ordered by name.
+	//
+	// Returns:
+	//   - []models.Role: Slice of Role models
+	GetRoles() []models.Role
+
+	// GetRoleByID retrieves a role by its ID.
+	//
+	// Parameters:
+	//   - roleID (int): The unique identifier of the role
+	//
+	// Returns:
+	//   - *models.Role: The role, or nil if it is not found
+	GetRoleByID(roleID int) *models.Role
+
+	// GetRoleBySlug retrieves a role by its slug.
+	//
+	// Parameters:
+	//   - roleSlug (types.Role): The slug of the role
+	//
+	// Returns:
+	//   - *models.Role: The role, or nil if it is not found
+	GetRoleBySlug(roleSlug types.Role) *models.Role
+
+	// CountUsersByRole counts the users of each role.
+	//
+	// Returns:
+	//   - map[int]int: Number of users keyed by role ID. Roles without users are missing.
+	CountUsersByRole() map[int]int
+
 	// GetRolesByUserID retrieves all roles associated with the given user ID.
 	// Returns a slice of Role models containing the role data.
 	//
@@ -39,6 +79,16 @@ type IRoleRepository interface {
 	//   - []models.Role: Slice of Role models containing the role data
 	GetRolesByUserID(userID int) []models.Role
 
+	// GetRolesByUserIDs retrieves the roles of many users with a constant number of queries.
+	// Use it instead of GetRolesByUserID when a list of users is processed.
+	//
+	// Parameters:
+	//   - userIDs ([]int): The unique identifiers of the users
+	//
+	// Returns:
+	//   - map[int][]models.Role: Roles ordered by name keyed by user ID. Users without roles are missing.
+	GetRolesByUserIDs(userIDs []int) map[int][]models.Role
+
 	// GetRolesBySlug retrieves a list of roles that match the specified slug values.
 	// Parameters:
 	//   - roleSlugs (...types.Role): A variadic parameter representing the slugs to filter roles by.
@@ -57,8 +107,7 @@ type IRoleRepository interface {
 	//   - error: Returns nil on success, error on failure
 	AddRoleForUserID(userID int, roleSlug types.Role) error
 
-	// SyncRolesWithUser synchronizes ro
-	// les for a given user.
+	// SyncRolesWithUser synchronizes roles for a given user.
 	// It associates the user with the specified roles by slug.
 	// Parameters:
 	//   - userID (int): The unique identifier of the user.
@@ -66,6 +115,37 @@ type IRoleRepository interface {
 	// Returns:
 	//   - (error): An error if the synchronization fails.
 	SyncRolesWithUser(userID int, roleSlugs ...types.Role) (err error)
+
+	// AssignRoleToUser assigns a role to a user. Nothing is done if the user already has the role.
+	//
+	// Parameters:
+	//   - userID (int): The unique identifier of the user.
+	//   - roleID (int): The unique identifier of the role.
+	//
+	// Returns:
+	//   - error: Returns nil on success, error on failure
+	AssignRoleToUser(userID, roleID int) error
+
+	// RevokeRoleFromUser revokes a role from a user.
+	//
+	// Parameters:
+	//   - userID (int): The unique identifier of the user.
+	//   - roleID (int): The unique identifier of the role.
+	//
+	// Returns:
+	//   - error: Returns nil on success, error on failure
+	RevokeRoleFromUser(userID, roleID int) error
+
+	// DeleteRole deletes a role in a transaction. Users of the role are moved to
+	// the reassigned role (if they do not have it yet) before the deletion.
+	//
+	// Parameters:
+	//   - roleID (int): The unique identifier of the role to delete.
+	//   - reassignRoleID (int): The role which receives the users. 0 to only revoke the role.
+	//
+	// Returns:
+	//   - error: Returns nil on success, error on failure
+	DeleteRole(roleID, reassignRoleID int) (err error)
 }
 
 // ====================================================================
@@ -76,6 +156,77 @@ type IRoleRepository interface {
 // The struct is an implementation of interface IRoleRepository
 type roleRepository struct{}
 
+// roleUserCount struct to receive the number of users of a role.
+type roleUserCount struct {
+	// Table meta data
+	MetaData mb.MetaData `db:"-" model:"table:user_roles"`
+
+	// Table fields
+	RoleID int `db:"role_id" model:"name:role_id"`
+	Total  int `db:"total" model:"name:total"`
+}
+
+// GetRoles query for getting all roles.
+func (q *roleRepository) GetRoles() []models.Role {
+	var roles []models.Role
+
+	_, err := mb.Instance().
+		OrderBy("name", mb.Asc).
+		Limit(1000, 0).
+		Find(&roles)
+
+	if err != nil {
+		log.Error(err)
+	}
+
+	// For case empty list => return an empty list
+	if roles == nil {
+		roles = []models.Role{}
+	}
+
+	return roles
+}
+
+// GetRoleByID query for getting a role by given ID.
+func (q *roleRepository) GetRoleByID(roleID int) *models.Role {
+	role, err := mb.GetModelByID[models.Role](roleID)
+	if err != nil {
+		return nil
+	}
+
+	return role
+}
+
+// GetRoleBySlug query for getting a role by given slug.
+func (q *roleRepository) GetRoleBySlug(roleSlug types.Role) *models.Role {
+	role, err := mb.GetModelBy[models.Role]("slug", roleSlug)
+	if err != nil {
+		return nil
+	}
+
+	return role
+}
+
+// CountUsersByRole query for counting users of each role.
+func (q *roleRepository) CountUsersByRole() map[int]int {
+	var counts []roleUserCount
+
+	_, err := mb.Instance().Select("role_id", "COUNT(user_id) AS total").
+		GroupBy("role_id").
+		Find(&counts)
+
+	if err != nil {
+		log.Error(err)
+	}
+
+	result := make(map[int]int, len(counts))
+	for _, count := range counts {
+		result[count.RoleID] = count.Total
+	}
+
+	return result
+}
+
 // GetRolesByUserID query for getting roles by given user ID.
 func (q *roleRepository) GetRolesByUserID(userID int) []models.Role {
 	// Define role variable.
@@ -99,6 +250,62 @@ func (q *roleRepository) GetRolesByUserID(userID int) []models.Role {
 	return roles
 }
 
+// GetRolesByUserIDs query for getting roles of many users at once.
+func (q *roleRepository) GetRolesByUserIDs(userIDs []int) map[int][]models.Role {
+	result := make(map[int][]models.Role, len(userIDs))
+	if len(userIDs) == 0 {
+		return result
+	}
+
+	var userRoles []models.UserRole
+
+	_, err := mb.Instance().
+		Where("user_id", mb.In, userIDs).
+		Find(&userRoles)
+
+	if err != nil {
+		log.Error(err)
+
+		return result
+	}
+
+	// User IDs keyed by role ID
+	roleUsers := make(map[int][]int)
+	roleIDs := make([]int, 0, len(userRoles))
+	for _, userRole := range userRoles {
+		if _, ok := roleUsers[userRole.RoleID]; !ok {
+			roleIDs = append(roleIDs, userRole.RoleID)
+		}
+		roleUsers[userRole.RoleID] = append(roleUsers[userRole.RoleID], userRole.UserID)
+	}
+
+	if len(roleIDs) == 0 {
+		return result
+	}
+
+	var roles []models.Role
+
+	_, err = mb.Instance().
+		Where("id", mb.In, roleIDs).
+		OrderBy("name", mb.Asc).
+		Find(&roles)
+
+	if err != nil {
+		log.Error(err)
+
+		return result
+	}
+
+	// Roles are ordered by name, so the roles of each user are too.
+	for _, role := range roles {
+		for _, userID := range roleUsers[role.ID] {
+			result[userID] = append(result[userID], role)
+		}
+	}
+
+	return result
+}
+
 // GetRolesBySlug retrieves a list of roles that match the specified slug values.
 // Parameters:
 //   - roleSlugs (...types.Role): A variadic parameter representing the slugs to filter roles by.
@@ -140,26 +347,12 @@ func Convert[T fmt.Stringer](items []T) []string {
 // AddRoleForUserID query for adding role for given user ID.
 func (q *roleRepository) AddRoleForUserID(userID int, roleSlug types.Role) error {
 	// Get a role by slug
-	role, err := mb.GetModel[models.Role](mb.Condition{
-		Field: models.TableRole + ".slug",
-		Opt:   mb.Eq,
-		Value: roleSlug,
-	})
-	if err != nil || role == nil {
-		log.Error(err)
-
+	role := q.GetRoleBySlug(roleSlug)
+	if role == nil {
 		return errors.New("Role not found")
 	}
 
-	// Create a new user
-	userRole := models.UserRole{
-		ID:        userID,
-		RoleID:    role.ID,
-		UserID:    userID,
-		CreatedAt: time.Now(),
-	}
-
-	return mb.CreateModel(&userRole)
+	return q.AssignRoleToUser(userID, role.ID)
 }
 
 // SyncRolesWithUser synchronizes roles for a given user by associating the user with the specified roles.
@@ -203,5 +396,144 @@ func (q *roleRepository) SyncRolesWithUser(userID int, roleSlugs ...types.Role)
 		_ = db.Rollback() // Rollback the transaction in case of failure.
 	})
 
+	// Inherited roles and permissions of the user must be resolved again
+	ForgetUserRoles(userID)
+
 	return err
 }
+
+// AssignRoleToUser query for assigning a role to given user ID.
+func (q *roleRepository) AssignRoleToUser(userID, roleID int) error {
+	// The user already has the role
+	if _, err := mb.GetModel[models.UserRole](
+		mb.Condition{Field: "user_id", Opt: mb.Eq, Value: userID},
+		mb.Condition{Field: "role_id", Opt: mb.Eq, Value: roleID},
+	); err == nil {
+		return nil
+	}
+
+	defer ForgetUserRoles(userID)
+
+	return mb.CreateModel(&models.UserRole{
+		RoleID:    roleID,
+		UserID:    userID,
+		CreatedAt: time.Now(),
+	})
+}
+
+// RevokeRoleFromUser query for revoking a role from given user ID.
+func (q *roleRepository) RevokeRoleFromUser(userID, roleID int) error {
+	defer ForgetUserRoles(userID)
+
+	return mb.Instance().
+		Where("user_id", mb.Eq, userID).
+		Where("role_id", mb.Eq, roleID).
+		Delete(&models.UserRole{})
+}
+
+// DeleteRole query for deleting a role and moving its users to the reassigned role.
+func (q *roleRepository) DeleteRole(roleID, reassignRoleID int) (err error) {
+	// DB Model instance
+	db := mb.Instance()
+
+	try.Perform(func() {
+		db.Begin()
+
+		if reassignRoleID > 0 {
+			var userRoles []models.UserRole
+			if _, err := db.Where("role_id", mb.In, []int{roleID, reassignRoleID}).
+				Limit(100000, 0).
+				Find(&userRoles); err != nil {
+				try.Throw(err)
+			}
+
+			// Users who already have the reassigned role
+			reassigned := make(map[int]bool)
+			for _, userRole := range userRoles {
+				if userRole.RoleID == reassignRoleID {
+					reassigned[userRole.UserID] = true
+				}
+			}
+
+			for _, userRole := range userRoles {
+				if userRole.RoleID != roleID || reassigned[userRole.UserID] {
+					continue
+				}
+
+				if err := db.Create(&models.UserRole{
+					RoleID:    reassignRoleID,
+					UserID:    userRole.UserID,
+					CreatedAt: time.Now(),
+				}); err != nil {
+					try.Throw(err)
+				}
+				reassigned[userRole.UserID] = true
+			}
+		}
+
+		// Remove the role from its users and permissions.
+		if err := db.Where("role_id", mb.Eq, roleID).Delete(&models.UserRole{}); err != nil {
+			try.Throw(err)
+		}
+		if err := db.Where("role_id", mb.Eq, roleID).Delete(&models.RolePermission{}); err != nil {
+			try.Throw(err)
+		}
+
+		if err := db.Where("id", mb.Eq, roleID).Delete(&models.Role{}); err != nil {
+			try.Throw(err)
+		}
+
+		// Commit the transaction to the database.
+		err = db.Commit()
+	}).Catch(func(e try.E) {
+		err = e.(error)
+		_ = db.Rollback() // Rollback the transaction in case of failure.
+	})
+
+	// The role graph changed for all users
+	FlushRolesCache()
+
+	return err
+}
+
+// ====================================================================
+// ============================== Cache ===============================
+// ====================================================================
+
+// rolesVersionKey cache key of the role graph version. Bumping it invalidates
+// the inherited roles and permissions cached for all users.
+const rolesVersionKey = "roles:version"
+
+// UserRolesCacheKey builds the cache key of the roles (direct and inherited) of a user.
+func UserRolesCacheKey(userID int) string {
+	return fmt.Sprintf("user_roles:%s:%d", rolesVersion(), userID)
+}
+
+// UserPermissionsCacheKey builds the cache key of the permissions of a user.
+func UserPermissionsCacheKey(userID int) string {
+	return fmt.Sprintf("user_permissions:%s:%d", rolesVersion(), userID)
+}
+
+// ForgetUserRoles removes the roles and permissions cached for a user.
+func ForgetUserRoles(userID int) {
+	_ = cache.Del(UserRolesCacheKey(userID))
+	_ = cache.Del(UserPermissionsCacheKey(userID))
+}
+
+// FlushRolesCache invalidates the roles and permissions cached for all users.
+// It must be called when the role graph changes (parent, permissions or deletion of a role).
+func FlushRolesCache() {
+	if err := cache.Set(rolesVersionKey, fmt.Sprint(time.Now().UnixNano()), 0); err != nil {
+		log.Errorf("Flush roles cache error '%v'", err)
+	}
+}
+
+// rolesVersion reads the current role graph version.
+func rolesVersion() string {
+	version, err := cache.Get(rolesVersionKey)
+	if err != nil || version == nil {
+		return "0"
+	}
+
+	return fmt.Sprint(version)
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"gfly/internal/domain/models"
+	mb "github.com/gflydev/db" // Model builder
+)
+
+// ====================================================================
+// ======================= Repository Interface =======================
+// ====================================================================
+
+// IUserIdentityRepository defines the interface for managing external accounts linked to users.
+//
+// Methods:
+//   - GetUserIdentity(provider, subject string) *models.UserIdentity: Retrieves the link of an external account.
+type IUserIdentityRepository interface {
+	// GetUserIdentity retrieves the link of an external account.
+	//
+	// Parameters:
+	//   - provider (string): The provider name
+	//   - subject (string): The account ID at the provider (`sub` claim)
+	//
+	// Returns:
+	//   - (*models.UserIdentity): The link, or nil if not found.
+	GetUserIdentity(provider, subject string) *models.UserIdentity
+}
+
+// ====================================================================
+// ====================== Repository Implement ========================
+// ====================================================================
+
+// userIdentityRepository struct for queries from a UserIdentity model.
+// The struct is an implementation of interface IUserIdentityRepository
+type userIdentityRepository struct{}
+
+// GetUserIdentity retrieves the link of an external account.
+func (r *userIdentityRepository) GetUserIdentity(provider, subject string) *models.UserIdentity {
+	var identity models.UserIdentity
+
+	err := mb.Instance().
+		Where("provider", mb.Eq, provider).
+		Where("subject", mb.Eq, subject).
+		First(&identity)
+
+	if err != nil {
+		return nil
+	}
+
+	return &identity
+}
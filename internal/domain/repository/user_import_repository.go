@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"gfly/internal/domain/models"
+	mb "github.com/gflydev/db" // Model builder
+)
+
+// ====================================================================
+// ======================= Repository Interface =======================
+// ====================================================================
+
+// IUserImportRepository defines the interface for managing the bulk imports of users.
+//
+// Methods:
+//   - GetUserImportByID(importID int) *models.UserImport: Retrieves an import by its ID.
+type IUserImportRepository interface {
+	// GetUserImportByID retrieves an import by its ID.
+	//
+	// Parameters:
+	//   - importID (int): The ID of the import
+	//
+	// Returns:
+	//   - (*models.UserImport): The import, or nil if not found.
+	GetUserImportByID(importID int) *models.UserImport
+}
+
+// ====================================================================
+// ====================== Repository Implement ========================
+// ====================================================================
+
+// userImportRepository struct for queries from a UserImport model.
+// The struct is an implementation of interface IUserImportRepository
+type userImportRepository struct{}
+
+// GetUserImportByID retrieves an import by its ID.
+func (r *userImportRepository) GetUserImportByID(importID int) *models.UserImport {
+	userImport, err := mb.GetModelByID[models.UserImport](importID)
+	if err != nil {
+		return nil
+	}
+
+	return userImport
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"gfly/internal/domain/models"
+	"github.com/gflydev/core/utils"
+	"github.com/gflydev/search"
+)
+
+// searchEngine is the application-wide Elasticsearch search engine for users.
+// The host is read from the ES_HOST environment variable (default: http://localhost:9200).
+var searchEngine = search.New(search.NewElasticsearchDriver(search.ElasticsearchConfig{
+	Host: utils.Getenv("ES_HOST", "http://localhost:9200"),
+}))
+
+// ====================================================================
+// ======================= Repository Interface =======================
+// ====================================================================
+
+// IUserIndexRepository defines the interface for managing the Elasticsearch index of users.
+//
+// Methods:
+//   - SearchUserIDs(keyword, status string, page, perPage int) ([]int, int64, error): Searches user IDs by keyword.
+//   - IndexUser(user models.User) error: Adds or replaces the document of a user.
+//   - RemoveUserIndex(userID int) error: Removes the document of a user.
+//   - BulkIndexUsers(users []models.User) error: Adds or replaces the documents of many users.
+type IUserIndexRepository interface {
+	// SearchUserIDs searches users by keyword with optional status filter.
+	//
+	// Parameters:
+	//   - keyword (string): Full-text search term matched against fullname, email and phone.
+	//   - status (string): Optional status filter. Pass "" to skip.
+	//   - page (int): 1-based page number.
+	//   - perPage (int): Number of results per page.
+	//
+	// Returns:
+	//   - ([]int, int64, error): Matched user IDs, total count, and any error.
+	SearchUserIDs(keyword, status string, page, perPage int) ([]int, int64, error)
+
+	// IndexUser adds or replaces the document of a user.
+	//
+	// Parameters:
+	//   - user (models.User): The user model to index.
+	//
+	// Returns:
+	//   - error: An error if indexing fails.
+	IndexUser(user models.User) error
+
+	// RemoveUserIndex removes the document of a user.
+	//
+	// Parameters:
+	//   - userID (int): The user ID.
+	//
+	// Returns:
+	//   - error: An error if removal fails.
+	RemoveUserIndex(userID int) error
+
+	// BulkIndexUsers adds or replaces the documents of many users in a single bulk request.
+	//
+	// Parameters:
+	//   - users ([]models.User): Slice of user models to index.
+	//
+	// Returns:
+	//   - error: An error if the bulk operation fails.
+	BulkIndexUsers(users []models.User) error
+}
+
+// ====================================================================
+// ====================== Repository Implement ========================
+// ====================================================================
+
+// userIndexRepository struct for queries on the Elasticsearch index of users.
+// The struct is an implementation of interface IUserIndexRepository
+type userIndexRepository struct{}
+
+// SearchUserIDs searches users by keyword with optional status filter.
+func (r *userIndexRepository) SearchUserIDs(keyword, status string, page, perPage int) ([]int, int64, error) {
+	builder := searchEngine.For(models.User{}).
+		Query(keyword).
+		Page(page).
+		OrderBy("id", "asc").
+		PerPage(perPage)
+
+	if status != "" {
+		builder = builder.Where("status", status)
+	}
+
+	result, err := builder.Search()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return result.IntIDs(), result.Total, nil
+}
+
+// IndexUser adds or replaces the document of a user.
+func (r *userIndexRepository) IndexUser(user models.User) error {
+	return searchEngine.IndexModel(user)
+}
+
+// RemoveUserIndex removes the document of a user.
+func (r *userIndexRepository) RemoveUserIndex(userID int) error {
+	return searchEngine.RemoveModel(models.User{ID: userID})
+}
+
+// BulkIndexUsers adds or replaces the documents of many users in a single bulk request.
+func (r *userIndexRepository) BulkIndexUsers(users []models.User) error {
+	searchableData := make([]search.Searchable, len(users))
+	for idx := range users {
+		searchableData[idx] = users[idx]
+	}
+
+	return searchEngine.BulkIndex(searchableData)
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"gfly/internal/domain/models"
+	mb "github.com/gflydev/db" // Model builder
+	dbNull "github.com/gflydev/db/null"
+)
+
+// ====================================================================
+// ======================= Repository Interface =======================
+// ====================================================================
+
+// IUserRefreshTokenRepository defines the interface for managing refresh token families.
+//
+// Methods:
+//   - GetRefreshTokenByHash(tokenHash string) *models.UserRefreshToken: Retrieves a refresh token by its hash.
+//   - UseRefreshToken(refreshToken *models.UserRefreshToken, replacedBy string) (bool, error): Marks a refresh token as used.
+type IUserRefreshTokenRepository interface {
+	// GetRefreshTokenByHash retrieves a refresh token by its SHA256 hash.
+	//
+	// Parameters:
+	//   - tokenHash (string): The hash of the refresh token
+	//
+	// Returns:
+	//   - (*models.UserRefreshToken): The refresh token, or nil if not found.
+	GetRefreshTokenByHash(tokenHash string) *models.UserRefreshToken
+
+	// UseRefreshToken marks a refresh token as used, only if it was not used yet.
+	// Concurrent calls with the same token are safe: only one of them wins.
+	//
+	// Parameters:
+	//   - refreshToken (*models.UserRefreshToken): The refresh token to mark as used
+	//   - replacedBy (string): The hash of the new refresh token which rotates it
+	//
+	// Returns:
+	//   - (bool): true if this call marked the token, false if it was already used.
+	//   - (error): Returns nil on success, error on failure
+	UseRefreshToken(refreshToken *models.UserRefreshToken, replacedBy string) (bool, error)
+}
+
+// ====================================================================
+// ====================== Repository Implement ========================
+// ====================================================================
+
+// userRefreshTokenRepository struct for queries from a UserRefreshToken model.
+// The struct is an implementation of interface IUserRefreshTokenRepository
+type userRefreshTokenRepository struct{}
+
+// GetRefreshTokenByHash retrieves a refresh token by its SHA256 hash.
+func (r *userRefreshTokenRepository) GetRefreshTokenByHash(tokenHash string) *models.UserRefreshToken {
+	refreshToken, err := mb.GetModelBy[models.UserRefreshToken]("token_hash", tokenHash)
+	if err != nil {
+		return nil
+	}
+
+	return refreshToken
+}
+
+// UseRefreshToken marks a refresh token as used with a conditional update (`used_at IS NULL`).
+// The builder does not report the affected rows, so the token is read back: the call which
+// wrote its own `replaced_by` is the one which used the token.
+func (r *userRefreshTokenRepository) UseRefreshToken(refreshToken *models.UserRefreshToken, replacedBy string) (bool, error) {
+	refreshToken.UsedAt = dbNull.TimeNow()
+	refreshToken.ReplacedBy = dbNull.String(replacedBy)
+
+	err := mb.Instance().
+		Where("id", mb.Eq, refreshToken.ID).
+		Where("used_at", mb.Null, nil).
+		Update(refreshToken)
+	if err != nil {
+		return false, err
+	}
+
+	storedToken, err := mb.GetModelByID[models.UserRefreshToken](refreshToken.ID)
+	if err != nil {
+		return false, err
+	}
+
+	return storedToken.ReplacedBy.Valid && storedToken.ReplacedBy.String == replacedBy, nil
+}
@@ -2,6 +2,9 @@ package repository
 
 import (
 	"gfly/internal/domain/models"
+	"github.com/gflydev/core/try"
+	"time"
+
 	mb "github.com/gflydev/db" // Model builder
 )
 
@@ -16,6 +19,8 @@ import (
 //   - GetUserByEmail(email string) *models.User: Retrieves a user by their email address.
 //   - GetUserByToken(token string) *models.User: Retrieves a user by their authentication token.
 //   - SelectUser(page, limit int) ([]*models.User, int, error): Retrieves a paginated list of users with the total count.
+//   - GetUsersByEmails(emails []string) []models.User: Retrieves the users (soft-deleted included) having the given email addresses, case-insensitively.
+//   - CreateUsers(users []*models.User, userRoles [][]models.Role) error: Creates users with their roles in a single transaction.
 type IUserRepository interface {
 	// GetUserByEmail retrieves a user by their email address.
 	// Parameters:
@@ -32,6 +37,24 @@ type IUserRepository interface {
 	// Returns:
 	//   - (*models.User): The user associated with the given token, or nil if not found.
 	GetUserByToken(token string) *models.User
+
+	// GetUsersByEmails retrieves the users (soft-deleted included) having the given email addresses, case-insensitively.
+	// Parameters:
+	//   - emails ([]string): The email addresses to look for, in lower case.
+	//
+	// Returns:
+	//   - ([]models.User): The users found, in no particular order.
+	GetUsersByEmails(emails []string) []models.User
+
+	// CreateUsers creates users with their roles in a single transaction.
+	// Nothing is created when one of the users fails.
+	// Parameters:
+	//   - users ([]*models.User): The users to create. Their IDs are set on success.
+	//   - userRoles ([][]models.Role): The roles of each user, `userRoles[i]` belongs to `users[i]`.
+	//
+	// Returns:
+	//   - (error): An error if the creation fails.
+	CreateUsers(users []*models.User, userRoles [][]models.Role) error
 }
 
 // ====================================================================
@@ -72,3 +95,76 @@ func (r *userRepository) GetUserByEmail(email string) *models.User {
 func (r *userRepository) GetUserByToken(token string) *models.User {
 	return r.getBy("token", token)
 }
+
+// GetUsersByEmails retrieves the users (soft-deleted included) having the given email addresses, case-insensitively.
+//
+// Parameters:
+//   - emails ([]string): The email addresses to look for, in lower case.
+//
+// Returns:
+//   - ([]models.User): The users found, in no particular order.
+func (r *userRepository) GetUsersByEmails(emails []string) []models.User {
+	var users []models.User
+	if len(emails) == 0 {
+		return users
+	}
+
+	if _, err := mb.Instance().
+		Model(&models.User{}).
+		Where("LOWER("+models.TableUser+".email)", mb.In, emails).
+		Find(&users); err != nil {
+		return nil
+	}
+
+	return users
+}
+
+// CreateUsers creates users with their roles in a single transaction.
+// Nothing is created when one of the users fails.
+//
+// Parameters:
+//   - users ([]*models.User): The users to create. Their IDs are set on success.
+//   - userRoles ([][]models.Role): The roles of each user, `userRoles[i]` belongs to `users[i]`.
+//
+// Returns:
+//   - (error): An error if the creation fails.
+func (r *userRepository) CreateUsers(users []*models.User, userRoles [][]models.Role) (err error) {
+	// DB Model instance
+	db := mb.Instance()
+
+	try.Perform(func() {
+		db.Begin()
+
+		for idx, user := range users {
+			if err := db.Create(user); err != nil {
+				try.Throw(err)
+			}
+
+			// Create a relationship between roles and user.
+			for _, role := range userRoles[idx] {
+				userRole := models.UserRole{
+					RoleID:    role.ID,
+					UserID:    user.ID,
+					CreatedAt: time.Now(),
+				}
+
+				if err := db.Create(&userRole); err != nil {
+					try.Throw(err)
+				}
+			}
+		}
+
+		// Commit the transaction to the database.
+		err = db.Commit()
+	}).Catch(func(e try.E) {
+		err = e.(error)
+		_ = db.Rollback() // Rollback the transaction in case of failure.
+
+		// IDs of the rolled back users are meaningless
+		for _, user := range users {
+			user.ID = 0
+		}
+	})
+
+	return err
+}
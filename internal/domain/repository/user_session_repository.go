@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"gfly/internal/domain/models"
+	"github.com/gflydev/core/log"
+	mb "github.com/gflydev/db" // Model builder
+	dbNull "github.com/gflydev/db/null"
+	"slices"
+	"time"
+)
+
+// ====================================================================
+// ======================= Repository Interface =======================
+// ====================================================================
+
+// IUserSessionRepository defines the interface for managing signed-in devices (sessions) of users.
+//
+// Methods:
+//   - GetSessionByID(sessionID string) *models.UserSession: Retrieves a session by its ID.
+//   - GetActiveSessionsByUserID(userID int) []models.UserSession: Retrieves all active sessions of a user.
+//   - RevokeSession(session *models.UserSession) error: Revokes a single session.
+//   - RevokeSessionsByUserID(userID int, exceptSessionIDs ...string) error: Revokes all active sessions of a user.
+type IUserSessionRepository interface {
+	// GetSessionByID retrieves a session by its ID.
+	//
+	// Parameters:
+	//   - sessionID (string): The unique identifier of the session
+	//
+	// Returns:
+	//   - (*models.UserSession): The session, or nil if not found.
+	GetSessionByID(sessionID string) *models.UserSession
+
+	// GetActiveSessionsByUserID retrieves all sessions of a user which are neither revoked nor expired.
+	//
+	// Parameters:
+	//   - userID (int): The unique identifier of the user
+	//
+	// Returns:
+	//   - ([]models.UserSession): Active sessions, the most recent first.
+	GetActiveSessionsByUserID(userID int) []models.UserSession
+
+	// RevokeSession marks the given session as revoked.
+	//
+	// Parameters:
+	//   - session (*models.UserSession): The session to revoke
+	//
+	// Returns:
+	//   - error: Returns nil on success, error on failure
+	RevokeSession(session *models.UserSession) error
+
+	// RevokeSessionsByUserID revokes all active sessions of a user.
+	//
+	// Parameters:
+	//   - userID (int): The unique identifier of the user
+	//   - exceptSessionIDs (...string): Sessions which should stay active (Ex: the current device)
+	//
+	// Returns:
+	//   - error: Returns nil on success, error on failure
+	RevokeSessionsByUserID(userID int, exceptSessionIDs ...string) error
+}
+
+// ====================================================================
+// ====================== Repository Implement ========================
+// ====================================================================
+
+// userSessionRepository struct for queries from a UserSession model.
+// The struct is an implementation of interface IUserSessionRepository
+type userSessionRepository struct{}
+
+// GetSessionByID retrieves a session by its ID.
+func (r *userSessionRepository) GetSessionByID(sessionID string) *models.UserSession {
+	session, err := mb.GetModelByID[models.UserSession](sessionID)
+	if err != nil {
+		return nil
+	}
+
+	return session
+}
+
+// GetActiveSessionsByUserID retrieves all sessions of a user which are neither revoked nor expired.
+func (r *userSessionRepository) GetActiveSessionsByUserID(userID int) []models.UserSession {
+	var sessions []models.UserSession
+
+	_, err := mb.Instance().
+		Where("user_id", mb.Eq, userID).
+		Where("revoked_at", mb.Null, nil).
+		Where("expires_at", mb.Greater, time.Now()).
+		OrderBy("created_at", mb.Desc).
+		Find(&sessions)
+
+	if err != nil {
+		log.Error(err)
+	}
+
+	// For case empty list => return an empty list
+	if sessions == nil {
+		sessions = []models.UserSession{}
+	}
+
+	return sessions
+}
+
+// RevokeSession marks the given session as revoked.
+func (r *userSessionRepository) RevokeSession(session *models.UserSession) error {
+	session.RevokedAt = dbNull.TimeNow()
+	session.UpdatedAt = dbNull.TimeNow()
+
+	return mb.UpdateModel(session)
+}
+
+// RevokeSessionsByUserID revokes all active sessions of a user except the given ones.
+func (r *userSessionRepository) RevokeSessionsByUserID(userID int, exceptSessionIDs ...string) error {
+	sessions := r.GetActiveSessionsByUserID(userID)
+
+	for i := range sessions {
+		if slices.Contains(exceptSessionIDs, sessions[i].ID) {
+			continue
+		}
+
+		if err := r.RevokeSession(&sessions[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"gfly/internal/domain/models"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/try"
+	"github.com/gflydev/core/utils"
+	mb "github.com/gflydev/db" // Model builder
+	dbNull "github.com/gflydev/db/null"
+	"time"
+)
+
+// ====================================================================
+// ======================= Repository Interface =======================
+// ====================================================================
+
+// IUserTwoFactorRepository defines the interface for managing two-factor authentication of users.
+//
+// Methods:
+//   - GetTwoFactorByUserID(userID int) *models.UserTwoFactor: Retrieves the TOTP enrollment of a user.
+//   - GetUnusedRecoveryCodes(userID int) []models.UserRecoveryCode: Retrieves recovery codes not used yet.
+//   - UseTwoFactorStep(twoFactor *models.UserTwoFactor, step int64) (bool, error): Accepts the time step of a TOTP code once.
+//   - UseRecoveryCode(recoveryCode *models.UserRecoveryCode) (bool, error): Marks a recovery code as used once.
+//   - ReplaceRecoveryCodes(userID int, codeHashes []string) error: Replaces all recovery codes of a user.
+//   - DeleteTwoFactor(userID int) error: Removes the enrollment and recovery codes of a user.
+type IUserTwoFactorRepository interface {
+	// GetTwoFactorByUserID retrieves the TOTP enrollment of a user.
+	//
+	// Parameters:
+	//   - userID (int): The unique identifier of the user
+	//
+	// Returns:
+	//   - (*models.UserTwoFactor): The enrollment, or nil if not found.
+	GetTwoFactorByUserID(userID int) *models.UserTwoFactor
+
+	// GetUnusedRecoveryCodes retrieves the recovery codes of a user which were not used yet.
+	//
+	// Parameters:
+	//   - userID (int): The unique identifier of the user
+	//
+	// Returns:
+	//   - ([]models.UserRecoveryCode): Unused recovery codes.
+	GetUnusedRecoveryCodes(userID int) []models.UserRecoveryCode
+
+	// UseTwoFactorStep stores the time step of an accepted TOTP code, if it is after the last accepted step.
+	//
+	// Parameters:
+	//   - twoFactor (*models.UserTwoFactor): The enrollment of the user
+	//   - step (int64): The time step of the code (RFC 6238)
+	//
+	// Returns:
+	//   - (bool): False when the step (or a later one) was already accepted, also by a concurrent request.
+	//   - error: Returns nil on success, error on failure
+	UseTwoFactorStep(twoFactor *models.UserTwoFactor, step int64) (bool, error)
+
+	// UseRecoveryCode marks a recovery code as used, if it was not used yet.
+	//
+	// Parameters:
+	//   - recoveryCode (*models.UserRecoveryCode): The recovery code
+	//
+	// Returns:
+	//   - (bool): False when the code was already used, also by a concurrent request.
+	//   - error: Returns nil on success, error on failure
+	UseRecoveryCode(recoveryCode *models.UserRecoveryCode) (bool, error)
+
+	// ReplaceRecoveryCodes removes all recovery codes of a user then stores the given ones.
+	//
+	// Parameters:
+	//   - userID (int): The unique identifier of the user
+	//   - codeHashes ([]string): SHA256 hashes of the new recovery codes
+	//
+	// Returns:
+	//   - error: Returns nil on success, error on failure
+	ReplaceRecoveryCodes(userID int, codeHashes []string) error
+
+	// DeleteTwoFactor removes the enrollment and recovery codes of a user.
+	//
+	// Parameters:
+	//   - userID (int): The unique identifier of the user
+	//
+	// Returns:
+	//   - error: Returns nil on success, error on failure
+	DeleteTwoFactor(userID int) error
+}
+
+// ====================================================================
+// ====================== Repository Implement ========================
+// ====================================================================
+
+// userTwoFactorRepository struct for queries from UserTwoFactor and UserRecoveryCode models.
+// The struct is an implementation of interface IUserTwoFactorRepository
+type userTwoFactorRepository struct{}
+
+// GetTwoFactorByUserID retrieves the TOTP enrollment of a user.
+func (r *userTwoFactorRepository) GetTwoFactorByUserID(userID int) *models.UserTwoFactor {
+	twoFactor, err := mb.GetModelBy[models.UserTwoFactor]("user_id", userID)
+	if err != nil {
+		return nil
+	}
+
+	return twoFactor
+}
+
+// GetUnusedRecoveryCodes retrieves the recovery codes of a user which were not used yet.
+func (r *userTwoFactorRepository) GetUnusedRecoveryCodes(userID int) []models.UserRecoveryCode {
+	var codes []models.UserRecoveryCode
+
+	_, err := mb.Instance().
+		Where("user_id", mb.Eq, userID).
+		Where("used_at", mb.Null, nil).
+		Find(&codes)
+
+	if err != nil {
+		log.Error(err)
+	}
+
+	return codes
+}
+
+// UseTwoFactorStep stores the time step of an accepted TOTP code with a conditional update
+// (no step yet, or an older one). The builder does not report the affected rows, so the
+// enrollment is read back: the call whose claim was stored is the one which used the step.
+func (r *userTwoFactorRepository) UseTwoFactorStep(twoFactor *models.UserTwoFactor, step int64) (bool, error) {
+	claim := utils.Token()
+
+	twoFactor.LastUsedStep = dbNull.Int64(step)
+	twoFactor.LastUsedClaim = dbNull.String(claim)
+	twoFactor.UpdatedAt = dbNull.TimeNow()
+
+	err := mb.Instance().
+		Where("id", mb.Eq, twoFactor.ID).
+		WhereGroup(func(stepGroup mb.WhereBuilder) *mb.WhereBuilder {
+			stepGroup.Where("last_used_step", mb.Null, nil).
+				WhereOr("last_used_step", mb.Lesser, step)
+
+			return &stepGroup
+		}).
+		Update(twoFactor)
+	if err != nil {
+		return false, err
+	}
+
+	storedTwoFactor, err := mb.GetModelByID[models.UserTwoFactor](twoFactor.ID)
+	if err != nil {
+		return false, err
+	}
+
+	return storedTwoFactor.LastUsedClaim.Valid && storedTwoFactor.LastUsedClaim.String == claim, nil
+}
+
+// UseRecoveryCode marks a recovery code as used with a conditional update (`used_at IS NULL`).
+// The builder does not report the affected rows, so the code is read back: the call whose
+// claim was stored is the one which used the code.
+func (r *userTwoFactorRepository) UseRecoveryCode(recoveryCode *models.UserRecoveryCode) (bool, error) {
+	claim := utils.Token()
+
+	recoveryCode.UsedAt = dbNull.TimeNow()
+	recoveryCode.Claim = dbNull.String(claim)
+
+	err := mb.Instance().
+		Where("id", mb.Eq, recoveryCode.ID).
+		Where("used_at", mb.Null, nil).
+		Update(recoveryCode)
+	if err != nil {
+		return false, err
+	}
+
+	storedCode, err := mb.GetModelByID[models.UserRecoveryCode](recoveryCode.ID)
+	if err != nil {
+		return false, err
+	}
+
+	return storedCode.Claim.Valid && storedCode.Claim.String == claim, nil
+}
+
+// ReplaceRecoveryCodes removes all recovery codes of a user then stores the given ones.
+func (r *userTwoFactorRepository) ReplaceRecoveryCodes(userID int, codeHashes []string) (err error) {
+	// DB Model instance
+	db := mb.Instance()
+
+	try.Perform(func() {
+		db.Begin()
+
+		// Remove old recovery codes of the user.
+		if err := db.Where("user_id", mb.Eq, userID).Delete(&models.UserRecoveryCode{}); err != nil {
+			try.Throw(err)
+		}
+
+		for _, codeHash := range codeHashes {
+			code := models.UserRecoveryCode{
+				UserID:    userID,
+				CodeHash:  codeHash,
+				CreatedAt: time.Now(),
+			}
+
+			if err := db.Create(&code); err != nil {
+				try.Throw(err)
+			}
+		}
+
+		// Commit the transaction to the database.
+		err = db.Commit()
+	}).Catch(func(e try.E) {
+		err = e.(error)
+		_ = db.Rollback() // Rollback the transaction in case of failure.
+	})
+
+	return err
+}
+
+// DeleteTwoFactor removes the enrollment and recovery codes of a user.
+func (r *userTwoFactorRepository) DeleteTwoFactor(userID int) (err error) {
+	// DB Model instance
+	db := mb.Instance()
+
+	try.Perform(func() {
+		db.Begin()
+
+		if err := db.Where("user_id", mb.Eq, userID).Delete(&models.UserRecoveryCode{}); err != nil {
+			try.Throw(err)
+		}
+
+		if err := db.Where("user_id", mb.Eq, userID).Delete(&models.UserTwoFactor{}); err != nil {
+			try.Throw(err)
+		}
+
+		// Commit the transaction to the database.
+		err = db.Commit()
+	}).Catch(func(e try.E) {
+		err = e.(error)
+		_ = db.Rollback() // Rollback the transaction in case of failure.
+	})
+
+	return err
+}
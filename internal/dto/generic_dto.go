@@ -10,10 +10,41 @@ package dto
 // @PerPage PerPage is the number of items to display per page (optional)
 // @Keyword Keyword is used for searching/filtering records by text content
 // @OrderBy OrderBy specifies the field to sort by, prefix with '-' for descending order
+// @Cursor Cursor is the opaque position returned as `next_cursor` or `prev_cursor`. Page is ignored when it is set
+// @Conditions Conditions are the structured filters `filter[field][operator]=value`
+// @Sort Sort is a comma-separated list of fields to sort by, prefix with '-' for descending order. It replaces OrderBy
 // @Tags Request Filters
 type Filter struct {
 	Page    int    `json:"page" example:"1" validate:"number" doc:"Current page number for pagination"`
 	PerPage int    `json:"per_page" example:"10" validate:"number" doc:"Number of items to display per page"`
 	Keyword string `json:"keyword" example:"search term" validate:"" doc:"Search keyword for filtering records"`
 	OrderBy string `json:"order_by" example:"-created_at" validate:"" doc:"Field to order by, prefix with '-' for descending order"`
+	Cursor  string `json:"cursor" example:"eyJvIjoiZW1haWwiLCJ2Ijoiam9obkBleGFtcGxlLmNvbSIsImkiOjEyfQ" validate:"max=1024" doc:"Opaque cursor of the page to load"`
+
+	Conditions []FilterCondition `json:"filter" validate:"max=20,dive" doc:"Structured filters"`
+	Sort       string            `json:"sort" example:"-last_access,email" validate:"max=255" doc:"Fields to sort by, prefix with '-' for descending order"`
+}
+
+// FilterCondition struct to describe a structured filter `filter[field][operator]=value1,value2`.
+type FilterCondition struct {
+	Field    string   `json:"field" example:"status" validate:"required" doc:"Field to filter by"`
+	Operator string   `json:"operator" example:"eq" validate:"required,oneof=eq ne gt gte lt lte like" doc:"Comparison operator"`
+	Values   []string `json:"values" example:"active,pending" validate:"required,min=1,max=50" doc:"Values to compare with. Many values are OR-ed (eq, ne)"`
+}
+
+// Pagination struct to describe where a page of items is in the whole list.
+type Pagination struct {
+	Total      *int   // Total number of items. Nil with a cursor, the list is not counted
+	NextCursor string // Cursor of the next page, empty on the last page
+	PrevCursor string // Cursor of the previous page, empty on the first page
+}
+
+// Fieldset struct to describe the sparse fieldset `fields=id,email` and the relationships `include=roles,addresses` of a response.
+// @Description Sparse fieldset and relationships of a response
+// @Fields Fields are the fields of the response. All fields when empty
+// @Include Include are the relationships loaded into the response
+// @Tags Request Filters
+type Fieldset struct {
+	Fields  []string `json:"fields" example:"id,email,status" validate:"max=50" doc:"Fields of the response, all fields when empty"`
+	Include []string `json:"include" example:"roles" validate:"max=10" doc:"Relationships loaded into the response"`
 }
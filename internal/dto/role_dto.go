@@ -0,0 +1,23 @@
+package dto
+
+import "gfly/internal/domain/models/types"
+
+// CreateRole struct to describe the request body to create a new role.
+// @Description Request payload for creating a new role.
+// @Tags Roles
+type CreateRole struct {
+	Name        string             `json:"name" example:"Editor" validate:"required,max=100" doc:"Role's name (required, max length 100)"`
+	Slug        types.Role         `json:"slug" example:"editor" validate:"required,max=100" doc:"Role's slug (required, max length 100, lowercase letters, digits, '-' and '_')"`
+	Parent      types.Role         `json:"parent" example:"member" validate:"omitempty,max=100" doc:"Slug of the role whose roles and permissions are inherited (optional)"`
+	Permissions []types.Permission `json:"permissions" example:"users.read,users.update" validate:"omitempty" doc:"List of permissions granted by the role (optional)"`
+}
+
+// UpdateRole struct to rename a role and replace its permissions.
+// The slug can not be changed because scopes of issued tokens refer to it.
+// @Description Request payload for updating an existing role.
+// @Tags Roles
+type UpdateRole struct {
+	Name        string              `json:"name" example:"Content editor" validate:"required,max=100" doc:"Role's new name (required, max length 100)"`
+	Parent      *types.Role         `json:"parent" example:"member" validate:"omitempty,max=100" doc:"Slug of the role whose roles and permissions are inherited (optional, empty string to remove the parent)"`
+	Permissions *[]types.Permission `json:"permissions" example:"users.read" validate:"omitempty" doc:"Updated list of permissions granted by the role (optional, empty list to remove all permissions)"`
+}
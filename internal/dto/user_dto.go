@@ -8,7 +8,7 @@ import "gfly/internal/domain/models/types"
 // @Tags Users
 type CreateUser struct {
 	Email    string       `json:"email" example:"john@jivecode.com" validate:"required,email,max=255" doc:"User's email address (required, max length 255)"`
-	Password string       `json:"password" example:"M1PassW@s" validate:"required,max=255" doc:"User's password (required, max length 255)"` // #nosec G117 -- Legitimate password input field for creating users
+	Password string       `json:"password" example:"M1PassW@s" validate:"required,max=255,password" doc:"User's password (required, max length 255, must match the password policy)"` // #nosec G117 -- Legitimate password input field for creating users
 	Fullname string       `json:"fullname" example:"John Doe" validate:"required,max=255" doc:"User's full name (required, max length 255)"`
 	Phone    string       `json:"phone" example:"0989831911" validate:"required,max=20" doc:"User's phone number (required, max length 20)"`
 	Avatar   string       `json:"avatar" example:"https://i.pravatar.cc/32" validate:"omitempty,max=255" doc:"URL of the user's avatar (optional, max length 255)"`
@@ -16,18 +16,29 @@ type CreateUser struct {
 	Roles    []types.Role `json:"roles" example:"admin,user" validate:"omitempty" doc:"List of user's roles (optional)"`
 }
 
+// PersonalData returns the data which must not be part of the password.
+func (d CreateUser) PersonalData() []string {
+	return []string{d.Email, d.Fullname}
+}
+
 // UpdateUser struct to partially update an existed user.
 // @Description Request payload for updating an existing user.
 // @Tags Users
 type UpdateUser struct {
 	ID       int          `json:"-" validate:"omitempty,gte=1" doc:"User ID (greater than or equal to 1)"`
-	Password string       `json:"password" example:"M1PassW@s" validate:"omitempty,max=255" doc:"User's new password (optional, max length 255)"` // #nosec G117 -- Legitimate password input field for updating user passwords
+	Password string       `json:"password" example:"M1PassW@s" validate:"omitempty,max=255,password" doc:"User's new password (optional, max length 255, must match the password policy)"` // #nosec G117 -- Legitimate password input field for updating user passwords
 	Fullname string       `json:"fullname" example:"John Doe" validate:"max=255" doc:"User's updated full name (optional, max length 255)"`
 	Phone    string       `json:"phone" example:"0989831911" validate:"max=20" doc:"User's updated phone number (optional, max length 20)"`
 	Avatar   string       `json:"avatar" example:"https://i.pravatar.cc/32" validate:"max=255" doc:"Updated URL of the user's avatar (optional, max length 255)"`
 	Roles    []types.Role `json:"roles" example:"admin,user" validate:"omitempty" doc:"Updated list of user's roles (optional)"`
 }
 
+// PersonalData returns the data which must not be part of the password.
+// The email of the user is not in the request, services.UpdateUser checks it.
+func (d UpdateUser) PersonalData() []string {
+	return []string{d.Fullname}
+}
+
 // UpdateUserStatus struct allows update `status` field from an existing user.
 // @Description Request payload for updating the status field of an existing user.
 // @Tags Users
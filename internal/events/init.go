@@ -7,10 +7,12 @@ package events
 
 import (
 	"gfly/internal/events/user"
+	authEvents "gfly/pkg/modules/auth/events"
 	"github.com/gflydev/event"
 )
 
 // init auto-registers all event subscribers when this package is imported.
 func init() {
 	event.Subscribe(&user.UserSubscriber{})
+	event.Subscribe(&authEvents.AuthSubscriber{})
 }
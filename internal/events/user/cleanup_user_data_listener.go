@@ -1,11 +1,14 @@
 package user
 
 import (
+	"gfly/internal/domain/events"
+	"gfly/internal/domain/repository"
 	"github.com/gflydev/core/log"
 )
 
-// CleanupUserDataListener removes user-related data (cache, files, sessions)
-// after a user account has been deleted.
+// CleanupUserDataListener removes the data of a purged user which is not removed with
+// the user row: the roles and permissions cached for the user. Sessions, tokens and
+// roles are deleted by the foreign keys of the user.
 type CleanupUserDataListener struct{}
 
 // Handle processes the UserDeleted event.
@@ -15,8 +18,10 @@ type CleanupUserDataListener struct{}
 //
 // Returns:
 //   - error: Non-nil if the cleanup encounters a critical failure.
-func (l *CleanupUserDataListener) Handle(event UserDeleted) error {
+func (l *CleanupUserDataListener) Handle(event events.UserDeleted) error {
 	log.Infof("[Listener] CleanupUserData: cleaning up for user %d (%s)", event.UserID, event.Email)
 
+	repository.ForgetUserRoles(event.UserID)
+
 	return nil
 }
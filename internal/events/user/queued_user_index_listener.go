@@ -0,0 +1,68 @@
+package user
+
+import (
+	"gfly/internal/console/queues"
+	"gfly/internal/domain/events"
+	"github.com/gflydev/console"
+	"github.com/gflydev/core/log"
+)
+
+// The listeners below keep the Elasticsearch index in sync with the users table.
+// The indexing is deferred to the queue worker, which retries the task while
+// Elasticsearch is down.
+//
+// Requires the queue worker to be running: ./build/artisan queue:run
+
+// QueuedIndexUserListener indexes a newly registered user.
+type QueuedIndexUserListener struct{}
+
+// Handle processes the UserRegistered event by dispatching a queue task.
+//
+// Parameters:
+//   - event (events.UserRegistered): The concrete user-registered event.
+//
+// Returns:
+//   - error: Non-nil if task dispatch fails.
+func (l *QueuedIndexUserListener) Handle(event events.UserRegistered) error {
+	log.Infof("[Listener] QueuedIndexUser: queuing user %d", event.User.ID)
+
+	console.DispatchTask(queues.NewSyncUserIndexTask(event.User.ID))
+
+	return nil
+}
+
+// QueuedReindexUserListener re-indexes an updated user.
+type QueuedReindexUserListener struct{}
+
+// Handle processes the UserUpdated event by dispatching a queue task.
+//
+// Parameters:
+//   - event (events.UserUpdated): The concrete user-updated event.
+//
+// Returns:
+//   - error: Non-nil if task dispatch fails.
+func (l *QueuedReindexUserListener) Handle(event events.UserUpdated) error {
+	log.Infof("[Listener] QueuedReindexUser: queuing user %d", event.User.ID)
+
+	console.DispatchTask(queues.NewSyncUserIndexTask(event.User.ID))
+
+	return nil
+}
+
+// QueuedRemoveUserIndexListener removes a deleted user from the index.
+type QueuedRemoveUserIndexListener struct{}
+
+// Handle processes the UserDeleted event by dispatching a queue task.
+//
+// Parameters:
+//   - event (events.UserDeleted): The concrete user-deleted event.
+//
+// Returns:
+//   - error: Non-nil if task dispatch fails.
+func (l *QueuedRemoveUserIndexListener) Handle(event events.UserDeleted) error {
+	log.Infof("[Listener] QueuedRemoveUserIndex: queuing user %d", event.UserID)
+
+	console.DispatchTask(queues.NewSyncUserIndexTask(event.UserID))
+
+	return nil
+}
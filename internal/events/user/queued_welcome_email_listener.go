@@ -2,6 +2,7 @@ package user
 
 import (
 	"gfly/internal/console/queues"
+	"gfly/internal/domain/events"
 	"github.com/gflydev/console"
 	"github.com/gflydev/core/log"
 )
@@ -20,7 +21,7 @@ type QueuedWelcomeEmailListener struct{}
 //
 // Returns:
 //   - error: Non-nil if task dispatch fails.
-func (l *QueuedWelcomeEmailListener) Handle(event UserRegistered) error {
+func (l *QueuedWelcomeEmailListener) Handle(event events.UserRegistered) error {
 	log.Infof("[Listener] QueuedWelcomeEmail: queuing for %s", event.User.Email)
 
 	console.DispatchTask(queues.NewSendWelcomeEmailTask(event.User.Email, event.User.Fullname))
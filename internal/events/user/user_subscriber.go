@@ -1,6 +1,7 @@
 package user
 
 import (
+	"gfly/internal/domain/events"
 	"github.com/gflydev/event"
 )
 
@@ -15,10 +16,13 @@ type UserSubscriber struct{}
 // Subscribe UserSubscriber registers user event listeners on the given dispatcher.
 //
 // Registered mappings:
-//   - user.registered → SendWelcomeEmailListener, QueuedWelcomeEmailListener
-//   - user.deleted    → CleanupUserDataListener
+//   - user.registered → QueuedWelcomeEmailListener, QueuedIndexUserListener
+//   - user.updated    → QueuedReindexUserListener
+//   - user.deleted    → CleanupUserDataListener, QueuedRemoveUserIndexListener
 func (s *UserSubscriber) Subscribe(d *event.Dispatcher) {
-	event.ListenOn[UserRegistered](d, &SendWelcomeEmailListener{})
-	event.ListenOn[UserRegistered](d, &QueuedWelcomeEmailListener{})
-	event.ListenOn[UserDeleted](d, &CleanupUserDataListener{})
+	event.ListenOn[events.UserRegistered](d, &QueuedWelcomeEmailListener{})
+	event.ListenOn[events.UserRegistered](d, &QueuedIndexUserListener{})
+	event.ListenOn[events.UserUpdated](d, &QueuedReindexUserListener{})
+	event.ListenOn[events.UserDeleted](d, &CleanupUserDataListener{})
+	event.ListenOn[events.UserDeleted](d, &QueuedRemoveUserIndexListener{})
 }
@@ -0,0 +1,94 @@
+package role
+
+import (
+	"gfly/internal/domain/models"
+	"gfly/internal/services"
+	"github.com/gflydev/core"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/http"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+type AssignRoleApi struct {
+	core.Api
+}
+
+func NewAssignRoleApi() *AssignRoleApi {
+	return &AssignRoleApi{}
+}
+
+// ====================================================================
+// ======================== Request Validation ========================
+// ====================================================================
+
+func (h *AssignRoleApi) Validate(c *core.Ctx) error {
+	return processRoleUserIDs(c)
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle function assigns a role to a user.
+// @Description Function assigns a role to a user. Nothing changes if the user already has the role.
+// @Description The role and the user must be below the roles of the current user. Deleted users can not get roles.
+// @Summary Assign role to user
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Param id path int true "Role ID"
+// @Param user_id path int true "User ID"
+// @Success 204
+// @Failure 400 {object} http.Error
+// @Failure 401 {object} http.Error
+// @Failure 403 {object} http.Error
+// @Failure 404 {object} http.Error
+// @Security ApiKeyAuth
+// @Router /roles/{id}/users/{user_id} [put]
+func (h *AssignRoleApi) Handle(c *core.Ctx) error {
+	roleID := c.GetData(http.PathIDKey).(int)
+	userID := c.GetData(UserIDKey).(int)
+	actor := c.GetData(http.UserKey).(models.User)
+
+	if err := services.AssignRole(actor.ID, roleID, userID); err != nil {
+		status := core.StatusBadRequest
+		switch {
+		case errors.Is(err, services.ErrRoleNotFound):
+			status = core.StatusNotFound
+		case errors.Is(err, services.ErrRoleRank):
+			status = core.StatusForbidden
+		}
+
+		return c.Error(http.Error{
+			Message: err.Error(),
+		}, status)
+	}
+
+	return c.NoContent()
+}
+
+// ====================================================================
+// ========================= Helper Functions =========================
+// ====================================================================
+
+// UserIDKey context key storing the path parameter `user_id`.
+const UserIDKey = "__role_user_id__"
+
+// processRoleUserIDs receives path parameters `id` (role) and `user_id` then stores them into context.
+func processRoleUserIDs(c *core.Ctx) error {
+	if err := http.ProcessPathID(c); err != nil {
+		return err
+	}
+
+	userID, errData := http.PathID(c, "user_id")
+	if errData != nil {
+		return c.Error(errData)
+	}
+
+	c.SetData(UserIDKey, userID)
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package role
+
+import (
+	"gfly/internal/domain/models"
+	"gfly/internal/http/request"
+	_ "gfly/internal/http/response" // Used for Swagger documentation
+	"gfly/internal/services"
+	"github.com/gflydev/core"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/http"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+type CreateRoleApi struct {
+	core.Api
+}
+
+func NewCreateRoleApi() *CreateRoleApi {
+	return &CreateRoleApi{}
+}
+
+// ====================================================================
+// ======================== Request Validation ========================
+// ====================================================================
+
+func (h *CreateRoleApi) Validate(c *core.Ctx) error {
+	return http.ProcessData[request.CreateRole](c)
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle function creates a new role with its permissions.
+// @Description Function creates a new role with its permissions. The role can be assigned right away, no code change is needed.
+// @Description The parent role must be below the roles of the current user.
+// @Summary Create a new role
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Param data body request.CreateRole true "CreateRole payload"
+// @Success 201 {object} response.RoleDetail
+// @Failure 400 {object} http.Error
+// @Failure 401 {object} http.Error
+// @Failure 403 {object} http.Error
+// @Security ApiKeyAuth
+// @Router /roles [post]
+func (h *CreateRoleApi) Handle(c *core.Ctx) error {
+	requestData := c.GetData(http.RequestKey).(request.CreateRole)
+	actor := c.GetData(http.UserKey).(models.User)
+
+	role, err := services.CreateRole(actor.ID, requestData.ToDto())
+	if err != nil {
+		status := core.StatusBadRequest
+		if errors.Is(err, services.ErrRoleRank) {
+			status = core.StatusForbidden
+		}
+
+		return c.Error(http.Error{
+			Message: err.Error(),
+		}, status)
+	}
+
+	return c.
+		Status(core.StatusCreated).
+		JSON(roleResponse(*role))
+}
@@ -0,0 +1,73 @@
+package role
+
+import (
+	"gfly/internal/domain/models"
+	"gfly/internal/domain/models/types"
+	"gfly/internal/services"
+	"github.com/gflydev/core"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/http"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+type DeleteRoleApi struct {
+	core.Api
+}
+
+func NewDeleteRoleApi() *DeleteRoleApi {
+	return &DeleteRoleApi{}
+}
+
+// ====================================================================
+// ======================== Request Validation ========================
+// ====================================================================
+
+func (h *DeleteRoleApi) Validate(c *core.Ctx) error {
+	return http.ProcessPathID(c)
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle function deletes a role and moves its users to another role.
+// @Description Function deletes a role and moves its users to the role given by `reassign_to`.
+// @Description `reassign_to` is required when the role still has users. System roles can not be deleted.
+// @Description The role and `reassign_to` must be below the roles of the current user.
+// @Summary Delete role by given roleID
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Param id path int true "Role ID"
+// @Param reassign_to query string false "Slug of the role which receives the users"
+// @Success 204
+// @Failure 400 {object} http.Error
+// @Failure 401 {object} http.Error
+// @Failure 403 {object} http.Error
+// @Failure 404 {object} http.Error
+// @Security ApiKeyAuth
+// @Router /roles/{id} [delete]
+func (h *DeleteRoleApi) Handle(c *core.Ctx) error {
+	roleID := c.GetData(http.PathIDKey).(int)
+	reassignTo := types.Role(c.QueryStr("reassign_to"))
+	actor := c.GetData(http.UserKey).(models.User)
+
+	if err := services.DeleteRole(actor.ID, roleID, reassignTo); err != nil {
+		status := core.StatusBadRequest
+		switch {
+		case errors.Is(err, services.ErrRoleNotFound):
+			status = core.StatusNotFound
+		case errors.Is(err, services.ErrSystemRole), errors.Is(err, services.ErrRoleRank):
+			status = core.StatusForbidden
+		}
+
+		return c.Error(http.Error{
+			Message: err.Error(),
+		}, status)
+	}
+
+	return c.NoContent()
+}
@@ -0,0 +1,60 @@
+package role
+
+import (
+	"gfly/internal/domain/models"
+	"gfly/internal/http/response"
+	"gfly/internal/http/transformers"
+	"gfly/internal/services"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+type ListRolesApi struct {
+	core.Api
+}
+
+func NewListRolesApi() *ListRolesApi {
+	return &ListRolesApi{}
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle function lists all roles with their permissions and number of users.
+// @Description Function lists all roles (built-in and created at runtime) with their permissions and number of users.
+// @Summary List all roles
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.ListRole
+// @Failure 401 {object} http.Error
+// @Failure 403 {object} http.Error
+// @Security ApiKeyAuth
+// @Router /roles [get]
+func (h *ListRolesApi) Handle(c *core.Ctx) error {
+	roles, usersCount := services.FindRoles()
+
+	data := make([]response.RoleDetail, 0, len(roles))
+	for _, role := range roles {
+		data = append(data, transformers.ToRoleDetailResponse(role, usersCount[role.ID]))
+	}
+
+	return c.Success(response.ListRole{
+		Data: data,
+	})
+}
+
+// ====================================================================
+// ========================= Helper Functions =========================
+// ====================================================================
+
+// roleResponse transforms a role with its current number of users.
+func roleResponse(role models.Role) response.RoleDetail {
+	_, usersCount := services.FindRoles()
+
+	return transformers.ToRoleDetailResponse(role, usersCount[role.ID])
+}
@@ -0,0 +1,71 @@
+package role
+
+import (
+	"gfly/internal/domain/models"
+	"gfly/internal/services"
+	"github.com/gflydev/core"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/http"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+type RevokeRoleApi struct {
+	core.Api
+}
+
+func NewRevokeRoleApi() *RevokeRoleApi {
+	return &RevokeRoleApi{}
+}
+
+// ====================================================================
+// ======================== Request Validation ========================
+// ====================================================================
+
+func (h *RevokeRoleApi) Validate(c *core.Ctx) error {
+	return processRoleUserIDs(c)
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle function revokes a role from a user.
+// @Description Function revokes a role from a user.
+// @Description The role and the user must be below the roles of the current user.
+// @Summary Revoke role from user
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Param id path int true "Role ID"
+// @Param user_id path int true "User ID"
+// @Success 204
+// @Failure 400 {object} http.Error
+// @Failure 401 {object} http.Error
+// @Failure 403 {object} http.Error
+// @Failure 404 {object} http.Error
+// @Security ApiKeyAuth
+// @Router /roles/{id}/users/{user_id} [delete]
+func (h *RevokeRoleApi) Handle(c *core.Ctx) error {
+	roleID := c.GetData(http.PathIDKey).(int)
+	userID := c.GetData(UserIDKey).(int)
+	actor := c.GetData(http.UserKey).(models.User)
+
+	if err := services.RevokeRole(actor.ID, roleID, userID); err != nil {
+		status := core.StatusBadRequest
+		switch {
+		case errors.Is(err, services.ErrRoleNotFound):
+			status = core.StatusNotFound
+		case errors.Is(err, services.ErrRoleRank):
+			status = core.StatusForbidden
+		}
+
+		return c.Error(http.Error{
+			Message: err.Error(),
+		}, status)
+	}
+
+	return c.NoContent()
+}
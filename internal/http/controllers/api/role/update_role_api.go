@@ -0,0 +1,78 @@
+package role
+
+import (
+	"gfly/internal/domain/models"
+	"gfly/internal/http/request"
+	_ "gfly/internal/http/response" // Used for Swagger documentation
+	"gfly/internal/services"
+	"github.com/gflydev/core"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/http"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+type UpdateRoleApi struct {
+	core.Api
+}
+
+func NewUpdateRoleApi() *UpdateRoleApi {
+	return &UpdateRoleApi{}
+}
+
+// ====================================================================
+// ======================== Request Validation ========================
+// ====================================================================
+
+func (h *UpdateRoleApi) Validate(c *core.Ctx) error {
+	if err := http.ProcessPathID(c); err != nil {
+		return err
+	}
+
+	return http.ProcessData[request.UpdateRole](c)
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle function renames a role and replaces its permissions.
+// @Description Function renames a role and replaces its permissions. The slug can not be changed.
+// @Description The role and its new parent must be below the roles of the current user.
+// @Summary Update an existing role
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Param id path int true "Role ID"
+// @Param data body request.UpdateRole true "UpdateRole payload"
+// @Success 200 {object} response.RoleDetail
+// @Failure 400 {object} http.Error
+// @Failure 401 {object} http.Error
+// @Failure 403 {object} http.Error
+// @Failure 404 {object} http.Error
+// @Security ApiKeyAuth
+// @Router /roles/{id} [put]
+func (h *UpdateRoleApi) Handle(c *core.Ctx) error {
+	roleID := c.GetData(http.PathIDKey).(int)
+	requestData := c.GetData(http.RequestKey).(request.UpdateRole)
+	actor := c.GetData(http.UserKey).(models.User)
+
+	role, err := services.UpdateRole(actor.ID, roleID, requestData.ToDto())
+	if err != nil {
+		status := core.StatusBadRequest
+		switch {
+		case errors.Is(err, services.ErrRoleNotFound):
+			status = core.StatusNotFound
+		case errors.Is(err, services.ErrRoleRank):
+			status = core.StatusForbidden
+		}
+
+		return c.Error(http.Error{
+			Message: err.Error(),
+		}, status)
+	}
+
+	return c.Success(roleResponse(*role))
+}
@@ -1,11 +1,14 @@
 package user
 
 import (
+	"gfly/internal/domain/models"
 	"gfly/internal/http/request"
 	_ "gfly/internal/http/response" // Used for Swagger documentation
 	"gfly/internal/http/transformers"
 	"gfly/internal/services"
+	"gfly/pkg/modules/auth/password"
 	"github.com/gflydev/core"
+	"github.com/gflydev/core/errors"
 	"github.com/gflydev/http"
 )
 
@@ -26,7 +29,11 @@ func NewCreateUserApi() *CreateUserApi {
 // ====================================================================
 
 func (h *CreateUserApi) Validate(c *core.Ctx) error {
-	return http.ProcessData[request.CreateUser](c)
+	if err := password.ProcessData[request.CreateUser](c); err != nil {
+		return err
+	}
+
+	return request.ProcessFieldset(c, transformers.UserFields, transformers.UserIncludes)
 }
 
 // ====================================================================
@@ -35,6 +42,7 @@ func (h *CreateUserApi) Validate(c *core.Ctx) error {
 
 // Handle function allows Administrator create a new user with specific roles
 // @Description Function allows Administrator create a new user with specific roles
+// @Description The roles must be below the roles of the current user.
 // @Summary Create a new user for Administrator
 // @Tags Users
 // @Accept json
@@ -43,20 +51,29 @@ func (h *CreateUserApi) Validate(c *core.Ctx) error {
 // @Success 201 {object} response.User
 // @Failure 400 {object} http.Error
 // @Failure 401 {object} http.Error
+// @Failure 403 {object} http.Error
 // @Security ApiKeyAuth
+// @Param fields query string false "Fields of the response, e.g. id,email,status (default: all fields)"
+// @Param include query string false "Relationships of the response: roles, addresses (default: all when fields is empty)"
 // @Router /users [post]
 func (h *CreateUserApi) Handle(c *core.Ctx) error {
 	requestData := c.GetData(http.RequestKey).(request.CreateUser)
+	actor := c.GetData(http.UserKey).(models.User)
 
-	user, err := services.CreateUser(requestData.ToDto())
+	user, err := services.CreateUser(actor.ID, requestData.ToDto())
 	if err != nil {
+		status := core.StatusBadRequest
+		if errors.Is(err, services.ErrRoleRank) {
+			status = core.StatusForbidden
+		}
+
 		return c.Error(http.Error{
 			Message: err.Error(),
-		})
+		}, status)
 	}
 
 	// Transform to response data
-	userResponse := transformers.ToUserResponse(*user)
+	userResponse := transformers.ToUserResponse(*user, request.FieldsetData(c))
 
 	return c.
 		Status(core.StatusCreated).
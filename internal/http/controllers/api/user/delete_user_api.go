@@ -2,6 +2,7 @@ package user
 
 import (
 	_ "gfly/internal/http/response" // Used for Swagger documentation
+	"gfly/internal/policies"
 	"gfly/internal/services"
 	"github.com/gflydev/core"
 	"github.com/gflydev/http"
@@ -31,22 +32,27 @@ func (h *DeleteUserApi) Validate(c *core.Ctx) error {
 // ========================= Request Handling =========================
 // ====================================================================
 
-// Handle function hard-delete user with its roles by given userID.
-// @Description Function hard-delete user with its roles by given userID.
+// Handle function soft-delete user by given userID.
+// @Description Function soft-delete user by given userID. The user keeps their roles but can not sign in anymore.
+// @Description The user can be restored until the scheduled purge deletes the account permanently.
 // @Summary Delete user by given userID
 // @Tags Users
 // @Accept json
 // @Produce json
 // @Param id path int true "User ID"
-// @Success 200 {object} response.User
+// @Success 204
 // @Failure 401 {object} http.Error
+// @Failure 403 {object} http.Error
 // @Failure 404 {object} http.Error
 // @Security ApiKeyAuth
 // @Router /users/{id} [delete]
 func (h *DeleteUserApi) Handle(c *core.Ctx) error {
-	userId := c.GetData(http.PathIDKey).(int)
+	user, err := authorizeUser(c, policies.AbilityDelete)
+	if err != nil {
+		return err
+	}
 
-	err := services.DeleteUserByID(userId)
+	err = services.DeleteUserByID(user.ID)
 	if err != nil {
 		return c.Error(http.Error{
 			Message: err.Error(),
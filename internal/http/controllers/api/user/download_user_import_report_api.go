@@ -0,0 +1,77 @@
+package user
+
+import (
+	"fmt"
+	"gfly/internal/services"
+	"github.com/gflydev/core"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/http"
+	"github.com/gflydev/storage"
+	"strings"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewDownloadUserImportReportApi As a constructor to create new API.
+func NewDownloadUserImportReportApi() *DownloadUserImportReportApi {
+	return &DownloadUserImportReportApi{}
+}
+
+// DownloadUserImportReportApi API struct.
+type DownloadUserImportReportApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ======================== Request Validation ========================
+// ====================================================================
+
+func (h *DownloadUserImportReportApi) Validate(c *core.Ctx) error {
+	return http.ProcessPathID(c)
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle function download the error report of a user import.
+// @Description Download the rows of a user import which were not imported, as CSV with the columns line, email, error.
+// @Summary Download user import error report
+// @Tags Users
+// @Produce text/csv
+// @Param id path int true "Import ID"
+// @Success 200 {file} file
+// @Failure 401 {object} http.Error
+// @Failure 403 {object} http.Error
+// @Failure 404 {object} http.Error
+// @Security ApiKeyAuth
+// @Router /users/imports/{id}/report [get]
+func (h *DownloadUserImportReportApi) Handle(c *core.Ctx) error {
+	importID := c.GetData(http.PathIDKey).(int)
+
+	userImport, err := services.GetUserImport(importID)
+	if err != nil || !userImport.ReportPath.Valid {
+		return c.Error(http.Error{
+			Message: "Report not found",
+		}, core.StatusNotFound)
+	}
+
+	report, err := storage.Instance().Get(userImport.ReportPath.String)
+	if err != nil {
+		log.Errorf("Unable to read user import report %s: %v", userImport.ReportPath.String, err)
+
+		return c.Error(http.Error{
+			Message: "Report not found",
+		}, core.StatusNotFound)
+	}
+
+	fileName := strings.TrimSuffix(userImport.FileName, ".csv")
+	fileName = strings.TrimSuffix(fileName, ".xlsx") + "-errors.csv"
+
+	return c.
+		ContentType("text/csv; charset=utf-8").
+		SetHeader("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName)).
+		Raw(report)
+}
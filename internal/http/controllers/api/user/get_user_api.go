@@ -2,8 +2,10 @@ package user
 
 import (
 	"gfly/internal/domain/models"
+	"gfly/internal/http/request"
 	_ "gfly/internal/http/response" // Used for Swagger documentation
 	"gfly/internal/http/transformers"
+	"gfly/internal/policies"
 	"github.com/gflydev/core"
 	"github.com/gflydev/core/log"
 	mb "github.com/gflydev/db"
@@ -27,7 +29,11 @@ func NewGetUserByIdApi() *GetUserByIdApi {
 // ====================================================================
 
 func (h *GetUserByIdApi) Validate(c *core.Ctx) error {
-	return http.ProcessPathID(c)
+	if err := http.ProcessPathID(c); err != nil {
+		return err
+	}
+
+	return request.ProcessFieldset(c, transformers.UserFields, transformers.UserIncludes)
 }
 
 // ====================================================================
@@ -42,24 +48,51 @@ func (h *GetUserByIdApi) Validate(c *core.Ctx) error {
 // @Produce json
 // @Param id path int true "User ID"
 // @Success 200 {object} response.User
+// @Failure 400 {object} http.Error
 // @Failure 401 {object} http.Error
+// @Failure 403 {object} http.Error
 // @Failure 404 {object} http.Error
 // @Security ApiKeyAuth
+// @Param fields query string false "Fields of the response, e.g. id,email,status (default: all fields)"
+// @Param include query string false "Relationships of the response: roles, addresses (default: all when fields is empty)"
 // @Router /users/{id} [get]
 func (h *GetUserByIdApi) Handle(c *core.Ctx) error {
-	userID := c.GetData(http.PathIDKey).(int)
+	user, err := authorizeUser(c, policies.AbilityView)
+	if err != nil {
+		return err
+	}
+
+	// Transform to response data
+	userTransformer := transformers.ToUserResponse(*user, request.FieldsetData(c))
+
+	return c.Success(userTransformer)
+}
+
+// ====================================================================
+// ========================= Helper Functions =========================
+// ====================================================================
+
+// authorizeUser loads the user of path parameter `id` (soft-deleted users are not found)
+// then checks that the current user can perform the ability on it (see policies.UserPolicy).
+// The error response (404 or 403) is already written when an error is returned.
+func authorizeUser(c *core.Ctx, ability string) (*models.User, error) {
+	userID, errData := http.PathID(c)
+	if errData != nil {
+		return nil, c.Error(errData)
+	}
 
 	user, err := mb.GetModelByID[models.User](userID)
-	if err != nil {
-		log.Error(err)
+	if err != nil || user.DeletedAt.Valid {
+		log.Errorf("User %d not found '%v'", userID, err)
 
-		return c.Error(http.Error{
+		return nil, c.Error(http.Error{
 			Message: "User not found",
 		}, core.StatusNotFound)
 	}
 
-	// Transform to response data
-	userTransformer := transformers.ToUserResponse(*user)
+	if err = policies.Gate.Authorize(c, ability, *user); err != nil {
+		return nil, err
+	}
 
-	return c.Success(userTransformer)
+	return user, nil
 }
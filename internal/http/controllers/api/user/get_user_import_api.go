@@ -0,0 +1,61 @@
+package user
+
+import (
+	_ "gfly/internal/http/response" // Used for Swagger documentation
+	"gfly/internal/http/transformers"
+	"gfly/internal/services"
+	"github.com/gflydev/core"
+	"github.com/gflydev/http"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewGetUserImportApi As a constructor to create new API.
+func NewGetUserImportApi() *GetUserImportApi {
+	return &GetUserImportApi{}
+}
+
+// GetUserImportApi API struct.
+type GetUserImportApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ======================== Request Validation ========================
+// ====================================================================
+
+func (h *GetUserImportApi) Validate(c *core.Ctx) error {
+	return http.ProcessPathID(c)
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle function get the progress of a user import by given id.
+// @Description Function get the progress of a user import. `report_url` is set when some rows were not imported.
+// @Summary Get user import progress
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path int true "Import ID"
+// @Success 200 {object} response.UserImport
+// @Failure 401 {object} http.Error
+// @Failure 403 {object} http.Error
+// @Failure 404 {object} http.Error
+// @Security ApiKeyAuth
+// @Router /users/imports/{id} [get]
+func (h *GetUserImportApi) Handle(c *core.Ctx) error {
+	importID := c.GetData(http.PathIDKey).(int)
+
+	userImport, err := services.GetUserImport(importID)
+	if err != nil {
+		return c.Error(http.Error{
+			Message: err.Error(),
+		}, core.StatusNotFound)
+	}
+
+	return c.Success(transformers.ToUserImportResponse(*userImport))
+}
@@ -2,6 +2,7 @@ package user
 
 import (
 	"gfly/internal/domain/models"
+	"gfly/internal/http/request"
 	_ "gfly/internal/http/response" // Used for Swagger documentation
 	"gfly/internal/http/transformers"
 	"github.com/gflydev/core"
@@ -22,6 +23,14 @@ type GetUserProfileApi struct {
 	core.Api
 }
 
+// ====================================================================
+// ======================== Request Validation ========================
+// ====================================================================
+
+func (h *GetUserProfileApi) Validate(c *core.Ctx) error {
+	return request.ProcessFieldset(c, transformers.UserFields, transformers.UserIncludes)
+}
+
 // ====================================================================
 // ========================= Request Handling =========================
 // ====================================================================
@@ -32,6 +41,8 @@ type GetUserProfileApi struct {
 // @Tags Users
 // @Accept json
 // @Produce json
+// @Param fields query string false "Fields of the response, e.g. id,email,status (default: all fields)"
+// @Param include query string false "Relationships of the response: roles, addresses (default: all when fields is empty)"
 // @Success 200 {object} response.User
 // @Failure 400 {object} http.Error
 // @Security ApiKeyAuth
@@ -46,7 +57,7 @@ func (h *GetUserProfileApi) Handle(c *core.Ctx) error {
 	user := c.GetData(http.UserKey).(models.User)
 
 	// Transform to response data
-	var userRes = transformers.ToUserResponse(user)
+	var userRes = transformers.ToUserResponse(user, request.FieldsetData(c))
 
 	return c.Success(userRes)
 }
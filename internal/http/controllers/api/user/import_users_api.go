@@ -0,0 +1,87 @@
+package user
+
+import (
+	"gfly/internal/domain/models"
+	_ "gfly/internal/http/response" // Used for Swagger documentation
+	"gfly/internal/http/transformers"
+	"gfly/internal/services"
+	"github.com/gflydev/core"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/http"
+	"os"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewImportUsersApi As a constructor to create new API.
+func NewImportUsersApi() *ImportUsersApi {
+	return &ImportUsersApi{}
+}
+
+// ImportUsersApi API struct.
+type ImportUsersApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ======================== Request Validation ========================
+// ====================================================================
+
+func (h *ImportUsersApi) Validate(c *core.Ctx) error {
+	files, err := c.FormUpload("file")
+	if err != nil || len(files) == 0 {
+		return c.Error(http.Error{
+			Message: "File is required",
+		})
+	}
+
+	// Store data into context
+	c.SetData(http.RequestKey, files[0])
+
+	return nil
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle function allows Administrator import many users from a CSV or XLSX file.
+// @Description Upload a CSV or XLSX file (max 10 MB) to create many users. The file is imported by the queue worker.
+// @Description The header row names the columns: email, password, fullname, phone (required), avatar, status, roles (optional, separated by `,` or `|`).
+// @Description The roles must be below the roles of the current user, other rows are reported.
+// @Description Each row is validated like `POST /users`. Follow the progress with `GET /users/imports/{id}`.
+// @Summary Import users from a CSV or XLSX file
+// @Tags Users
+// @Accept mpfd
+// @Produce json
+// @Param file formData file true "CSV or XLSX file"
+// @Success 202 {object} response.UserImport
+// @Failure 400 {object} http.Error
+// @Failure 401 {object} http.Error
+// @Failure 403 {object} http.Error
+// @Security ApiKeyAuth
+// @Router /users/imports [post]
+func (h *ImportUsersApi) Handle(c *core.Ctx) error {
+	upload := c.GetData(http.RequestKey).(core.UploadedFile)
+	actor := c.GetData(http.UserKey).(models.User)
+
+	// The file is copied into the storage
+	defer func() {
+		if err := os.Remove(upload.Path); err != nil {
+			log.Warnf("Unable to remove uploaded file %s: %v", upload.Path, err)
+		}
+	}()
+
+	userImport, err := services.ImportUsers(actor.ID, upload.Name, upload.Path)
+	if err != nil {
+		return c.Error(http.Error{
+			Message: err.Error(),
+		})
+	}
+
+	return c.
+		Status(core.StatusAccepted).
+		JSON(transformers.ToUserImportResponse(*userImport))
+}
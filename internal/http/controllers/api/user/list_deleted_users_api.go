@@ -0,0 +1,63 @@
+package user
+
+import (
+	"gfly/internal/http/request"
+	_ "gfly/internal/http/response" // Used for Swagger documentation
+	"gfly/internal/http/transformers"
+	"gfly/internal/services"
+	"github.com/gflydev/core"
+	"github.com/gflydev/http"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+type ListDeletedUsersApi struct {
+	http.ListApi
+}
+
+func NewListDeletedUsersApi() *ListDeletedUsersApi {
+	return &ListDeletedUsersApi{}
+}
+
+// ====================================================================
+// ======================== Request Validation ========================
+// ====================================================================
+
+func (h *ListDeletedUsersApi) Validate(c *core.Ctx) error {
+	if err := request.ProcessFilter(c); err != nil {
+		return err
+	}
+
+	return request.ProcessFieldset(c, transformers.UserFields, transformers.UserIncludes)
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle Process main logic for API.
+// @Summary Function list soft-deleted users (trash)
+// @Description Function list soft-deleted users which can be restored until they are purged
+// @Description <b>Keyword fields:</b> roles.name, roles.slug, users.email, users.fullname, users.phone, user.status
+// @Description <b>Order_by fields:</b> users.email, users.fullname, users.phone, users.status, users.last_access_at, users.deleted_at (default: -deleted_at)
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param keyword query string false "Keyword"
+// @Param order_by query string false "Order By"
+// @Param page query int false "Page"
+// @Param per_page query int false "Items Per Page"
+// @Param cursor query string false "Cursor (next_cursor or prev_cursor of the previous response). Replaces page"
+// @Param fields query string false "Fields of the response, e.g. id,email,status (default: all fields)"
+// @Param include query string false "Relationships of the response: roles, addresses (default: all when fields is empty)"
+// @Failure 400 {object} http.Error
+// @Failure 401 {object} http.Error
+// @Failure 403 {object} http.Error
+// @Success 200 {object} response.ListUser{data=[]response.User}
+// @Security ApiKeyAuth
+// @Router /users/trash [get]
+func (h *ListDeletedUsersApi) Handle(c *core.Ctx) error {
+	return listUsers(c, services.FindDeletedUsers)
+}
@@ -1,11 +1,14 @@
 package user
 
 import (
+	"gfly/internal/domain/models"
 	"gfly/internal/dto"
+	"gfly/internal/http/request"
 	"gfly/internal/http/response"
 	"gfly/internal/http/transformers"
 	"gfly/internal/services"
 	"github.com/gflydev/core"
+	"github.com/gflydev/core/errors"
 	"github.com/gflydev/http"
 )
 
@@ -21,6 +24,18 @@ func NewListUsersApi() *ListUsersApi {
 	return &ListUsersApi{}
 }
 
+// ====================================================================
+// ======================== Request Validation ========================
+// ====================================================================
+
+func (h *ListUsersApi) Validate(c *core.Ctx) error {
+	if err := request.ProcessFilter(c); err != nil {
+		return err
+	}
+
+	return request.ProcessFieldset(c, transformers.UserFields, transformers.UserIncludes)
+}
+
 // ====================================================================
 // ========================= Request Handling =========================
 // ====================================================================
@@ -30,34 +45,63 @@ func NewListUsersApi() *ListUsersApi {
 // @Description Function list all users data
 // @Description <b>Keyword fields:</b> roles.name, roles.slug, users.email, users.fullname, users.phone, user.status
 // @Description <b>Order_by fields:</b> users.email, users.fullname, users.phone, users.status, users.last_access_at
+// @Description <b>Sort:</b> many order_by fields, comma separated, `-` for descending. E.g. `sort=-last_access,email`
+// @Description <b>Filter fields:</b> `filter[field][operator]=value1,value2`. Operator defaults to `eq`
+// @Description - id: eq, ne, gt, gte, lt, lte
+// @Description - email, fullname, phone: eq, ne, like
+// @Description - status: eq, ne (active, pending, blocked)
+// @Description - role: eq (role slug)
+// @Description - created_at, verified_at, blocked_at, last_access_at, deleted_at: gt, gte, lt, lte (2026-01-31 or RFC 3339)
 // @Tags Users
 // @Accept json
 // @Produce json
 // @Param keyword query string false "Keyword"
 // @Param order_by query string false "Order By"
+// @Param sort query string false "Sort. Replaces order_by"
+// @Param filter[status] query string false "Filter by status, e.g. active,pending"
+// @Param filter[role] query string false "Filter by role slug, e.g. admin"
+// @Param filter[created_at][gte] query string false "Filter by creation date, e.g. 2026-01-01"
 // @Param page query int false "Page"
 // @Param per_page query int false "Items Per Page"
+// @Param cursor query string false "Cursor (next_cursor or prev_cursor of the previous response). Replaces page"
 // @Failure 400 {object} http.Error
 // @Failure 401 {object} http.Error
-// @Success 200 {object} response.ListUser
+// @Success 200 {object} response.ListUser{data=[]response.User}
 // @Security ApiKeyAuth
+// @Param fields query string false "Fields of the response, e.g. id,email,status (default: all fields)"
+// @Param include query string false "Relationships of the response: roles, addresses (default: all when fields is empty)"
 // @Router /users [get]
 func (h *ListUsersApi) Handle(c *core.Ctx) error {
+	return listUsers(c, services.FindUsers)
+}
+
+// listUsers responds a page of the users found by the given function.
+func listUsers(c *core.Ctx, find func(dto.Filter) ([]models.User, dto.Pagination, error)) error {
 	filterDto := c.GetData(http.FilterKey).(dto.Filter)
-	users, total, err := services.FindUsers(filterDto)
+	users, pagination, err := find(filterDto)
+	if errors.Is(err, services.ErrInvalidFilter) || errors.Is(err, services.ErrInvalidCursor) {
+		return c.Error(http.Error{
+			Message: err.Error(),
+		}, core.StatusBadRequest)
+	}
 	if err != nil {
 		return err
 	}
 
-	// Pagination metadata
-	metadata := http.Meta{
-		Page:    filterDto.Page,
-		PerPage: filterDto.PerPage,
-		Total:   total,
+	// Pagination metadata. The page is meaningless with a cursor.
+	metadata := response.Meta{
+		Page:       filterDto.Page,
+		PerPage:    filterDto.PerPage,
+		Total:      pagination.Total,
+		NextCursor: pagination.NextCursor,
+		PrevCursor: pagination.PrevCursor,
+	}
+	if filterDto.Cursor != "" {
+		metadata.Page = 0
 	}
 
 	// Transform to response data
-	data := http.ToListResponse(users, transformers.ToUserResponse)
+	data := transformers.ToListUserResponse(users, request.FieldsetData(c))
 
 	return c.Success(response.ListUser{
 		Meta: metadata,
@@ -0,0 +1,82 @@
+package user
+
+import (
+	"gfly/internal/domain/models"
+	"gfly/internal/http/request"
+	_ "gfly/internal/http/response" // Used for Swagger documentation
+	"gfly/internal/http/transformers"
+	"gfly/internal/policies"
+	"gfly/internal/services"
+	"github.com/gflydev/core"
+	mb "github.com/gflydev/db"
+	"github.com/gflydev/http"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+type RestoreUserApi struct {
+	core.Api
+}
+
+func NewRestoreUserApi() *RestoreUserApi {
+	return &RestoreUserApi{}
+}
+
+// ====================================================================
+// ======================== Request Validation ========================
+// ====================================================================
+
+func (h *RestoreUserApi) Validate(c *core.Ctx) error {
+	if err := http.ProcessPathID(c); err != nil {
+		return err
+	}
+
+	return request.ProcessFieldset(c, transformers.UserFields, transformers.UserIncludes)
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle function restores a soft-deleted user by given userID.
+// @Description Function restores a soft-deleted user by given userID. The user can sign in again with their roles.
+// @Summary Restore deleted user by given userID
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} response.User
+// @Failure 400 {object} http.Error
+// @Failure 401 {object} http.Error
+// @Failure 403 {object} http.Error
+// @Failure 404 {object} http.Error
+// @Security ApiKeyAuth
+// @Param fields query string false "Fields of the response, e.g. id,email,status (default: all fields)"
+// @Param include query string false "Relationships of the response: roles, addresses (default: all when fields is empty)"
+// @Router /users/{id}/restore [post]
+func (h *RestoreUserApi) Handle(c *core.Ctx) error {
+	userID := c.GetData(http.PathIDKey).(int)
+
+	user, err := mb.GetModelByID[models.User](userID)
+	if err != nil || !user.DeletedAt.Valid {
+		return c.Error(http.Error{
+			Message: "User not found",
+		}, core.StatusNotFound)
+	}
+
+	// Restoring is the undo of deleting
+	if err = policies.Gate.Authorize(c, policies.AbilityDelete, *user); err != nil {
+		return err
+	}
+
+	user, err = services.RestoreUserByID(user.ID)
+	if err != nil {
+		return c.Error(http.Error{
+			Message: err.Error(),
+		}, core.StatusNotFound)
+	}
+
+	return c.Success(transformers.ToUserResponse(*user, request.FieldsetData(c)))
+}
@@ -1,11 +1,15 @@
 package user
 
 import (
+	"gfly/internal/domain/models"
 	"gfly/internal/http/request"
 	_ "gfly/internal/http/response" // Used for Swagger documentation
 	"gfly/internal/http/transformers"
+	"gfly/internal/policies"
 	"gfly/internal/services"
+	"gfly/pkg/modules/auth/password"
 	"github.com/gflydev/core"
+	"github.com/gflydev/core/errors"
 	"github.com/gflydev/http"
 )
 
@@ -26,7 +30,11 @@ func NewUpdateUserApi() *UpdateUserApi {
 // ====================================================================
 
 func (h *UpdateUserApi) Validate(c *core.Ctx) error {
-	return http.ProcessUpdateData[request.UpdateUser](c)
+	if err := password.ProcessUpdateData[request.UpdateUser](c); err != nil {
+		return err
+	}
+
+	return request.ProcessFieldset(c, transformers.UserFields, transformers.UserIncludes)
 }
 
 // ====================================================================
@@ -35,6 +43,7 @@ func (h *UpdateUserApi) Validate(c *core.Ctx) error {
 
 // Handle function allows Administrator update users table or authorize user roles.
 // @Description Function allows Administrator update users table or authorize user roles.
+// @Description The roles must be below the roles of the current user.
 // @Summary Function allows Administrator update an existing user
 // @Tags Users
 // @Accept json
@@ -44,20 +53,39 @@ func (h *UpdateUserApi) Validate(c *core.Ctx) error {
 // @Success 200 {object} response.User
 // @Failure 400 {object} http.Error
 // @Failure 401 {object} http.Error
+// @Failure 403 {object} http.Error
+// @Failure 404 {object} http.Error
 // @Security ApiKeyAuth
+// @Param fields query string false "Fields of the response, e.g. id,email,status (default: all fields)"
+// @Param include query string false "Relationships of the response: roles, addresses (default: all when fields is empty)"
 // @Router /users/{id} [put]
 func (h *UpdateUserApi) Handle(c *core.Ctx) error {
 	requestData := c.GetData(http.RequestKey).(request.UpdateUser)
 
-	user, err := services.UpdateUser(requestData.ToDto())
+	target, err := authorizeUser(c, policies.AbilityUpdate)
+	if err != nil {
+		return err
+	}
+
+	updateUserDto := requestData.ToDto()
+	updateUserDto.ID = target.ID
+
+	actor := c.GetData(http.UserKey).(models.User)
+
+	user, err := services.UpdateUser(actor.ID, updateUserDto)
 	if err != nil {
+		status := core.StatusBadRequest
+		if errors.Is(err, services.ErrRoleRank) {
+			status = core.StatusForbidden
+		}
+
 		return c.Error(http.Error{
 			Message: err.Error(),
-		})
+		}, status)
 	}
 
 	// Transform to response data
-	userTransformer := transformers.ToUserResponse(*user)
+	userTransformer := transformers.ToUserResponse(*user, request.FieldsetData(c))
 
 	return c.Success(userTransformer)
 }
@@ -4,6 +4,7 @@ import (
 	"gfly/internal/http/request"
 	_ "gfly/internal/http/response" // Used for Swagger documentation
 	"gfly/internal/http/transformers"
+	"gfly/internal/policies"
 	"gfly/internal/services"
 	"github.com/gflydev/core"
 	"github.com/gflydev/http"
@@ -26,7 +27,11 @@ func NewUpdateUserStatusApi() *UpdateUserStatusApi {
 // ====================================================================
 
 func (h UpdateUserStatusApi) Validate(c *core.Ctx) error {
-	return http.ProcessUpdateData[request.UpdateUserStatus](c)
+	if err := http.ProcessUpdateData[request.UpdateUserStatus](c); err != nil {
+		return err
+	}
+
+	return request.ProcessFieldset(c, transformers.UserFields, transformers.UserIncludes)
 }
 
 // ====================================================================
@@ -43,21 +48,33 @@ func (h UpdateUserStatusApi) Validate(c *core.Ctx) error {
 // @Param request body request.UpdateUserStatus true "Update user status data"
 // @Failure 400 {object} http.Error
 // @Failure 401 {object} http.Error
+// @Failure 403 {object} http.Error
+// @Failure 404 {object} http.Error
 // @Success 200 {object} response.User
 // @Security ApiKeyAuth
+// @Param fields query string false "Fields of the response, e.g. id,email,status (default: all fields)"
+// @Param include query string false "Relationships of the response: roles, addresses (default: all when fields is empty)"
 // @Router /users/{id}/status [put]
 func (h UpdateUserStatusApi) Handle(c *core.Ctx) error {
 	requestData := c.GetData(http.RequestKey).(request.UpdateUserStatus)
 
+	target, err := authorizeUser(c, policies.AbilityUpdate)
+	if err != nil {
+		return err
+	}
+
 	// Bind data to service
-	user, err := services.UpdateUserStatus(requestData.ToDto())
+	updateUserStatusDto := requestData.ToDto()
+	updateUserStatusDto.ID = target.ID
+
+	user, err := services.UpdateUserStatus(updateUserStatusDto)
 	if err != nil {
 		c.Status(core.StatusBadRequest)
 		return err
 	}
 
 	// Transform response data
-	userResponse := transformers.ToUserResponse(*user)
+	userResponse := transformers.ToUserResponse(*user, request.FieldsetData(c))
 
 	return c.Success(userResponse)
 }
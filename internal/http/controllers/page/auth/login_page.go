@@ -2,6 +2,7 @@ package auth
 
 import (
 	"gfly/internal/http/controllers/page"
+	"gfly/pkg/modules/auth/oauth"
 	"github.com/gflydev/core"
 	"github.com/gflydev/http"
 )
@@ -28,5 +29,7 @@ func (m *LoginPage) Handle(c *core.Ctx) error {
 		return c.Redirect("/profile")
 	}
 
-	return m.View(c, "login", core.Data{})
+	return m.View(c, "login", core.Data{
+		"oauth_providers": oauth.Names(),
+	})
 }
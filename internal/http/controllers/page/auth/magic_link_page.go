@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"gfly/internal/http/controllers/page"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewMagicLinkPage As a constructor to create a Magic Link Page.
+func NewMagicLinkPage() *MagicLinkPage {
+	return &MagicLinkPage{}
+}
+
+type MagicLinkPage struct {
+	page.BasePage
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle renders the page of the passwordless sign-in link.
+// The page submits the link data to the API `/frontend/auth/magic-link/consume`.
+func (m *MagicLinkPage) Handle(c *core.Ctx) error {
+	return m.View(c, "magic_link", core.Data{
+		"id":        c.QueryStr("id"),
+		"expires":   c.QueryStr("expires"),
+		"token":     c.QueryStr("token"),
+		"signature": c.QueryStr("signature"),
+	})
+}
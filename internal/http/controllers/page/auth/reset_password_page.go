@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"gfly/internal/http/controllers/page"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewResetPasswordPage As a constructor to create a Reset Password Page.
+func NewResetPasswordPage() *ResetPasswordPage {
+	return &ResetPasswordPage{}
+}
+
+type ResetPasswordPage struct {
+	page.BasePage
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle renders the page of the link sent by forgot password.
+// The page submits the new password to the API `/password/reset`.
+func (m *ResetPasswordPage) Handle(c *core.Ctx) error {
+	return m.View(c, "reset_password", core.Data{
+		"token": c.QueryStr("token"),
+	})
+}
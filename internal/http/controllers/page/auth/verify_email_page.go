@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"gfly/internal/http/controllers/page"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewVerifyEmailPage As a constructor to create a Verify Email Page.
+func NewVerifyEmailPage() *VerifyEmailPage {
+	return &VerifyEmailPage{}
+}
+
+type VerifyEmailPage struct {
+	page.BasePage
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle renders the page of the signed link sent at signup.
+// The page submits the link data to the API `/auth/verify-email`.
+func (m *VerifyEmailPage) Handle(c *core.Ctx) error {
+	return m.View(c, "verify_email", core.Data{
+		"id":        c.QueryStr("id"),
+		"expires":   c.QueryStr("expires"),
+		"signature": c.QueryStr("signature"),
+	})
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"gfly/internal/domain/models/types"
+	authMiddleware "gfly/pkg/modules/auth/middleware"
+	"github.com/gflydev/core"
+)
+
+// CheckPermissionsMiddleware is a middleware that verifies the access token was granted all
+// the required permissions. Permissions are scopes of the token, resolved from the user roles
+// at sign-in (JWT `scope` claim) or from the role scopes of a personal access token, so no
+// database query is made. Must run after JWTAuth.
+//
+// Use:
+//
+//	userRouter.GET("", r.Apply(middleware.CheckPermissionsMiddleware(types.PermissionUsersRead))(user.NewListUsersApi()))
+//
+// Parameters:
+//   - permissions (...types.Permission): The permissions required to access the route.
+//
+// Returns:
+//   - core.MiddlewareHandler: A middleware handler function.
+//
+// @Throws response.Error with code 401 when user is not authenticated
+// @Throws response.Error with code 403 when the token lacks a required permission
+func CheckPermissionsMiddleware(permissions ...types.Permission) core.MiddlewareHandler {
+	scopes := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		scopes = append(scopes, string(permission))
+	}
+
+	return authMiddleware.RequireScopes(scopes...)
+}
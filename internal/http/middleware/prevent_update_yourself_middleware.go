@@ -1,47 +0,0 @@
-package middleware
-
-import (
-	"gfly/internal/domain/models"
-	"github.com/gflydev/core"
-	"github.com/gflydev/http"
-	"strconv"
-)
-
-// PreventUpdateYourSelf is a middleware that prevents a user from updating their own data.
-// It extracts the user ID from the request path and compares it with the current authenticated user’s ID.
-// If the user attempts to update their own record, it returns a 403 Forbidden status.
-//
-// @Param id path string true "User ID to update"
-// @Success 200 {object} nil "Success"
-// @Failure 403 {object} http.Error "Forbidden: User cannot update their own record"
-// @Failure 400 {object} http.Error "Bad Request: Invalid User ID"
-func PreventUpdateYourSelf(c *core.Ctx) error {
-	// Parse request parameter
-	userId, err := strconv.Atoi(c.PathVal("id"))
-	if err != nil {
-		return err
-	}
-
-	if c.GetData(http.UserKey) == nil {
-		return c.Error(http.Error{
-			Message: "Unauthorized",
-		}, core.StatusUnauthorized)
-	}
-
-	// Get user data
-	user := c.GetData(http.UserKey).(models.User)
-
-	// In-case the user updates itself
-	if userId == user.ID {
-		// Force update request header
-		c.Root().Request.Header.SetContentType(core.MIMEApplicationJSONCharsetUTF8)
-
-		return c.Error(http.Error{
-			Message: "Don't allow update yourself",
-		}, core.StatusForbidden)
-	}
-
-	c.Status(core.StatusOK)
-
-	return nil
-}
@@ -0,0 +1,96 @@
+package request
+
+import (
+	"gfly/internal/dto"
+	"github.com/gflydev/core"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/http"
+	"slices"
+	"strings"
+)
+
+// FieldsetKey the key of dto.Fieldset in the Ctx's Data.
+const FieldsetKey = "__fieldset__"
+
+// ====================================================================
+// ========================= Fieldset Requests ========================
+// ====================================================================
+
+// ProcessFieldset parses the sparse fieldset `fields=id,email` and the relationships `include=roles`
+// of a request, then puts dto.Fieldset into the Ctx's Data (key `FieldsetKey`).
+//
+// Without `fields` and `include`, the response has all fields and all relationships.
+// Unknown fields and relationships are refused (400).
+//
+// Example Usage:
+//
+//	func (h GetUserByIdApi) Validate(c *core.Ctx) error {
+//		return request.ProcessFieldset(c, transformers.UserFields, transformers.UserIncludes)
+//	}
+func ProcessFieldset(c *core.Ctx, fields, includes []string) error {
+	fieldset := dto.Fieldset{
+		Fields:  splitList(c.QueryStr("fields")),
+		Include: splitList(c.QueryStr("include")),
+	}
+
+	if err := checkFieldset(fieldset, fields, includes); err != nil {
+		return c.Error(http.Error{
+			Message: err.Error(),
+		}, core.StatusBadRequest)
+	}
+
+	// Validate DTO
+	if errData := http.Validate(fieldset); errData != nil {
+		return c.Error(errData)
+	}
+
+	// Full response by default
+	if fieldset.Fields == nil && fieldset.Include == nil {
+		fieldset.Include = includes
+	}
+
+	// Store data into context.
+	c.SetData(FieldsetKey, fieldset)
+
+	return nil
+}
+
+// FieldsetData gets dto.Fieldset from the Ctx's Data. All fields and no relationships when it was not processed.
+func FieldsetData(c *core.Ctx) dto.Fieldset {
+	fieldset, _ := c.GetData(FieldsetKey).(dto.Fieldset)
+
+	return fieldset
+}
+
+// ====================================================================
+// ========================= Helper Functions =========================
+// ====================================================================
+
+// checkFieldset checks that the fieldset only uses the allowed fields and relationships.
+func checkFieldset(fieldset dto.Fieldset, fields, includes []string) error {
+	for _, field := range fieldset.Fields {
+		if !slices.Contains(fields, field) {
+			return errors.New("Unknown field %q", field)
+		}
+	}
+
+	for _, include := range fieldset.Include {
+		if !slices.Contains(includes, include) {
+			return errors.New("Unknown include %q", include)
+		}
+	}
+
+	return nil
+}
+
+// splitList splits a comma-separated list, skipping empty and duplicated items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" && !slices.Contains(items, item) {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,113 @@
+package request
+
+import (
+	"gfly/internal/dto"
+	"github.com/gflydev/core"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/http"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// filterParamPattern matches the structured filter parameters `filter[field]` and `filter[field][operator]`.
+var filterParamPattern = regexp.MustCompile(`^filter\[([a-z_]+)](?:\[([a-z]+)])?$`)
+
+// ====================================================================
+// ========================== Filter Requests =========================
+// ====================================================================
+
+// ProcessFilter parses the filter, sort, pagination and cursor parameters of a list request,
+// then puts dto.Filter into the Ctx's Data (key `http.FilterKey`).
+//
+// Fields of the structured filters are checked by the service of the list (whitelist).
+//
+// Example Usage:
+//
+//	func (h ListUsersApi) Validate(c *core.Ctx) error {
+//		return request.ProcessFilter(c)
+//	}
+func ProcessFilter(c *core.Ctx) error {
+	filter := http.FilterData(c)
+
+	conditions, err := ParseFilterConditions(c.Queries())
+	if err != nil {
+		return c.Error(http.Error{
+			Message: err.Error(),
+		}, core.StatusBadRequest)
+	}
+
+	filterDto := dto.Filter{
+		Page:       filter.Page,
+		PerPage:    filter.PerPage,
+		Keyword:    filter.Keyword,
+		OrderBy:    filter.OrderBy,
+		Cursor:     c.QueryStr("cursor"),
+		Conditions: conditions,
+		Sort:       c.QueryStr("sort"),
+	}
+
+	// Validate DTO
+	if errData := http.Validate(filterDto); errData != nil {
+		return c.Error(errData)
+	}
+
+	// Store data into context.
+	c.SetData(http.FilterKey, filterDto)
+
+	return nil
+}
+
+// ParseFilterConditions parses the structured filters of a query string.
+//
+//	filter[status]=active,pending        => status eq [active pending]
+//	filter[created_at][gte]=2026-01-01   => created_at gte [2026-01-01]
+//
+// Parameters:
+//   - queries (map[string]string): The query string parameters.
+//
+// Returns:
+//   - ([]dto.FilterCondition, error): The conditions ordered by field, or an error for a malformed parameter.
+func ParseFilterConditions(queries map[string]string) ([]dto.FilterCondition, error) {
+	var conditions []dto.FilterCondition
+
+	for key, value := range queries {
+		if !strings.HasPrefix(key, "filter[") {
+			continue
+		}
+
+		matches := filterParamPattern.FindStringSubmatch(key)
+		if matches == nil {
+			return nil, errors.New("Invalid filter parameter %q", key)
+		}
+
+		operator := matches[2]
+		if operator == "" {
+			operator = "eq"
+		}
+
+		var values []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+
+		if len(values) == 0 {
+			return nil, errors.New("Filter parameter %q has no value", key)
+		}
+
+		conditions = append(conditions, dto.FilterCondition{
+			Field:    matches[1],
+			Operator: operator,
+			Values:   values,
+		})
+	}
+
+	// Same query => same SQL
+	slices.SortFunc(conditions, func(a, b dto.FilterCondition) int {
+		return strings.Compare(a.Field+"|"+a.Operator, b.Field+"|"+b.Operator)
+	})
+
+	return conditions, nil
+}
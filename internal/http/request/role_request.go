@@ -0,0 +1,33 @@
+package request
+
+import "gfly/internal/dto"
+
+// ====================================================================
+// ========================== Add Requests ============================
+// ====================================================================
+
+// ---------------------- Create Role ------------------------
+
+type CreateRole struct {
+	dto.CreateRole
+}
+
+// ToDto Convert to CreateRole DTO object.
+func (r CreateRole) ToDto() dto.CreateRole {
+	return r.CreateRole
+}
+
+// ====================================================================
+// ========================= Update Requests ==========================
+// ====================================================================
+
+// ---------------------- Update Role ------------------------
+
+type UpdateRole struct {
+	dto.UpdateRole
+}
+
+// ToDto Convert to UpdateRole DTO object.
+func (r UpdateRole) ToDto() dto.UpdateRole {
+	return r.UpdateRole
+}
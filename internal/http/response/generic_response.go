@@ -0,0 +1,13 @@
+package response
+
+// Meta struct to describe pagination metadata with cursors.
+// @Description Pagination metadata. Pass `next_cursor` or `prev_cursor` as `cursor` to load the next or previous page.
+// @Description With a cursor, the list is not counted: total is left out and `next_cursor` is empty on the last page.
+// @Tags Info Responses
+type Meta struct {
+	Page       int    `json:"page,omitempty" example:"1" doc:"Current page number"`
+	PerPage    int    `json:"per_page,omitempty" example:"10" doc:"Number of items per page"`
+	Total      *int   `json:"total,omitempty" example:"1354" doc:"Total number of records, left out with a cursor"`
+	NextCursor string `json:"next_cursor,omitempty" example:"eyJvIjoiZW1haWwiLCJ2Ijoiam9obkBleGFtcGxlLmNvbSIsImkiOjEyfQ" doc:"Cursor of the next page"`
+	PrevCursor string `json:"prev_cursor,omitempty" example:"eyJvIjoiZW1haWwiLCJ2IjoiYW5uYUBleGFtcGxlLmNvbSIsImkiOjMsImIiOnRydWV9" doc:"Cursor of the previous page"`
+}
@@ -0,0 +1,23 @@
+package response
+
+import (
+	"gfly/internal/domain/models/types"
+	"time"
+)
+
+// RoleDetail struct to describe a role of the `/roles` API.
+type RoleDetail struct {
+	ID          int                `json:"id" example:"5" doc:"The unique identifier for the role."`
+	Name        string             `json:"name" example:"Editor" doc:"The name of the role."`
+	Slug        types.Role         `json:"slug" example:"editor" doc:"The slug (URL-friendly name) of the role."`
+	System      bool               `json:"system" example:"false" doc:"Built-in role which can not be deleted."`
+	ParentID    *int               `json:"parent_id" example:"4" doc:"The role whose roles and permissions are inherited."`
+	Permissions []types.Permission `json:"permissions" example:"users.read,users.update" doc:"The permissions granted by the role."`
+	UsersCount  int                `json:"users_count" example:"12" doc:"The number of users who have the role."`
+	CreatedAt   time.Time          `json:"created_at" example:"2023-01-01T10:30:00Z" doc:"The timestamp of when the role was created."`
+	UpdatedAt   *time.Time         `json:"updated_at" example:"2023-01-01T10:30:00Z" doc:"The timestamp of when the role was last updated."`
+}
+
+type ListRole struct {
+	Data []RoleDetail `json:"data" doc:"A list of roles."`
+}
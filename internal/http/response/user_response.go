@@ -2,18 +2,18 @@ package response
 
 import (
 	"gfly/internal/domain/models/types"
-	"github.com/gflydev/http"
+	"github.com/gflydev/core"
 	"time"
 )
 
 // User struct to describe User response.
-// The instance should be created from models.User.ToResponse()
+// The response is created by transformers.ToUserResponse with the requested fields only
+// (`fields=id,email`) and the requested relationships (`include=roles,addresses`).
 type User struct {
 	ID           int              `json:"id" doc:"The unique identifier for the user."`
 	Email        string           `json:"email" doc:"The email address of the user."`
 	Fullname     string           `json:"fullname" doc:"The full name of the user."`
 	Phone        string           `json:"phone" doc:"The phone number of the user."`
-	Token        *string          `json:"token" doc:"The authorization token of the user."`
 	Status       types.UserStatus `json:"status" doc:"The status of the user account."`
 	CreatedAt    time.Time        `json:"created_at" doc:"The timestamp of when the user was created."`
 	UpdatedAt    time.Time        `json:"updated_at" doc:"The timestamp of when the user was last updated."`
@@ -22,7 +22,8 @@ type User struct {
 	DeletedAt    *time.Time       `json:"deleted_at" example:"null" doc:"The timestamp of when the user was deleted."`
 	LastAccessAt *time.Time       `json:"last_access_at" example:"2023-01-01T12:00:00Z" doc:"The timestamp of the user's last access."`
 	Avatar       *string          `json:"avatar" doc:"The URL of the user's avatar or profile picture."`
-	Roles        []Role           `json:"roles" doc:"A list of roles assigned to the user."`
+	Roles        []Role           `json:"roles,omitempty" doc:"A list of roles assigned to the user (include=roles)."`
+	Addresses    []Address        `json:"addresses,omitempty" doc:"A list of addresses of the user (include=addresses)."`
 }
 
 // Role struct to describe Role response.
@@ -32,7 +33,38 @@ type Role struct {
 	Slug types.Role `json:"slug" doc:"The slug (URL-friendly name) of the role."`
 }
 
+// Address struct to describe Address response.
+type Address struct {
+	ID           int               `json:"id" doc:"The unique identifier for the address."`
+	Type         types.AddressType `json:"type" example:"shipping" doc:"The type of the address: address, billing or shipping."`
+	IsDefault    bool              `json:"is_default" doc:"Whether the address is the default one of its type."`
+	AddressLine1 string            `json:"address_line1" doc:"The first line of the address."`
+	AddressLine2 *string           `json:"address_line2" doc:"The second line of the address."`
+	Ward         *string           `json:"ward" doc:"The ward of the address."`
+	District     *string           `json:"district" doc:"The district of the address."`
+	City         *string           `json:"city" doc:"The city of the address."`
+	State        *string           `json:"state" doc:"The state of the address."`
+	Country      *string           `json:"country" doc:"The country of the address."`
+}
+
+// ListUser struct to describe a page of User responses (see User).
 type ListUser struct {
-	Meta http.Meta `json:"meta" doc:"Pagination metadata for a list of users."`
-	Data []User    `json:"data" doc:"A list of users matching the query criteria."`
+	Meta Meta        `json:"meta" doc:"Pagination metadata for a list of users."`
+	Data []core.Data `json:"data" doc:"A list of users matching the query criteria."`
+}
+
+// UserImport struct to describe the progress of a bulk import of users.
+type UserImport struct {
+	ID            int                    `json:"id" example:"12" doc:"The unique identifier for the import."`
+	FileName      string                 `json:"file_name" example:"users.xlsx" doc:"The name of the uploaded file."`
+	Status        types.UserImportStatus `json:"status" example:"processing" doc:"The status of the import: pending, processing, completed or failed."`
+	TotalRows     int                    `json:"total_rows" example:"250" doc:"The number of data rows of the file."`
+	ProcessedRows int                    `json:"processed_rows" example:"100" doc:"The number of rows processed so far."`
+	CreatedRows   int                    `json:"created_rows" example:"97" doc:"The number of users created."`
+	FailedRows    int                    `json:"failed_rows" example:"3" doc:"The number of rows which were not imported."`
+	Error         *string                `json:"error" example:"null" doc:"Why the file could not be processed (failed status)."`
+	ReportURL     *string                `json:"report_url" example:"/api/v1/users/imports/12/report" doc:"The URL of the per-row error report, when some rows failed."`
+	CreatedAt     time.Time              `json:"created_at" doc:"The timestamp of when the file was uploaded."`
+	StartedAt     *time.Time             `json:"started_at" doc:"The timestamp of when the import started."`
+	FinishedAt    *time.Time             `json:"finished_at" doc:"The timestamp of when the import finished."`
 }
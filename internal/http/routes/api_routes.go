@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"gfly/internal/domain/models/types"
 	"gfly/internal/http/controllers/api"
+	"gfly/internal/http/controllers/api/role"
 	"gfly/internal/http/controllers/api/user"
 	"gfly/internal/http/middleware"
+	authApi "gfly/pkg/modules/auth/api"
+	authMiddleware "gfly/pkg/modules/auth/middleware"
 	authRoute "gfly/pkg/modules/auth/routes"
 
 	"github.com/gflydev/core"
@@ -26,25 +29,64 @@ func ApiRoutes(r core.IFly) {
 		apiRouter.GET("/info", api.NewInfoApi())
 
 		/* ============================ Auth Group ============================ */
-		authRoute.RegisterApi(apiRouter)
+		authRoute.RegisterApi(r, apiRouter)
 
 		/* ============================ User Group ============================ */
 		apiRouter.Group("/users", func(userRouter *core.Group) {
-			// Allow admin permission to access `/users/*` API
-			userRouter.Use(middleware.CheckRolesMiddleware(
-				[]types.Role{types.RoleAdmin},
-				prefixAPI+"/users/profile",
-			))
-
-			preventUpdateYourSelfFunc := r.Apply(middleware.PreventUpdateYourSelf)
-
-			userRouter.GET("", user.NewListUsersApi())
-			userRouter.POST("", user.NewCreateUserApi())
-			userRouter.PUT("/{id}/status", preventUpdateYourSelfFunc(user.NewUpdateUserStatusApi()))
-			userRouter.PUT("/{id}", preventUpdateYourSelfFunc(user.NewUpdateUserApi()))
-			userRouter.DELETE("/{id}", preventUpdateYourSelfFunc(user.NewDeleteUserApi()))
-			userRouter.GET("/{id}", user.NewGetUserByIdApi())
-			userRouter.GET("/profile", user.NewGetUserProfileApi())
+			// Admin APIs are refused to impersonation tokens
+			userRouter.Use(authMiddleware.BlockImpersonation)
+
+			// Each API declares the permissions it needs. Rules on the target user are checked by `policies.UserPolicy`
+			can := func(permissions ...types.Permission) func(core.IHandler) core.IHandler {
+				return r.Apply(middleware.CheckPermissionsMiddleware(permissions...))
+			}
+
+			userRouter.GET("", can(types.PermissionUsersRead)(user.NewListUsersApi()))
+			userRouter.POST("", can(types.PermissionUsersCreate)(user.NewCreateUserApi()))
+			userRouter.GET("/trash", can(types.PermissionUsersRead, types.PermissionUsersDelete)(user.NewListDeletedUsersApi()))
+			userRouter.POST("/{id}/restore", can(types.PermissionUsersDelete)(user.NewRestoreUserApi()))
+
+			// Bulk import from CSV/XLSX files, processed by the queue worker
+			userRouter.POST("/imports", can(types.PermissionUsersCreate)(user.NewImportUsersApi()))
+			userRouter.GET("/imports/{id}", can(types.PermissionUsersCreate)(user.NewGetUserImportApi()))
+			userRouter.GET("/imports/{id}/report", can(types.PermissionUsersCreate)(user.NewDownloadUserImportReportApi()))
+
+			userRouter.PUT("/{id}/status", can(types.PermissionUsersUpdate)(user.NewUpdateUserStatusApi()))
+			userRouter.PUT("/{id}", can(types.PermissionUsersUpdate)(user.NewUpdateUserApi()))
+			userRouter.DELETE("/{id}", can(types.PermissionUsersDelete)(user.NewDeleteUserApi()))
+			userRouter.GET("/{id}", can(types.PermissionUsersRead)(user.NewGetUserByIdApi()))
+
+			// Sign in as another user (audited)
+			userRouter.POST("/{id}/impersonate", can(types.PermissionUsersImpersonate)(authApi.NewImpersonateUserApi()))
+		})
+
+		/* ============================ Role Group ============================ */
+		apiRouter.Group("/roles", func(roleRouter *core.Group) {
+			// Admin APIs are refused to impersonation tokens
+			roleRouter.Use(authMiddleware.BlockImpersonation)
+			roleRouter.Use(middleware.CheckPermissionsMiddleware(types.PermissionRolesManage))
+
+			roleRouter.GET("", role.NewListRolesApi())
+			roleRouter.POST("", role.NewCreateRoleApi())
+			roleRouter.PUT("/{id}", role.NewUpdateRoleApi())
+			roleRouter.DELETE("/{id}", role.NewDeleteRoleApi())
+			roleRouter.PUT("/{id}/users/{user_id}", role.NewAssignRoleApi())
+			roleRouter.DELETE("/{id}/users/{user_id}", role.NewRevokeRoleApi())
+		})
+
+		/* ============================ Profile Group ============================ */
+		apiRouter.Group("/users/profile", func(profileRouter *core.Group) {
+			// Sensitive APIs are refused to impersonation tokens
+			blockImpersonationFunc := r.Apply(authMiddleware.BlockImpersonation)
+
+			profileRouter.GET("", user.NewGetUserProfileApi())
+
+			// Personal access tokens
+			profileRouter.GET("/tokens", authApi.NewListAccessTokensApi())
+			profileRouter.POST("/tokens", blockImpersonationFunc(authApi.NewCreateAccessTokenApi()))
+			profileRouter.GET("/tokens/{id}", authApi.NewGetAccessTokenApi())
+			profileRouter.PUT("/tokens/{id}", blockImpersonationFunc(authApi.NewUpdateAccessTokenApi()))
+			profileRouter.DELETE("/tokens/{id}", blockImpersonationFunc(authApi.NewDeleteAccessTokenApi()))
 		})
 	})
 }
@@ -17,6 +17,9 @@ func WebRoutes(r core.IFly) {
 	r.GET("/", page.NewHomePage())
 
 	r.GET("/login", auth.NewLoginPage())
+	r.GET("/verify-email", auth.NewVerifyEmailPage())
+	r.GET("/magic-link", auth.NewMagicLinkPage())
+	r.GET("/reset-password", auth.NewResetPasswordPage())
 	r.GET("/profile", r.Apply(middleware.SessionAuthPage)(user.NewProfilePage()))
 	r.GET("/users", r.Apply(middleware.SessionAuthPage)(user.NewListPage()))
 
@@ -0,0 +1,47 @@
+package transformers
+
+import (
+	"gfly/internal/domain/models"
+	"gfly/internal/domain/models/types"
+	"gfly/internal/domain/repository"
+	"gfly/internal/http/response"
+	dbNull "github.com/gflydev/db/null"
+)
+
+// permissions retrieve the slugs of the permissions granted by a role
+//
+// Parameters:
+//   - roleID: The role ID to get permissions for
+//
+// Returns:
+//   - []types.Permission: Array of permission slugs
+func permissions(roleID int) []types.Permission {
+	permissions := []types.Permission{}
+	for _, permission := range repository.Pool.GetPermissionsByRoleIDs(roleID) {
+		permissions = append(permissions, permission.Slug)
+	}
+
+	return permissions
+}
+
+// ToRoleDetailResponse converts a Role model to a RoleDetail response object
+//
+// Parameters:
+//   - model: models.Role - The role model to convert
+//   - usersCount: int - The number of users who have the role
+//
+// Returns:
+//   - response.RoleDetail: The converted role response object
+func ToRoleDetailResponse(model models.Role, usersCount int) response.RoleDetail {
+	return response.RoleDetail{
+		ID:          model.ID,
+		Name:        model.Name,
+		Slug:        model.Slug,
+		System:      model.Slug.IsSystem(),
+		ParentID:    dbNull.Int32NilInt(model.ParentID),
+		Permissions: permissions(model.ID),
+		UsersCount:  usersCount,
+		CreatedAt:   model.CreatedAt,
+		UpdatedAt:   dbNull.TimeNil(model.UpdatedAt),
+	}
+}
@@ -1,12 +1,17 @@
 package transformers
 
 import (
+	"fmt"
 	"gfly/internal/domain/models"
 	"gfly/internal/domain/repository"
+	"gfly/internal/dto"
 	"gfly/internal/http/response"
 	"github.com/gflydev/core"
+	"github.com/gflydev/core/utils"
 	dbNull "github.com/gflydev/db/null"
+	"github.com/gflydev/http"
 	"github.com/gflydev/storage"
+	"slices"
 	"strings"
 )
 
@@ -55,8 +60,18 @@ func ToRoleResponse(model models.Role) response.Role {
 // Returns:
 //   - []response.Role: Array of role response objects
 func roles(userID int) []response.Role {
+	return toRoleList(repository.Pool.GetRolesByUserID(userID))
+}
+
+// toRoleList converts a list of Role models to Role response objects
+//
+// Parameters:
+//   - roleList: []models.Role - The role models to convert
+//
+// Returns:
+//   - []response.Role: Array of role response objects
+func toRoleList(roleList []models.Role) []response.Role {
 	var roles []response.Role
-	roleList := repository.Pool.GetRolesByUserID(userID)
 	for _, role := range roleList {
 		roles = append(roles, ToRoleResponse(role))
 	}
@@ -64,29 +79,192 @@ func roles(userID int) []response.Role {
 	return roles
 }
 
+// ToAddressResponse converts an Address model to an Address response object
+//
+// Parameters:
+//   - model: models.Address - The address model to convert
+//
+// Returns:
+//   - response.Address: The converted address response object
+func ToAddressResponse(model models.Address) response.Address {
+	return response.Address{
+		ID:           model.ID,
+		Type:         model.Type,
+		IsDefault:    model.IsDefault,
+		AddressLine1: model.AddressLine1,
+		AddressLine2: dbNull.StringNil(model.AddressLine2),
+		Ward:         dbNull.StringNil(model.Ward),
+		District:     dbNull.StringNil(model.District),
+		City:         dbNull.StringNil(model.City),
+		State:        dbNull.StringNil(model.State),
+		Country:      dbNull.StringNil(model.Country),
+	}
+}
+
+// toAddressList converts a list of Address models to Address response objects
+//
+// Parameters:
+//   - addressList: []models.Address - The address models to convert
+//
+// Returns:
+//   - []response.Address: Array of address response objects
+func toAddressList(addressList []models.Address) []response.Address {
+	addresses := make([]response.Address, 0, len(addressList))
+	for _, address := range addressList {
+		addresses = append(addresses, ToAddressResponse(address))
+	}
+
+	return addresses
+}
+
+// UserFields fields of response.User which can be requested with `fields`.
+var UserFields = []string{
+	"id", "email", "fullname", "phone", "status", "avatar",
+	"created_at", "updated_at", "verified_at", "blocked_at", "deleted_at", "last_access_at",
+}
+
+// UserIncludes relationships of response.User which can be requested with `include`.
+var UserIncludes = []string{"roles", "addresses"}
+
 // ToUserResponse converts a User model to a User response object
-// with all fields populated for general purpose response
+// with the fields and the relationships of the fieldset (see response.User)
 //
 // Parameters:
 //   - user: models.User - The user model to convert
+//   - fieldset: dto.Fieldset - The requested fields and relationships
 //
 // Returns:
-//   - response.User: The converted user response object
-func ToUserResponse(user models.User) response.User {
-	return response.User{
-		ID:           user.ID,
-		Email:        user.Email,
-		Fullname:     user.Fullname,
-		Phone:        user.Phone,
-		Token:        dbNull.StringNil(user.Token),
-		Status:       user.Status,
-		Avatar:       PublicAvatar(user.Avatar.String),
-		CreatedAt:    user.CreatedAt,
-		UpdatedAt:    user.UpdatedAt.Time,
-		VerifiedAt:   dbNull.TimeNil(user.VerifiedAt),
-		BlockedAt:    dbNull.TimeNil(user.BlockedAt),
-		DeletedAt:    dbNull.TimeNil(user.DeletedAt),
-		LastAccessAt: dbNull.TimeNil(user.LastAccessAt),
-		Roles:        roles(user.ID),
+//   - core.Data: The converted user response object
+func ToUserResponse(user models.User, fieldset dto.Fieldset) core.Data {
+	var userRoles []response.Role
+	if slices.Contains(fieldset.Include, "roles") {
+		userRoles = roles(user.ID)
+	}
+
+	var addresses []models.Address
+	if slices.Contains(fieldset.Include, "addresses") {
+		addresses = repository.Pool.GetAddressesByUserID(user.ID)
+	}
+
+	return toUserResponse(user, userRoles, addresses, fieldset)
+}
+
+// ToListUserResponse converts a page of User models to User response objects.
+// Roles and addresses of all users are loaded at once, instead of one query per user.
+//
+// Parameters:
+//   - users: []models.User - The user models to convert
+//   - fieldset: dto.Fieldset - The requested fields and relationships
+//
+// Returns:
+//   - []core.Data: The converted user response objects
+func ToListUserResponse(users []models.User, fieldset dto.Fieldset) []core.Data {
+	userIDs := make([]int, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.ID)
+	}
+
+	var userRoles map[int][]models.Role
+	if slices.Contains(fieldset.Include, "roles") {
+		userRoles = repository.Pool.GetRolesByUserIDs(userIDs)
+	}
+
+	var userAddresses map[int][]models.Address
+	if slices.Contains(fieldset.Include, "addresses") {
+		userAddresses = repository.Pool.GetAddressesByUserIDs(userIDs)
+	}
+
+	return http.ToListResponse(users, func(user models.User) core.Data {
+		return toUserResponse(user, toRoleList(userRoles[user.ID]), userAddresses[user.ID], fieldset)
+	})
+}
+
+// toUserResponse converts a User model with its relationships to a User response object.
+// Only the requested fields are computed, all fields when the fieldset has none.
+func toUserResponse(user models.User, roles []response.Role, addresses []models.Address, fieldset dto.Fieldset) core.Data {
+	fields := fieldset.Fields
+	if len(fields) == 0 {
+		fields = UserFields
+	}
+
+	data := core.Data{}
+	for _, field := range fields {
+		data[field] = userField(user, field)
+	}
+
+	if slices.Contains(fieldset.Include, "roles") {
+		data["roles"] = roles
+	}
+
+	if slices.Contains(fieldset.Include, "addresses") {
+		data["addresses"] = toAddressList(addresses)
+	}
+
+	return data
+}
+
+// userField returns the value of a field of the User response object.
+func userField(user models.User, field string) any {
+	switch field {
+	case "id":
+		return user.ID
+	case "email":
+		return user.Email
+	case "fullname":
+		return user.Fullname
+	case "phone":
+		return user.Phone
+	case "status":
+		return user.Status
+	case "avatar":
+		return PublicAvatar(user.Avatar.String)
+	case "created_at":
+		return user.CreatedAt
+	case "updated_at":
+		return user.UpdatedAt.Time
+	case "verified_at":
+		return dbNull.TimeNil(user.VerifiedAt)
+	case "blocked_at":
+		return dbNull.TimeNil(user.BlockedAt)
+	case "deleted_at":
+		return dbNull.TimeNil(user.DeletedAt)
+	case "last_access_at":
+		return dbNull.TimeNil(user.LastAccessAt)
+	}
+
+	return nil
+}
+
+// ToUserImportResponse converts a UserImport model to a UserImport response object
+//
+// Parameters:
+//   - userImport: models.UserImport - The import model to convert
+//
+// Returns:
+//   - response.UserImport: The converted import response object
+func ToUserImportResponse(userImport models.UserImport) response.UserImport {
+	var reportURL *string
+	if userImport.ReportPath.Valid {
+		url := fmt.Sprintf("/%s/%s/users/imports/%d/report",
+			utils.Getenv("API_PREFIX", "api"),
+			utils.Getenv("API_VERSION", "v1"),
+			userImport.ID,
+		)
+		reportURL = &url
+	}
+
+	return response.UserImport{
+		ID:            userImport.ID,
+		FileName:      userImport.FileName,
+		Status:        userImport.Status,
+		TotalRows:     userImport.TotalRows,
+		ProcessedRows: userImport.ProcessedRows,
+		CreatedRows:   userImport.CreatedRows,
+		FailedRows:    userImport.FailedRows,
+		Error:         dbNull.StringNil(userImport.Error),
+		ReportURL:     reportURL,
+		CreatedAt:     userImport.CreatedAt,
+		StartedAt:     dbNull.TimeNil(userImport.StartedAt),
+		FinishedAt:    dbNull.TimeNil(userImport.FinishedAt),
 	}
 }
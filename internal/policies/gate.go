@@ -0,0 +1,168 @@
+package policies
+
+import (
+	"gfly/internal/domain/models"
+	"github.com/gflydev/core"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/http"
+	"reflect"
+	"sync"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// Abilities checked by a Policy.
+const (
+	AbilityView   = "view"
+	AbilityUpdate = "update"
+	AbilityDelete = "delete"
+)
+
+// Policy defines the resource-level authorization rules of a model type T.
+// Each method decides if the actor can perform the ability on the target.
+//
+// Example:
+//
+//	type PostPolicy struct{}
+//
+//	func (p PostPolicy) View(actor models.User, target models.Post) bool   { return true }
+//	func (p PostPolicy) Update(actor models.User, target models.Post) bool { return target.UserID == actor.ID }
+//	func (p PostPolicy) Delete(actor models.User, target models.Post) bool { return target.UserID == actor.ID }
+//
+//	policies.Register[models.Post](PostPolicy{})
+type Policy[T any] interface {
+	View(actor models.User, target T) bool
+	Update(actor models.User, target T) bool
+	Delete(actor models.User, target T) bool
+}
+
+// BeforeFunc runs before any policy. It returns `decided = true` to grant or deny
+// the ability (`allowed`) without asking the policy. Ex: super-admins can do anything.
+type BeforeFunc func(actor models.User, ability string) (allowed bool, decided bool)
+
+// checkFunc a Policy wrapped for an untyped target.
+type checkFunc func(actor models.User, ability string, target any) bool
+
+// gate struct holds the registered policies (by target type) and before-hooks.
+type gate struct {
+	sync.RWMutex
+	policies map[reflect.Type]checkFunc
+	befores  []BeforeFunc
+}
+
+// Gate the policy registry of the application.
+//
+// Use:
+//
+//	if err := policies.Gate.Authorize(c, policies.AbilityUpdate, *user); err != nil {
+//		return err
+//	}
+var Gate = &gate{
+	policies: make(map[reflect.Type]checkFunc),
+}
+
+// ====================================================================
+// ========================= Main functions ===========================
+// ====================================================================
+
+// Register registers the policy of the model type T. A policy registered later for
+// the same type replaces the previous one.
+//
+// Parameters:
+//   - policy (Policy[T]): The authorization rules of T
+func Register[T any](policy Policy[T]) {
+	Gate.Lock()
+	defer Gate.Unlock()
+
+	Gate.policies[reflect.TypeFor[T]()] = func(actor models.User, ability string, target any) bool {
+		switch ability {
+		case AbilityView:
+			return policy.View(actor, target.(T))
+		case AbilityUpdate:
+			return policy.Update(actor, target.(T))
+		case AbilityDelete:
+			return policy.Delete(actor, target.(T))
+		}
+
+		return false
+	}
+}
+
+// Before registers a hook which runs before every policy.
+//
+// Parameters:
+//   - fn (BeforeFunc): The hook
+func (g *gate) Before(fn BeforeFunc) {
+	g.Lock()
+	defer g.Unlock()
+
+	g.befores = append(g.befores, fn)
+}
+
+// Allows checks if the actor can perform the ability on the target.
+// Before-hooks decide first, then the policy of the target's type.
+// An unknown ability or a target without policy is denied.
+//
+// Parameters:
+//   - actor (models.User): The current user
+//   - ability (string): The ability. Ex: `update`
+//   - target (any): The resource. A pointer is dereferenced. Ex: `models.User`
+//
+// Returns:
+//   - bool: True if the ability is granted, false otherwise
+func (g *gate) Allows(actor models.User, ability string, target any) bool {
+	g.RLock()
+	defer g.RUnlock()
+
+	for _, before := range g.befores {
+		if allowed, decided := before(actor, ability); decided {
+			return allowed
+		}
+	}
+
+	value := reflect.ValueOf(target)
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return false
+		}
+		value = value.Elem()
+	}
+
+	check, ok := g.policies[value.Type()]
+	if !ok {
+		log.Warnf("No policy registered for %v", value.Type())
+
+		return false
+	}
+
+	return check(actor, ability, value.Interface())
+}
+
+// Authorize checks if the current user can perform the ability on the target.
+// It writes the error response, so the handler returns the error right away.
+//
+// Parameters:
+//   - c (*core.Ctx): The request context holding the current user
+//   - ability (string): The ability. Ex: `update`
+//   - target (any): The resource. Ex: `models.User`
+//
+// Returns:
+//   - error: nil when granted. Otherwise, an error with response 401 (no user) or 403 `http.Error`
+func (g *gate) Authorize(c *core.Ctx, ability string, target any) error {
+	actor, ok := c.GetData(http.UserKey).(models.User)
+	if !ok {
+		return c.Error(http.Error{
+			Message: "Unauthorized",
+		}, core.StatusUnauthorized)
+	}
+
+	if !g.Allows(actor, ability, target) {
+		return c.Error(http.Error{
+			Message: "Permission denied",
+		}, core.StatusForbidden)
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+// Package policies holds the resource-level authorization rules.
+// Policies are registered when this package is imported:
+//
+//	policies.Gate.Authorize(c, policies.AbilityUpdate, *user)
+
+package policies
+
+import (
+	"gfly/internal/domain/models"
+	"gfly/internal/domain/models/types"
+	"gfly/internal/services"
+)
+
+// init registers the before-hooks and the policies of the application.
+func init() {
+	// Super-admins can do anything
+	Gate.Before(func(actor models.User, ability string) (bool, bool) {
+		if services.UserHasRole(actor.ID, []types.Role{types.RoleSuperAdmin}) {
+			return true, true
+		}
+
+		return false, false
+	})
+
+	Register[models.User](UserPolicy{})
+}
@@ -0,0 +1,28 @@
+package policies
+
+import (
+	"gfly/internal/domain/models"
+	"gfly/internal/services"
+)
+
+// UserPolicy struct describes the authorization rules of `models.User` resources.
+// The route permissions (Ex: `users.update`) are checked by CheckPermissionsMiddleware
+// before, so the policy only holds the rules which depend on the target user.
+// Changes are allowed on users below the actor in the role hierarchy only (Ex: an `admin`
+// can not block, edit or delete a `super-admin` or another `admin`).
+type UserPolicy struct{}
+
+// View any user can be viewed.
+func (p UserPolicy) View(actor models.User, target models.User) bool {
+	return true
+}
+
+// Update don't allow update yourself (Ex: change your own status or roles) and users of the same or a higher rank.
+func (p UserPolicy) Update(actor models.User, target models.User) bool {
+	return actor.ID != target.ID && services.UserOutranks(actor.ID, target.ID)
+}
+
+// Delete don't allow delete yourself and users of the same or a higher rank.
+func (p UserPolicy) Delete(actor models.User, target models.User) bool {
+	return actor.ID != target.ID && services.UserOutranks(actor.ID, target.ID)
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"gfly/internal/dto"
+	"github.com/gflydev/core/errors"
+	mb "github.com/gflydev/db"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// ErrInvalidFilter is returned when a structured filter or a sort of a list request
+// uses an unknown field, an unsupported operator or an invalid value.
+var ErrInvalidFilter = errors.New("Invalid filter")
+
+// filterKind the type of the values of a filter field.
+type filterKind int
+
+const (
+	filterString filterKind = iota // Text: eq, ne, like
+	filterEnum                     // One of the allowed values: eq, ne
+	filterInt                      // Integer: eq, ne, gt, gte, lt, lte
+	filterTime                     // Date or timestamp: gt, gte, lt, lte
+)
+
+// filterOperators operators allowed for each kind of field.
+var filterOperators = map[filterKind][]string{
+	filterString: {"eq", "ne", "like"},
+	filterEnum:   {"eq", "ne"},
+	filterInt:    {"eq", "ne", "gt", "gte", "lt", "lte"},
+	filterTime:   {"gt", "gte", "lt", "lte"},
+}
+
+// filterField describes a field which a list can be filtered by (`filter[field][operator]=value`).
+type filterField struct {
+	column    string     // Qualified column name
+	kind      filterKind // The type of the values
+	values    []string   // The allowed values of an enum field
+	operators []string   // Overrides the operators of the kind
+}
+
+// ====================================================================
+// ========================= Main functions ===========================
+// ====================================================================
+
+// applyFilters adds the structured filters of a list request to the query.
+// Only whitelisted fields can be filtered by. The conditions are AND-ed.
+//
+// Parameters:
+//   - builder (*mb.DBModel): The query of the list.
+//   - conditions ([]dto.FilterCondition): The structured filters of the request.
+//   - fields (map[string]filterField): The fields the list can be filtered by.
+//
+// Returns:
+//   - error: ErrInvalidFilter (wrapped with the reason) for an unknown field, operator or value.
+func applyFilters(builder *mb.DBModel, conditions []dto.FilterCondition, fields map[string]filterField) error {
+	for _, condition := range conditions {
+		field, ok := fields[condition.Field]
+		if !ok {
+			return errors.New("%w: unknown field %q", ErrInvalidFilter, condition.Field)
+		}
+
+		operators := field.operators
+		if operators == nil {
+			operators = filterOperators[field.kind]
+		}
+
+		if !slices.Contains(operators, condition.Operator) {
+			return errors.New("%w: operator %q is not supported by field %q", ErrInvalidFilter, condition.Operator, condition.Field)
+		}
+
+		values, err := filterValues(field, condition.Values)
+		if err != nil {
+			return errors.New("%w: %v of field %q", ErrInvalidFilter, err, condition.Field)
+		}
+
+		// Many values are OR-ed
+		if len(values) > 1 && condition.Operator != "eq" && condition.Operator != "ne" {
+			return errors.New("%w: operator %q of field %q accepts a single value", ErrInvalidFilter, condition.Operator, condition.Field)
+		}
+
+		switch condition.Operator {
+		case "eq":
+			if len(values) > 1 {
+				builder.Where(field.column, mb.In, listValues(values))
+			} else {
+				builder.Where(field.column, mb.Eq, values[0])
+			}
+		case "ne":
+			if len(values) > 1 {
+				builder.Where(field.column, mb.NotIn, listValues(values))
+			} else {
+				builder.Where(field.column, mb.NotEq, values[0])
+			}
+		case "gt":
+			builder.Where(field.column, mb.Greater, values[0])
+		case "gte":
+			builder.Where(field.column, mb.GrEq, values[0])
+		case "lt":
+			builder.Where(field.column, mb.Lesser, values[0])
+		case "lte":
+			builder.Where(field.column, mb.LeEq, values[0])
+		case "like":
+			builder.Where(field.column, mb.Like, "%"+condition.Values[0]+"%")
+		}
+	}
+
+	return nil
+}
+
+// hasFilter checks if the structured filters use the field.
+func hasFilter(conditions []dto.FilterCondition, field string) bool {
+	return slices.ContainsFunc(conditions, func(condition dto.FilterCondition) bool {
+		return condition.Field == field
+	})
+}
+
+// ====================================================================
+// ======================== Helper Functions ==========================
+// ====================================================================
+
+// filterValues converts the values of a filter to the type of the field.
+func filterValues(field filterField, values []string) ([]any, error) {
+	result := make([]any, 0, len(values))
+
+	for _, value := range values {
+		switch field.kind {
+		case filterEnum:
+			if !slices.Contains(field.values, value) {
+				return nil, errors.New("invalid value %q", value)
+			}
+			result = append(result, value)
+		case filterInt:
+			number, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, errors.New("invalid number %q", value)
+			}
+			result = append(result, number)
+		case filterTime:
+			timestamp, err := parseFilterTime(value)
+			if err != nil {
+				return nil, errors.New("invalid date %q", value)
+			}
+			result = append(result, timestamp)
+		default:
+			result = append(result, value)
+		}
+	}
+
+	return result, nil
+}
+
+// listValues converts values to []int or []string (the types supported by IN).
+func listValues(values []any) any {
+	if _, ok := values[0].(int); ok {
+		numbers := make([]int, 0, len(values))
+		for _, value := range values {
+			numbers = append(numbers, value.(int))
+		}
+
+		return numbers
+	}
+
+	strs := make([]string, 0, len(values))
+	for _, value := range values {
+		strs = append(strs, value.(string))
+	}
+
+	return strs
+}
+
+// parseFilterTime parses a date (2026-01-31) or a timestamp (RFC 3339).
+func parseFilterTime(value string) (time.Time, error) {
+	if timestamp, err := time.Parse(time.DateOnly, value); err == nil {
+		return timestamp, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
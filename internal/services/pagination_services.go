@@ -0,0 +1,318 @@
+package services
+
+import (
+	"gfly/internal/dto"
+	"gfly/pkg/utils"
+	"github.com/gflydev/core/errors"
+	mb "github.com/gflydev/db"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCursor is returned when the cursor of a list request can not be decoded
+// or was created for another order.
+var ErrInvalidCursor = errors.New("Invalid cursor")
+
+// orderField describes a column which a list can be ordered by.
+type orderField struct {
+	column   string // Qualified column name
+	nullable bool   // NULL values come last in ascending order, first in descending order
+	isTime   bool   // The column holds timestamps
+	isInt    bool   // The column holds integers
+}
+
+// listSort is a column of a multi-column sort.
+type listSort struct {
+	field     orderField
+	direction mb.OrderByDir
+}
+
+// listOrder is the resolved order (and cursor) of a list request.
+//
+// Items are ordered by the column then by `id` as tiebreaker, so a cursor points
+// to an exact position in the list (keyset pagination). Cursors are not supported
+// when items are sorted by many columns.
+type listOrder struct {
+	orderBy   string        // Normalized `order_by` or `sort`, e.g. "-last_access,email"
+	field     orderField    // The (first) order column
+	direction mb.OrderByDir // The direction requested by the client
+	then      []listSort    // The next columns of a multi-column sort
+	cursor    *utils.Cursor // The position to start from, nil for page/per_page pagination
+}
+
+// ====================================================================
+// ========================= Main functions ===========================
+// ====================================================================
+
+// parseListOrder resolves the order and the cursor of a list request.
+//
+// `sort` (many columns) replaces `order_by` (one column). An unknown `order_by` falls back
+// to the default order, while an unknown `sort` field is refused.
+//
+// Parameters:
+//   - filterDto (dto.Filter): The filter of the request.
+//   - fields (map[string]orderField): The columns the list can be ordered by. Must contain "id".
+//   - defaultOrderBy (string): The order used when `order_by` is empty or unknown.
+//
+// Returns:
+//   - (listOrder, error): The resolved order, or ErrInvalidFilter, or ErrInvalidCursor.
+func parseListOrder(filterDto dto.Filter, fields map[string]orderField, defaultOrderBy string) (listOrder, error) {
+	orderBy := filterDto.OrderBy
+	if _, ok := fields[strings.TrimPrefix(orderBy, "-")]; !ok {
+		orderBy = defaultOrderBy
+	}
+
+	if filterDto.Sort != "" {
+		var keys []string
+		for _, key := range strings.Split(filterDto.Sort, ",") {
+			key = strings.TrimSpace(key)
+			if _, ok := fields[strings.TrimPrefix(key, "-")]; !ok {
+				return listOrder{}, errors.New("%w: unknown sort field %q", ErrInvalidFilter, key)
+			}
+			keys = append(keys, key)
+		}
+		orderBy = strings.Join(keys, ",")
+	}
+
+	var order listOrder
+	for idx, key := range strings.Split(orderBy, ",") {
+		sort := listSort{
+			field:     fields[strings.TrimPrefix(key, "-")],
+			direction: mb.Asc,
+		}
+
+		if strings.HasPrefix(key, "-") {
+			sort.direction = mb.Desc
+		}
+
+		if idx == 0 {
+			order.field, order.direction = sort.field, sort.direction
+		} else {
+			order.then = append(order.then, sort)
+		}
+	}
+	order.orderBy = orderBy
+
+	if filterDto.Cursor == "" {
+		return order, nil
+	}
+
+	if len(order.then) > 0 {
+		return order, errors.New("%w: a cursor needs a single sort field", ErrInvalidCursor)
+	}
+
+	cursor, err := utils.DecodeCursor(filterDto.Cursor)
+	if err != nil || cursor.OrderBy != orderBy {
+		return order, ErrInvalidCursor
+	}
+
+	if cursor.Value == nil && !order.field.nullable {
+		return order, ErrInvalidCursor
+	}
+
+	order.cursor = &cursor
+	if cursor.Value != nil {
+		if _, err = order.value(); err != nil {
+			return order, ErrInvalidCursor
+		}
+	}
+
+	return order, nil
+}
+
+// apply adds the ORDER BY clause and, with a cursor, the keyset condition to the query.
+//
+// Parameters:
+//   - builder (*mb.DBModel): The query of the list.
+//   - idColumn (string): The qualified `id` column.
+func (o listOrder) apply(builder *mb.DBModel, idColumn string) {
+	direction := o.queryDirection()
+
+	if o.field.nullable {
+		builder.OrderBy(o.field.column+" IS NULL", direction)
+	}
+	builder.OrderBy(o.field.column, direction)
+
+	for _, sort := range o.then {
+		if sort.field.nullable {
+			builder.OrderBy(sort.field.column+" IS NULL", sort.direction)
+		}
+		builder.OrderBy(sort.field.column, sort.direction)
+	}
+
+	builder.OrderBy(idColumn, direction)
+
+	if o.cursor == nil {
+		return
+	}
+
+	// Items after the cursor in the query direction
+	after := mb.Greater
+	if direction == mb.Desc {
+		after = mb.Lesser
+	}
+
+	builder.WhereGroup(func(queryGroup mb.WhereBuilder) *mb.WhereBuilder {
+		if o.cursor.Value == nil {
+			// NULL values come last in ascending order
+			queryGroup.WhereGroup(func(nullGroup mb.WhereBuilder) *mb.WhereBuilder {
+				nullGroup.Where(o.field.column, mb.Null, nil).
+					Where(idColumn, after, o.cursor.ID)
+
+				return &nullGroup
+			})
+
+			if direction == mb.Desc {
+				queryGroup.WhereOr(o.field.column, mb.NotNull, nil)
+			}
+
+			return &queryGroup
+		}
+
+		value, _ := o.value()
+
+		queryGroup.Where(o.field.column, after, value)
+		queryGroup.WhereCondition(mb.Condition{
+			AndOr: mb.Or,
+			Group: []mb.Condition{
+				{Field: o.field.column, Opt: mb.Eq, Value: value},
+				{Field: idColumn, Opt: after, Value: o.cursor.ID},
+			},
+		})
+
+		if o.field.nullable && direction == mb.Asc {
+			queryGroup.WhereOr(o.field.column, mb.Null, nil)
+		}
+
+		return &queryGroup
+	})
+}
+
+// paginate builds the pagination of a page of items loaded with the order.
+//
+// Parameters:
+//   - order (listOrder): The order the items were loaded with.
+//   - items ([]T): The items in query order. They are reversed when a previous page was requested.
+//     With a cursor, one more item than the page size is loaded to know if there are more items.
+//   - total (int): The number of items matching the query (without LIMIT). Unused with a cursor.
+//   - offset (int): The offset of the page (page/per_page pagination only).
+//   - perPage (int): The page size.
+//   - position (func(T) (*string, int)): Returns the value of the order column (nil for NULL) and the ID of an item.
+//
+// Returns:
+//   - ([]T, dto.Pagination): The items in list order and the pagination.
+func paginate[T any](order listOrder, items []T, total, offset, perPage int, position func(T) (*string, int)) ([]T, dto.Pagination) {
+	var pagination dto.Pagination
+
+	// The extra item of a cursor page is not returned
+	hasMore := false
+	if order.cursor != nil {
+		hasMore = len(items) > perPage
+		items = items[:min(len(items), perPage)]
+	} else {
+		pagination.Total = &total
+	}
+
+	// No cursor for a multi-column sort
+	if len(items) == 0 || len(order.then) > 0 {
+		return items, pagination
+	}
+
+	hasNext := offset+len(items) < total
+	hasPrev := offset > 0
+
+	if order.cursor != nil {
+		// `hasMore` tells if there are items after the page in the query direction.
+		// The cursor itself is on the other side.
+		if order.cursor.Backward {
+			slices.Reverse(items)
+			hasNext, hasPrev = true, hasMore
+		} else {
+			hasNext, hasPrev = hasMore, true
+		}
+	}
+
+	if hasNext {
+		value, id := position(items[len(items)-1])
+		pagination.NextCursor = utils.EncodeCursor(utils.Cursor{
+			OrderBy: order.orderBy,
+			Value:   value,
+			ID:      id,
+		})
+	}
+
+	if hasPrev {
+		value, id := position(items[0])
+		pagination.PrevCursor = utils.EncodeCursor(utils.Cursor{
+			OrderBy:  order.orderBy,
+			Value:    value,
+			ID:       id,
+			Backward: true,
+		})
+	}
+
+	return items, pagination
+}
+
+// ====================================================================
+// ======================== Helper Functions ==========================
+// ====================================================================
+
+// findUncounted loads the items of a query without counting the whole list.
+// The builder counts the rows of a raw query as a subquery which keeps its LIMIT,
+// so the count costs no more than the page itself.
+func findUncounted[T any](builder *mb.DBModel, items *[]T) error {
+	var model T
+
+	queryBuilder, err := builder.Model(&model).ToQueryBuilder()
+	if err != nil {
+		return err
+	}
+
+	sqlStr, args, err := queryBuilder.Sql()
+	if err != nil {
+		return err
+	}
+
+	_, err = mb.Instance().Raw(sqlStr, args...).Find(items)
+
+	return err
+}
+
+// queryDirection returns the direction of the query. A previous page is loaded in reverse order.
+func (o listOrder) queryDirection() mb.OrderByDir {
+	if o.cursor == nil || !o.cursor.Backward {
+		return o.direction
+	}
+
+	if o.direction == mb.Asc {
+		return mb.Desc
+	}
+
+	return mb.Asc
+}
+
+// value returns the (not NULL) cursor value typed for the order column.
+func (o listOrder) value() (any, error) {
+	switch {
+	case o.field.isTime:
+		return time.Parse(time.RFC3339Nano, *o.cursor.Value)
+	case o.field.isInt:
+		return strconv.Atoi(*o.cursor.Value)
+	}
+
+	return *o.cursor.Value, nil
+}
+
+// cursorTime converts a nullable timestamp to a cursor value.
+func cursorTime(value time.Time, valid bool) *string {
+	if !valid {
+		return nil
+	}
+
+	str := value.Format(time.RFC3339Nano)
+
+	return &str
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"gfly/internal/domain/models"
+	"gfly/internal/domain/models/types"
+	"gfly/internal/domain/repository"
+	"github.com/gflydev/cache"
+	"github.com/gflydev/core/log"
+	"slices"
+)
+
+// ====================================================================
+// ========================= Main functions ===========================
+// ====================================================================
+
+// UserCan checks if a user was granted a permission by any of their roles (including inherited roles).
+//
+// Parameters:
+//   - userID (int): The ID of the user
+//   - permission (types.Permission): The permission to check. Ex: `users.update`
+//
+// Returns:
+//   - bool: True if one of the user's roles grants the permission, false otherwise
+func UserCan(userID int, permission types.Permission) bool {
+	return slices.Contains(UserPermissions(userID), permission)
+}
+
+// UserPermissions retrieves the slugs of all permissions granted to a user by their
+// roles (including inherited roles). The result is cached like UserRoles.
+//
+// Parameters:
+//   - userID (int): The ID of the user
+//
+// Returns:
+//   - []types.Permission: Distinct permission slugs
+func UserPermissions(userID int) []types.Permission {
+	key := repository.UserPermissionsCacheKey(userID)
+
+	if val, err := cache.Get(key); err == nil && val != nil {
+		var permissions []types.Permission
+		if err = json.Unmarshal([]byte(fmt.Sprint(val)), &permissions); err == nil {
+			return permissions
+		}
+	}
+
+	permissions := RolePermissions(UserRoles(userID))
+
+	value, _ := json.Marshal(permissions)
+	if err := cache.Set(key, string(value), userRolesTtl); err != nil {
+		log.Errorf("Cache permissions of user %d error '%v'", userID, err)
+	}
+
+	return permissions
+}
+
+// RolePermissions retrieves the slugs of all permissions granted by the given roles.
+// Inherited roles are not resolved, see models.InheritRoles.
+//
+// Parameters:
+//   - roles ([]models.Role): The roles
+//
+// Returns:
+//   - []types.Permission: Distinct permission slugs
+func RolePermissions(roles []models.Role) []types.Permission {
+	if len(roles) == 0 {
+		return []types.Permission{}
+	}
+
+	roleIDs := make([]int, 0, len(roles))
+	for _, role := range roles {
+		roleIDs = append(roleIDs, role.ID)
+	}
+
+	permissionList := repository.Pool.GetPermissionsByRoleIDs(roleIDs...)
+
+	permissions := make([]types.Permission, 0, len(permissionList))
+	for _, permission := range permissionList {
+		permissions = append(permissions, permission.Slug)
+	}
+
+	return permissions
+}
@@ -0,0 +1,422 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"gfly/internal/domain/models"
+	"gfly/internal/domain/models/types"
+	"gfly/internal/domain/repository"
+	"gfly/internal/dto"
+	"github.com/gflydev/cache"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/core/log"
+	mb "github.com/gflydev/db"
+	dbNull "github.com/gflydev/db/null"
+	"regexp"
+	"slices"
+	"time"
+)
+
+var (
+	// ErrRoleNotFound is returned when the role (or the reassigned role) does not exist.
+	ErrRoleNotFound = errors.New("Role not found")
+
+	// ErrSystemRole is returned when a built-in role is going to be deleted.
+	ErrSystemRole = errors.New("System role can not be deleted")
+
+	// ErrRoleCycle is returned when a role would inherit itself through its parents.
+	ErrRoleCycle = errors.New("Role can not inherit itself")
+
+	// ErrRoleRank is returned when the actor does not outrank a role or a user (see OutranksRole).
+	ErrRoleRank = errors.New("Only roles and users below your roles can be managed")
+)
+
+// userRolesTtl how long the resolved roles and permissions of a user are cached.
+const userRolesTtl = 30 * time.Minute
+
+// roleSlugPattern lowercase letters, digits, '-' and '_'. Ex: `content-editor`
+var roleSlugPattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// ====================================================================
+// ========================= Main functions ===========================
+// ====================================================================
+
+// FindRoles retrieves all roles with the number of users of each role.
+//
+// Returns:
+//   - []models.Role: All roles ordered by name
+//   - map[int]int: Number of users keyed by role ID
+func FindRoles() ([]models.Role, map[int]int) {
+	return repository.Pool.GetRoles(), repository.Pool.CountUsersByRole()
+}
+
+// CreateRole creates a new role at runtime and grants its permissions.
+// The actor must outrank the parent role, which gives its rank to the new role.
+//
+// Parameters:
+//   - actorID (int): The ID of the user creating the role.
+//   - createRoleDto (dto.CreateRole): The payload containing the role's details.
+//
+// Returns:
+//   - (*models.Role, error): The created role, ErrRoleRank or an error if any step fails.
+func CreateRole(actorID int, createRoleDto dto.CreateRole) (*models.Role, error) {
+	if !roleSlugPattern.MatchString(string(createRoleDto.Slug)) {
+		return nil, errors.New("Role slug must contain lowercase letters, digits, '-' and '_' only")
+	}
+
+	if repository.Pool.GetRoleBySlug(createRoleDto.Slug) != nil {
+		return nil, errors.New("the role with given slug already exist")
+	}
+
+	if err := checkPermissions(createRoleDto.Permissions); err != nil {
+		return nil, err
+	}
+
+	parentID, err := resolveParent(actorID, 0, createRoleDto.Parent)
+	if err != nil {
+		return nil, err
+	}
+
+	role := &models.Role{
+		Name:      createRoleDto.Name,
+		Slug:      createRoleDto.Slug,
+		ParentID:  parentID,
+		CreatedAt: time.Now(),
+		UpdatedAt: dbNull.TimeNow(),
+	}
+
+	if err := mb.CreateModel(role); err != nil {
+		log.Errorf("Error while creating new role %v", err)
+		return nil, errors.New("error occurs while creating new role")
+	}
+
+	if err := repository.Pool.SyncPermissionsWithRole(role.ID, createRoleDto.Permissions...); err != nil {
+		log.Errorf("Error while syncing permissions to role %v", err)
+		return nil, errors.New("error occurs while syncing role permissions")
+	}
+
+	return role, nil
+}
+
+// UpdateRole renames a role and replaces its parent and permissions (if provided, nil keeps them).
+// The actor must outrank the role and its new parent, so nobody can raise the rank of their own roles.
+//
+// Parameters:
+//   - actorID (int): The ID of the user updating the role.
+//   - roleID (int): The unique identifier of the role.
+//   - updateRoleDto (dto.UpdateRole): The payload containing the role's updated details.
+//
+// Returns:
+//   - (*models.Role, error): The updated role, ErrRoleRank or an error if any step fails.
+func UpdateRole(actorID, roleID int, updateRoleDto dto.UpdateRole) (*models.Role, error) {
+	role := repository.Pool.GetRoleByID(roleID)
+	if role == nil {
+		return nil, ErrRoleNotFound
+	}
+
+	if !OutranksRole(actorID, *role) {
+		return nil, ErrRoleRank
+	}
+
+	var err error
+	if updateRoleDto.Permissions != nil {
+		if err = checkPermissions(*updateRoleDto.Permissions); err != nil {
+			return nil, err
+		}
+	}
+
+	role.Name = updateRoleDto.Name
+	role.UpdatedAt = dbNull.TimeNow()
+
+	if updateRoleDto.Parent != nil {
+		if role.ParentID, err = resolveParent(actorID, role.ID, *updateRoleDto.Parent); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = mb.UpdateModel(role); err != nil {
+		log.Errorf("Error while updating role %v", err)
+		return nil, errors.New("error occurs while updating role")
+	}
+
+	// An empty list removes all permissions of the role
+	if updateRoleDto.Permissions != nil {
+		if err = repository.Pool.SyncPermissionsWithRole(role.ID, *updateRoleDto.Permissions...); err != nil {
+			log.Errorf("Error while syncing role permissions %v", err)
+			return nil, errors.New("error occurs while syncing role permissions")
+		}
+	}
+
+	// The parent or the permissions may be changed. Flushed last, so a concurrent request
+	// can not cache the previous permissions again.
+	repository.FlushRolesCache()
+
+	return role, nil
+}
+
+// DeleteRole deletes a role which is not a system role.
+// Users of the role are moved to the reassigned role. The reassignment is
+// required when the role still has users, so nobody loses their access by accident.
+// The actor must outrank the deleted role and the reassigned role.
+//
+// Parameters:
+//   - actorID (int): The ID of the user deleting the role.
+//   - roleID (int): The unique identifier of the role to delete.
+//   - reassignTo (types.Role): The slug of the role which receives the users. Empty when the role has no users.
+//
+// Returns:
+//   - error: ErrRoleNotFound, ErrSystemRole, ErrRoleRank or an error if the deletion fails.
+func DeleteRole(actorID, roleID int, reassignTo types.Role) error {
+	role := repository.Pool.GetRoleByID(roleID)
+	if role == nil {
+		return ErrRoleNotFound
+	}
+
+	if role.Slug.IsSystem() {
+		return ErrSystemRole
+	}
+
+	if !OutranksRole(actorID, *role) {
+		return ErrRoleRank
+	}
+
+	reassignRoleID := 0
+	if reassignTo != "" {
+		reassignRole := repository.Pool.GetRoleBySlug(reassignTo)
+		if reassignRole == nil || reassignRole.ID == role.ID {
+			return errors.New("Invalid role %v to reassign users", reassignTo)
+		}
+
+		if !OutranksRole(actorID, *reassignRole) {
+			return ErrRoleRank
+		}
+
+		reassignRoleID = reassignRole.ID
+	} else if total := repository.Pool.CountUsersByRole()[role.ID]; total > 0 {
+		return errors.New("Role is assigned to %d users, choose a role to reassign them", total)
+	}
+
+	if err := repository.Pool.DeleteRole(role.ID, reassignRoleID); err != nil {
+		log.Errorf("Error while deleting role %v", err)
+		return errors.New("error occurs while deleting role")
+	}
+
+	return nil
+}
+
+// AssignRole assigns a role to a user.
+// The actor must outrank the role and the user, so nobody can raise their own rank.
+//
+// Parameters:
+//   - actorID (int): The ID of the user assigning the role.
+//   - roleID (int): The unique identifier of the role.
+//   - userID (int): The unique identifier of the user.
+//
+// Returns:
+//   - error: ErrRoleNotFound, "User not found", ErrRoleRank or an error if the assignment fails.
+func AssignRole(actorID, roleID, userID int) error {
+	role := repository.Pool.GetRoleByID(roleID)
+	if role == nil {
+		return ErrRoleNotFound
+	}
+
+	// Roles can not be assigned to deleted users
+	user, err := mb.GetModelByID[models.User](userID)
+	if err != nil || user == nil || user.DeletedAt.Valid {
+		return errors.New("User not found")
+	}
+
+	if !OutranksRole(actorID, *role) || !UserOutranks(actorID, userID) {
+		return ErrRoleRank
+	}
+
+	if err = repository.Pool.AssignRoleToUser(userID, roleID); err != nil {
+		log.Errorf("Error while assigning role %v", err)
+		return errors.New("error occurs while assigning role")
+	}
+
+	return nil
+}
+
+// RevokeRole revokes a role from a user.
+// The actor must outrank the role and the user.
+//
+// Parameters:
+//   - actorID (int): The ID of the user revoking the role.
+//   - roleID (int): The unique identifier of the role.
+//   - userID (int): The unique identifier of the user.
+//
+// Returns:
+//   - error: ErrRoleNotFound, ErrRoleRank or an error if the revocation fails.
+func RevokeRole(actorID, roleID, userID int) error {
+	role := repository.Pool.GetRoleByID(roleID)
+	if role == nil {
+		return ErrRoleNotFound
+	}
+
+	if !OutranksRole(actorID, *role) || !UserOutranks(actorID, userID) {
+		return ErrRoleRank
+	}
+
+	if err := repository.Pool.RevokeRoleFromUser(userID, roleID); err != nil {
+		log.Errorf("Error while revoking role %v", err)
+		return errors.New("error occurs while revoking role")
+	}
+
+	return nil
+}
+
+// UserRoles retrieves the roles of a user including the roles inherited through parents.
+// The result is cached per user until the user's roles are synced or the role graph changes.
+//
+// Parameters:
+//   - userID (int): The ID of the user
+//
+// Returns:
+//   - []models.Role: Direct roles followed by inherited roles
+func UserRoles(userID int) []models.Role {
+	key := repository.UserRolesCacheKey(userID)
+
+	if val, err := cache.Get(key); err == nil && val != nil {
+		var roles []models.Role
+		if err = json.Unmarshal([]byte(fmt.Sprint(val)), &roles); err == nil {
+			return roles
+		}
+	}
+
+	roles := models.InheritRoles(repository.Pool.GetRoles(), repository.Pool.GetRolesByUserID(userID))
+
+	value, _ := json.Marshal(roles)
+	if err := cache.Set(key, string(value), userRolesTtl); err != nil {
+		log.Errorf("Cache roles of user %d error '%v'", userID, err)
+	}
+
+	return roles
+}
+
+// UserOutranks checks if a user is strictly above another user in the role hierarchy.
+// See models.OutranksRoles.
+//
+// Parameters:
+//   - actorID (int): The ID of the user acting
+//   - targetID (int): The ID of the user acted on
+//
+// Returns:
+//   - bool: True if the actor outranks the target, false otherwise
+func UserOutranks(actorID, targetID int) bool {
+	return models.OutranksRoles(UserRoles(actorID), UserRoles(targetID))
+}
+
+// OutranksRole checks if a user is strictly above a role, including the roles which the role
+// inherits. Only such a role can be granted, revoked or inherited by the user, otherwise the
+// user could raise their own rank. See models.OutranksRoles.
+//
+// Parameters:
+//   - actorID (int): The ID of the user acting
+//   - role (models.Role): The role acted on
+//
+// Returns:
+//   - bool: True if the actor outranks the role, false otherwise
+func OutranksRole(actorID int, role models.Role) bool {
+	return models.OutranksRoles(UserRoles(actorID), models.InheritRoles(repository.Pool.GetRoles(), []models.Role{role}))
+}
+
+// CheckRoleParent verifies that a role can inherit the parent role without cycle.
+//
+// Parameters:
+//   - roles ([]models.Role): All roles (the role graph)
+//   - roleID (int): The role which receives the parent. 0 for a new role
+//   - parentID (int): The parent role
+//
+// Returns:
+//   - error: ErrRoleCycle if the parent is the role itself or one of its descendants
+func CheckRoleParent(roles []models.Role, roleID, parentID int) error {
+	parents := make(map[int]int, len(roles))
+	for _, role := range roles {
+		parents[role.ID] = int(role.ParentID.Int32)
+	}
+
+	// Walk up from the parent. Reaching the role means a cycle.
+	for id, depth := parentID, 0; id != 0; id, depth = parents[id], depth+1 {
+		if id == roleID || depth > len(roles) {
+			return ErrRoleCycle
+		}
+	}
+
+	return nil
+}
+
+// ====================================================================
+// ======================== Helper Functions ==========================
+// ====================================================================
+
+// resolveParent finds the parent role by slug and checks the hierarchy stays acyclic.
+// The actor must outrank the parent, the role gets the rank of its parent.
+func resolveParent(actorID, roleID int, parent types.Role) (sql.NullInt32, error) {
+	if parent == "" {
+		return sql.NullInt32{}, nil
+	}
+
+	parentRole := repository.Pool.GetRoleBySlug(parent)
+	if parentRole == nil {
+		return sql.NullInt32{}, errors.New("Parent role %v not found", parent)
+	}
+
+	if !OutranksRole(actorID, *parentRole) {
+		return sql.NullInt32{}, ErrRoleRank
+	}
+
+	if err := CheckRoleParent(repository.Pool.GetRoles(), roleID, parentRole.ID); err != nil {
+		return sql.NullInt32{}, err
+	}
+
+	return dbNull.Int32(int32(parentRole.ID)), nil
+}
+
+// checkPermissions verifies that all given permission slugs exist.
+func checkPermissions(permissions []types.Permission) error {
+	existing := make([]types.Permission, 0)
+	for _, permission := range repository.Pool.GetPermissions() {
+		existing = append(existing, permission.Slug)
+	}
+
+	for _, permission := range permissions {
+		if !slices.Contains(existing, permission) {
+			return errors.New("Permission %v not found", permission)
+		}
+	}
+
+	return nil
+}
+
+// checkRoles verifies that all given role slugs exist.
+func checkRoles(roles []types.Role) error {
+	existing := make([]types.Role, 0)
+	for _, role := range repository.Pool.GetRolesBySlug(roles...) {
+		existing = append(existing, role.Slug)
+	}
+
+	for _, role := range roles {
+		if !slices.Contains(existing, role) {
+			return errors.New("Role %v not found", role)
+		}
+	}
+
+	return nil
+}
+
+// checkGrantedRoles verifies that all given role slugs exist and the actor outranks each of them.
+func checkGrantedRoles(actorID int, roles []types.Role) error {
+	if err := checkRoles(roles); err != nil {
+		return err
+	}
+
+	for _, role := range repository.Pool.GetRolesBySlug(roles...) {
+		if !OutranksRole(actorID, role) {
+			return ErrRoleRank
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"gfly/internal/console/queues"
+	"gfly/internal/domain/models"
+	"gfly/internal/domain/models/types"
+	"gfly/internal/domain/repository"
+	"gfly/pkg/utils"
+	"github.com/gflydev/console"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/core/log"
+	coreUtils "github.com/gflydev/core/utils"
+	mb "github.com/gflydev/db"
+	dbNull "github.com/gflydev/db/null"
+	"github.com/gflydev/storage"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// UploadUserImportDir directory of the import files (and their reports) in the storage.
+	UploadUserImportDir = "imports/users"
+
+	// maxUserImportFileSize the maximum size of an import file (10 MB).
+	maxUserImportFileSize = 10 << 20
+)
+
+// ====================================================================
+// ========================= Main functions ===========================
+// ====================================================================
+
+// ImportUsers stores an import file then queues the import of its users (see queues.ImportUsersTask).
+//
+// Parameters:
+//   - actorID (int): The ID of the administrator uploading the file.
+//   - fileName (string): The original name of the file (.csv or .xlsx).
+//   - filePath (string): The path of the uploaded file on the local disk.
+//
+// Returns:
+//   - (*models.UserImport, error): The pending import, or an error if the file is refused or can not be stored.
+func ImportUsers(actorID int, fileName, filePath string) (*models.UserImport, error) {
+	userImport, err := CreateUserImport(actorID, fileName, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	console.DispatchTask(queues.NewImportUsersTask(userImport.ID))
+
+	return userImport, nil
+}
+
+// CreateUserImport stores an import file through the storage and creates its pending import.
+// The users are not imported yet.
+//
+// Parameters:
+//   - actorID (int): The ID of the administrator uploading the file, 0 from the console.
+//   - fileName (string): The original name of the file (.csv or .xlsx).
+//   - filePath (string): The path of the file on the local disk.
+//
+// Returns:
+//   - (*models.UserImport, error): The pending import, or an error if the file is refused or can not be stored.
+func CreateUserImport(actorID int, fileName, filePath string) (*models.UserImport, error) {
+	extension := strings.ToLower(filepath.Ext(fileName))
+	if extension != ".csv" && extension != ".xlsx" {
+		return nil, utils.ErrUnsupportedSpreadsheet
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, errors.New("file %s not found", fileName)
+	}
+
+	if info.Size() > maxUserImportFileSize {
+		return nil, errors.New("file %s exceeds %d MB", fileName, maxUserImportFileSize>>20)
+	}
+
+	file, err := os.Open(filepath.Clean(filePath))
+	if err != nil {
+		return nil, errors.New("file %s can not be read", fileName)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	fs := storage.Instance()
+	storagePath := UploadUserImportDir + "/" + coreUtils.Token() + extension
+	if !fs.MakeDir(UploadUserImportDir) || !fs.PutFile(storagePath, file) {
+		log.Errorf("Error while storing user import file %s", storagePath)
+
+		return nil, errors.New("error occurs while storing the file")
+	}
+
+	userImport := &models.UserImport{
+		FileName:  filepath.Base(fileName),
+		FilePath:  storagePath,
+		Status:    types.UserImportStatusPending,
+		CreatedAt: time.Now(),
+	}
+
+	if actorID > 0 {
+		userImport.ActorID = dbNull.Int64(int64(actorID))
+	}
+
+	if err = mb.CreateModel(userImport); err != nil {
+		log.Errorf("Error while creating user import %v", err)
+
+		return nil, errors.New("error occurs while creating user import")
+	}
+
+	return userImport, nil
+}
+
+// GetUserImport retrieves an import by its ID.
+//
+// Parameters:
+//   - importID (int): The ID of the import.
+//
+// Returns:
+//   - (*models.UserImport, error): The import, or an error if not found.
+func GetUserImport(importID int) (*models.UserImport, error) {
+	userImport := repository.Pool.GetUserImportByID(importID)
+	if userImport == nil {
+		return nil, errors.New("User import not found")
+	}
+
+	return userImport, nil
+}
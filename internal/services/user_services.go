@@ -1,114 +1,180 @@
 package services
 
 import (
-	"fmt"
+	"database/sql"
+	userEvents "gfly/internal/domain/events"
 	"gfly/internal/domain/models"
 	"gfly/internal/domain/models/types"
 	"gfly/internal/domain/repository"
 	"gfly/internal/dto"
-	"github.com/gflydev/core"
+	"gfly/pkg/modules/auth/password"
 	"github.com/gflydev/core/errors"
 	"github.com/gflydev/core/log"
 	coreUtils "github.com/gflydev/core/utils"
 	mb "github.com/gflydev/db"
 	dbNull "github.com/gflydev/db/null"
+	"github.com/gflydev/event"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 )
 
 const (
 	UploadAvatarDir = "avatars"
+
+	// purgeBatchSize number of deleted users purged by a query.
+	purgeBatchSize = 100
+
+	// PurgeRetentionDays env key of the number of days a soft-deleted user is kept before being purged.
+	PurgeRetentionDays = "USER_PURGE_RETENTION_DAYS"
 )
 
 // ====================================================================
 // ========================= Main functions ===========================
 // ====================================================================
 
+// userOrderFields columns which user lists can be ordered by (`order_by` and `sort`).
+var userOrderFields = map[string]orderField{
+	"id":          {column: models.TableUser + ".id", isInt: true},
+	"created_at":  {column: models.TableUser + ".created_at", isTime: true},
+	"email":       {column: models.TableUser + ".email"},
+	"fullname":    {column: models.TableUser + ".fullname"},
+	"phone":       {column: models.TableUser + ".phone"},
+	"status":      {column: models.TableUser + ".status"},
+	"last_access": {column: models.TableUser + ".last_access_at", nullable: true, isTime: true},
+	"deleted_at":  {column: models.TableUser + ".deleted_at", nullable: true, isTime: true},
+}
+
+// userFilterFields fields which user lists can be filtered by (`filter[field][operator]=value`).
+var userFilterFields = map[string]filterField{
+	"id":             {column: models.TableUser + ".id", kind: filterInt},
+	"email":          {column: models.TableUser + ".email", kind: filterString},
+	"fullname":       {column: models.TableUser + ".fullname", kind: filterString},
+	"phone":          {column: models.TableUser + ".phone", kind: filterString},
+	"status":         {column: models.TableUser + ".status", kind: filterEnum, values: types.UserStatusArrStr(types.UserStatusList...)},
+	"role":           {column: models.TableRole + ".slug", kind: filterString, operators: []string{"eq"}},
+	"created_at":     {column: models.TableUser + ".created_at", kind: filterTime},
+	"verified_at":    {column: models.TableUser + ".verified_at", kind: filterTime},
+	"blocked_at":     {column: models.TableUser + ".blocked_at", kind: filterTime},
+	"last_access_at": {column: models.TableUser + ".last_access_at", kind: filterTime},
+	"deleted_at":     {column: models.TableUser + ".deleted_at", kind: filterTime},
+}
+
 // FindUsers retrieves a list of users from the database based on the provided filter criteria.
-// It supports searching by keyword, ordering by specified fields, and pagination.
+// It supports searching by keyword, structured filters (see userFilterFields), ordering by
+// specified fields (see userOrderFields), and pagination.
+//
+// Pagination is done by page/per_page, or by cursor when `filterDto.Cursor` is set.
+// A cursor is keyed on the `order_by` column plus `id`, so deep pages stay fast and
+// stable while users are added or removed. Cursor pages are not counted.
 //
 // Parameters:
-//   - filterDto (dto.Filter): The filter containing search criteria, order by field, page, and per-page details.
+//   - filterDto (dto.Filter): The filter containing search criteria, order by field, page, per-page and cursor details.
 //
 // Returns:
 //
-//	([]models.User, int, error): A list of user models, the total number of users, and any error encountered.
-func FindUsers(filterDto dto.Filter) ([]models.User, int, error) {
-	// DB Model instance
-	dbInstance := mb.Instance()
-	// Error variable
-	var err error
+//	([]models.User, dto.Pagination, error): A list of user models, the pagination, and any error encountered (ErrInvalidFilter, ErrInvalidCursor).
+func FindUsers(filterDto dto.Filter) ([]models.User, dto.Pagination, error) {
+	return findUsers(filterDto, false, "id")
+}
+
+// FindDeletedUsers retrieves the soft-deleted users (the trash) with the same
+// filter criteria as FindUsers. Latest deleted users come first by default.
+//
+// Parameters:
+//   - filterDto (dto.Filter): The filter containing search criteria, order by field, page, per-page and cursor details.
+//
+// Returns:
+//
+//	([]models.User, dto.Pagination, error): A list of user models, the pagination, and any error encountered (ErrInvalidFilter, ErrInvalidCursor).
+func FindDeletedUsers(filterDto dto.Filter) ([]models.User, dto.Pagination, error) {
+	return findUsers(filterDto, true, "-deleted_at")
+}
+
+// findUsers queries users (or soft-deleted users when trashed is true) by the filter.
+func findUsers(filterDto dto.Filter, trashed bool, defaultOrderBy string) ([]models.User, dto.Pagination, error) {
+	order, err := parseListOrder(filterDto, userOrderFields, defaultOrderBy)
+	if err != nil {
+		return nil, dto.Pagination{}, err
+	}
 
 	// Define User variable.
 	var users []models.User
 	var total int
 	var offset = 0
+	var limit = filterDto.PerPage
 
-	if filterDto.Page > 0 {
+	// A cursor replaces the page. One more user tells if there is a next page.
+	if order.cursor != nil {
+		limit++
+	} else if filterDto.Page > 0 {
 		offset = (filterDto.Page - 1) * filterDto.PerPage
 	}
 
-	builder := dbInstance.Select("DISTINCT users.id", "users.*").
-		Join(mb.LeftJoin, models.TableUserRole, mb.Condition{
+	deletedOpt := mb.Null
+	if trashed {
+		deletedOpt = mb.NotNull
+	}
+
+	// Users are grouped by ID (instead of DISTINCT), so they can be ordered by expressions of their columns.
+	builder := mb.Instance().Select(models.TableUser+".*").
+		Where(models.TableUser+".deleted_at", deletedOpt, nil).
+		GroupBy(models.TableUser+".id").
+		Limit(limit, offset)
+
+	// Roles are joined for keyword searching and role filter only
+	if filterDto.Keyword != "" || hasFilter(filterDto.Conditions, "role") {
+		builder.Join(mb.LeftJoin, models.TableUserRole, mb.Condition{
 			Field: models.TableUserRole + ".user_id",
 			Opt:   mb.Eq,
 			Value: mb.ValueField(models.TableUser + ".id"),
 		}).
-		Join(mb.LeftJoin, models.TableRole, mb.Condition{
-			Field: models.TableRole + ".id",
-			Opt:   mb.Eq,
-			Value: mb.ValueField(models.TableUserRole + ".role_id"),
-		}).
-		Where(models.TableUser+".deleted_at", mb.Null, nil).
-		When(filterDto.Keyword != "", func(query mb.WhereBuilder) *mb.WhereBuilder {
-			query.WhereGroup(func(queryGroup mb.WhereBuilder) *mb.WhereBuilder {
-				queryGroup.Where(models.TableRole+".name", mb.Like, "%"+filterDto.Keyword+"%").
-					WhereOr(models.TableRole+".slug", mb.Like, "%"+filterDto.Keyword+"%").
-					WhereOr(models.TableUser+".email", mb.Like, "%"+filterDto.Keyword+"%").
-					WhereOr(models.TableUser+".fullname", mb.Like, "%"+filterDto.Keyword+"%").
-					WhereOr(models.TableUser+".phone", mb.Like, "%"+filterDto.Keyword+"%")
-
-				if slices.Contains(types.UserStatusList, types.UserStatus(filterDto.Keyword)) {
-					queryGroup.WhereOr(models.TableUser+".status", mb.Eq, filterDto.Keyword)
-				}
-
-				return &queryGroup
+			Join(mb.LeftJoin, models.TableRole, mb.Condition{
+				Field: models.TableRole + ".id",
+				Opt:   mb.Eq,
+				Value: mb.ValueField(models.TableUserRole + ".role_id"),
 			})
+	}
 
-			return &query
-		}).
-		Limit(filterDto.PerPage, offset)
-
-	if filterDto.OrderBy != "" {
-		// Default order by
-		direction := mb.Asc
-		orderKey := filterDto.OrderBy
+	if filterDto.Keyword != "" {
+		builder.WhereGroup(func(queryGroup mb.WhereBuilder) *mb.WhereBuilder {
+			queryGroup.Where(models.TableRole+".name", mb.Like, "%"+filterDto.Keyword+"%").
+				WhereOr(models.TableRole+".slug", mb.Like, "%"+filterDto.Keyword+"%").
+				WhereOr(models.TableUser+".email", mb.Like, "%"+filterDto.Keyword+"%").
+				WhereOr(models.TableUser+".fullname", mb.Like, "%"+filterDto.Keyword+"%").
+				WhereOr(models.TableUser+".phone", mb.Like, "%"+filterDto.Keyword+"%")
 
-		if strings.HasPrefix(filterDto.OrderBy, "-") {
-			orderKey = filterDto.OrderBy[1:]
-			direction = mb.Desc
-		}
+			if slices.Contains(types.UserStatusList, types.UserStatus(filterDto.Keyword)) {
+				queryGroup.WhereOr(models.TableUser+".status", mb.Eq, filterDto.Keyword)
+			}
 
-		var orderByFields = core.Data{
-			"id":          fmt.Sprintf("%s.id", models.TableUser),
-			"email":       fmt.Sprintf("%s.email", models.TableUser),
-			"fullname":    fmt.Sprintf("%s.fullname", models.TableUser),
-			"phone":       fmt.Sprintf("%s.phone", models.TableUser),
-			"status":      fmt.Sprintf("%s.status", models.TableUser),
-			"last_access": fmt.Sprintf("%s.last_access_at", models.TableUser),
-		}
+			return &queryGroup
+		})
+	}
 
-		if field, ok := orderByFields[orderKey]; ok {
-			builder.OrderBy(field.(string), direction)
-		}
+	if err = applyFilters(builder, filterDto.Conditions, userFilterFields); err != nil {
+		return nil, dto.Pagination{}, err
 	}
 
+	order.apply(builder, models.TableUser+".id")
+
 	// Query data
-	total, err = builder.Find(&users)
+	if order.cursor != nil {
+		err = findUncounted(builder, &users)
+	} else {
+		total, err = builder.Find(&users)
+	}
+	if err != nil {
+		log.Errorf("Error while querying users %v", err)
+
+		return nil, dto.Pagination{}, errors.New("Error occurs while querying users")
+	}
+
+	users, pagination := paginate(order, users, total, offset, filterDto.PerPage, userPosition(order))
 
 	// Return query result.
-	return users, total, err
+	return users, pagination, nil
 }
 
 // CreateUser creates a new user in the system.
@@ -118,19 +184,26 @@ func FindUsers(filterDto dto.Filter) ([]models.User, int, error) {
 // 2. Processes and uploads the user's avatar if provided.
 // 3. Creates a new user entity in the database.
 // 4. Assigns roles to the user (default role is "user" if no roles are provided).
+// The actor can only grant roles which are below their own roles.
 //
 // Parameters:
+//   - actorID (int): The ID of the user creating the user.
 //   - createUserDto (dto.CreateUser): The payload containing the user's details.
 //
 // Returns:
-//   - (*models.User, error): The created user object or an error if any step fails.
-func CreateUser(createUserDto dto.CreateUser) (*models.User, error) {
+//   - (*models.User, error): The created user object, ErrRoleRank or an error if any step fails.
+func CreateUser(actorID int, createUserDto dto.CreateUser) (*models.User, error) {
 	// Check if the user with the given email already exist
 	userEmail := repository.Pool.GetUserByEmail(createUserDto.Email)
 	if userEmail != nil {
 		return nil, errors.New("the user with given email already exist")
 	}
 
+	// Roles can be created at runtime, so they are checked against the database
+	if err := checkGrantedRoles(actorID, createUserDto.Roles); err != nil {
+		return nil, err
+	}
+
 	// Create a new user
 	user := &models.User{
 		Status:       types.UserStatusActive,
@@ -160,15 +233,18 @@ func CreateUser(createUserDto dto.CreateUser) (*models.User, error) {
 		return nil, errors.New("error occurs while syncing user roles")
 	}
 
+	_ = event.Dispatch(userEvents.UserRegistered{User: user})
+
 	return user, nil
 }
 
 // UpdateUser updates an existing user in the system.
 //
 // This function fetches the user by their ID, updates the fields based on the given DTO,
-// and synchronizes the user's roles if provided.
+// and synchronizes the user's roles if provided. The actor can only grant roles which are below their own roles.
 //
 // Parameters:
+//   - actorID (int): The ID of the user updating the user.
 //   - updateUserDto (dto.UpdateUser): The payload containing the user's updated details.
 //
 // Returns:
@@ -176,14 +252,31 @@ func CreateUser(createUserDto dto.CreateUser) (*models.User, error) {
 //
 // Possible Errors:
 //   - "User not found": Returned when no user is found for the provided ID.
+//   - ErrRoleRank: Returned when a given role is not below the roles of the actor.
 //   - "Error occurs while updating user": Returned when an error occurs during the update process.
 //   - "Error occurs while syncing user roles": Returned when an error occurs during the role synchronization process.
-func UpdateUser(updateUserDto dto.UpdateUser) (*models.User, error) {
+func UpdateUser(actorID int, updateUserDto dto.UpdateUser) (*models.User, error) {
 	user, err := mb.GetModelByID[models.User](updateUserDto.ID)
 	if err != nil {
 		return nil, errors.New("User not found")
 	}
 
+	if err = checkGrantedRoles(actorID, updateUserDto.Roles); err != nil {
+		return nil, err
+	}
+
+	// Refuse a password with the data of the user, or which was used recently
+	previousPassword := user.Password
+	if updateUserDto.Password != "" {
+		if err = password.CheckPersonalData(updateUserDto.Password, user.Email, user.Fullname, updateUserDto.Fullname); err != nil {
+			return nil, errors.New("password %v", err)
+		}
+
+		if err = password.CheckHistory(user, updateUserDto.Password); err != nil {
+			return nil, errors.New("password %v", err)
+		}
+	}
+
 	// Update the fields that are provided in the updateUserDto
 	updatedUser := updateUserFromDto(user, updateUserDto)
 
@@ -192,6 +285,10 @@ func UpdateUser(updateUserDto dto.UpdateUser) (*models.User, error) {
 		return nil, errors.New("Error occurs while updating user")
 	}
 
+	if updateUserDto.Password != "" {
+		password.RememberPassword(user.ID, previousPassword)
+	}
+
 	// Sync user roles
 	if len(updateUserDto.Roles) > 0 {
 		if err = repository.Pool.SyncRolesWithUser(user.ID, updateUserDto.Roles...); err != nil {
@@ -200,6 +297,8 @@ func UpdateUser(updateUserDto dto.UpdateUser) (*models.User, error) {
 		}
 	}
 
+	_ = event.Dispatch(userEvents.UserUpdated{User: user})
+
 	return user, nil
 }
 
@@ -230,8 +329,10 @@ func UpdateUserStatus(updateUserStatusDto dto.UpdateUserStatus) (*models.User, e
 		return nil, errors.New("Error occurs while updating user's status %v", updateUserStatusDto.Status)
 	}
 
-	// Set new status and update user
+	// Set new status and update user.
+	// `blocked_at` marks a temporary sign-in lockout. It is cleared, so an admin decision is not auto-unlocked.
 	user.Status = types.UserStatus(updateUserStatusDto.Status)
+	user.BlockedAt = sql.NullTime{}
 
 	if err = mb.UpdateModel(user); err != nil {
 		log.Errorf("Error while updating user %v", err)
@@ -239,46 +340,125 @@ func UpdateUserStatus(updateUserStatusDto dto.UpdateUserStatus) (*models.User, e
 		return nil, errors.New("Error occurs while updating user")
 	}
 
+	_ = event.Dispatch(userEvents.UserUpdated{User: user})
+
 	return user, nil
 }
 
-// DeleteUserByID deletes a user and all associated roles from the system.
+// DeleteUserByID soft-deletes a user.
 //
-// This function performs the following steps:
-// 1. Fetches the user by their ID.
-// 2. Deletes all roles synchronized with the user.
-// 3. Deletes the user from the database.
+// The user keeps their roles and data, but can not sign in anymore (access tokens are refused too).
+// The user can be restored by RestoreUserByID until PurgeDeletedUsers removes the account permanently.
 //
 // Parameters:
 //   - userID (int): The unique identifier of the user to be deleted.
 //
 // Returns:
 //   - error: An error object if any step fails. Possible errors include:
-//   - "User not found": Returned when no user is found for the provided ID.
-//   - "Error occurs while deleting user roles": Returned when an error occurs while deleting roles synchronized with the user.
-//   - "Error occurs while deleting user": Returned when an error occurs during the deletion of the user record.
+//   - "User not found": Returned when no user (which is not deleted yet) is found for the provided ID.
+//   - "Error occurs while deleting user": Returned when an error occurs during the update of the user record.
 func DeleteUserByID(userID int) error {
 	user, err := mb.GetModelByID[models.User](userID)
-	if err != nil {
+	if err != nil || user.DeletedAt.Valid {
 		return errors.New("User not found")
 	}
 
-	// Delete roles that sync with user
-	if err := repository.Pool.SyncRolesWithUser(userID, ""); err != nil {
-		log.Errorf("Error while deleting user roles: %v", err)
-		return errors.New("error occurs while deleting user roles")
-	}
+	user.DeletedAt = dbNull.TimeNow()
+	user.UpdatedAt = dbNull.TimeNow()
 
-	// Delete user
-	if err := mb.DeleteModel(user); err != nil {
+	if err = mb.UpdateModel(user); err != nil {
 		log.Errorf("Error while deleting user: %v", err)
 		return errors.New("error occurs while deleting user")
 	}
 
+	// Nothing irreversible until the purge: the user is only removed from the search results.
+	// `UserDeleted` is dispatched by PurgeDeletedUsers.
+	_ = event.Dispatch(userEvents.UserUpdated{User: user})
+
 	return nil
 }
 
+// RestoreUserByID restores a soft-deleted user. The user can sign in again with their roles.
+//
+// Parameters:
+//   - userID (int): The unique identifier of the deleted user.
+//
+// Returns:
+//   - (*models.User, error): The restored user or an error. Possible errors include:
+//   - "User not found": Returned when no deleted user is found for the provided ID.
+//   - "Error occurs while restoring user": Returned when the update of the user record fails.
+func RestoreUserByID(userID int) (*models.User, error) {
+	user, err := mb.GetModelByID[models.User](userID)
+	if err != nil || !user.DeletedAt.Valid {
+		return nil, errors.New("User not found")
+	}
+
+	user.DeletedAt = sql.NullTime{}
+	user.UpdatedAt = dbNull.TimeNow()
+
+	if err = mb.UpdateModel(user); err != nil {
+		log.Errorf("Error while restoring user: %v", err)
+		return nil, errors.New("Error occurs while restoring user")
+	}
+
+	// The user is searchable again
+	_ = event.Dispatch(userEvents.UserUpdated{User: user})
+
+	return user, nil
+}
+
+// PurgeDeletedUsers permanently deletes the users which were soft-deleted before the retention period.
+// Roles are removed with the user and the event `UserDeleted` is dispatched for each purged user.
+//
+// Parameters:
+//   - retention (time.Duration): How long a soft-deleted user is kept.
+//
+// Returns:
+//   - (int, error): The number of purged users, or an error if a query fails.
+func PurgeDeletedUsers(retention time.Duration) (int, error) {
+	cutoff := time.Now().Add(-retention)
+	purged := 0
+
+	for {
+		var users []models.User
+
+		_, err := mb.Instance().
+			Where("deleted_at", mb.NotNull, nil).
+			Where("deleted_at", mb.Lesser, cutoff).
+			OrderBy("id", mb.Asc).
+			Limit(purgeBatchSize, 0).
+			Find(&users)
+		if err != nil {
+			return purged, err
+		}
+
+		for i := range users {
+			user := users[i]
+
+			// Delete roles that sync with user
+			if err = repository.Pool.SyncRolesWithUser(user.ID, ""); err != nil {
+				return purged, err
+			}
+
+			if err = mb.DeleteModel(&user); err != nil {
+				return purged, err
+			}
+			purged++
+
+			_ = event.Dispatch(userEvents.UserDeleted{
+				UserID: user.ID,
+				Email:  user.Email,
+			})
+		}
+
+		if len(users) < purgeBatchSize {
+			return purged, nil
+		}
+	}
+}
+
 // UserHasRole checks if a user has any of the specified roles.
+// Inherited roles count: an `admin` user has the role `moderator` when `admin` inherits `moderator`.
 //
 // Parameters:
 //   - userID (int): The ID of the user whose roles are being checked.
@@ -287,7 +467,7 @@ func DeleteUserByID(userID int) error {
 // Returns:
 //   - bool: True if the user has at least one of the specified roles, otherwise false.
 func UserHasRole(userID int, roles []types.Role) bool {
-	roleList := repository.Pool.GetRolesByUserID(userID)
+	roleList := UserRoles(userID)
 	if len(roleList) == 0 {
 		return false
 	}
@@ -305,6 +485,34 @@ func UserHasRole(userID int, roles []types.Role) bool {
 // ======================== Helper Functions ==========================
 // ====================================================================
 
+// userPosition returns the cursor position (order column value and ID) of a user in a list.
+func userPosition(order listOrder) func(models.User) (*string, int) {
+	return func(user models.User) (*string, int) {
+		var value string
+
+		switch strings.TrimPrefix(order.orderBy, "-") {
+		case "id":
+			value = strconv.Itoa(user.ID)
+		case "created_at":
+			value = user.CreatedAt.Format(time.RFC3339Nano)
+		case "email":
+			value = user.Email
+		case "fullname":
+			value = user.Fullname
+		case "phone":
+			value = user.Phone
+		case "status":
+			value = string(user.Status)
+		case "last_access":
+			return cursorTime(user.LastAccessAt.Time, user.LastAccessAt.Valid), user.ID
+		case "deleted_at":
+			return cursorTime(user.DeletedAt.Time, user.DeletedAt.Valid), user.ID
+		}
+
+		return &value, user.ID
+	}
+}
+
 // updateUserFromDto updates an existing User model with data from UpdateUser DTO.
 // Only updates fields that are provided in the DTO.
 //
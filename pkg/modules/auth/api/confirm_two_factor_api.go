@@ -0,0 +1,65 @@
+package api
+
+import (
+	"gfly/internal/domain/models"
+	"gfly/pkg/modules/auth/request"
+	"gfly/pkg/modules/auth/response"
+	"gfly/pkg/modules/auth/services"
+	"github.com/gflydev/core"
+	"github.com/gflydev/http"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewConfirmTwoFactorApi As a constructor to create new API.
+func NewConfirmTwoFactorApi() *ConfirmTwoFactorApi {
+	return &ConfirmTwoFactorApi{}
+}
+
+// ConfirmTwoFactorApi API struct.
+type ConfirmTwoFactorApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ======================== Request Validation ========================
+// ====================================================================
+
+// Validate data from request
+func (h *ConfirmTwoFactorApi) Validate(c *core.Ctx) error {
+	return http.ProcessData[request.TwoFactorCode](c)
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle method to confirm the TOTP enrollment of the current user.
+// @Description Enable two-factor authentication with a code of the authenticator app. Returns one-time recovery codes which are shown only once.
+// @Summary confirm two-factor authentication
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param data body request.TwoFactorCode true "TwoFactorCode payload"
+// @Success 200 {object} response.RecoveryCodes
+// @Failure 400 {object} http.Error
+// @Failure 401 {object} http.Error
+// @Security ApiKeyAuth
+// @Router /auth/2fa/confirm [post]
+func (h *ConfirmTwoFactorApi) Handle(c *core.Ctx) error {
+	user := c.GetData(http.UserKey).(models.User)
+	requestData := c.GetData(http.RequestKey).(request.TwoFactorCode)
+
+	codes, err := services.ConfirmTwoFactor(user.ID, requestData.ToDto().Code)
+	if err != nil {
+		return c.Error(http.Error{
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(response.RecoveryCodes{
+		RecoveryCodes: codes,
+	})
+}
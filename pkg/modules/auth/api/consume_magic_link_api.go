@@ -0,0 +1,98 @@
+package api
+
+import (
+	"gfly/pkg/modules/auth"
+	"gfly/pkg/modules/auth/request"
+	_ "gfly/pkg/modules/auth/response" // Used for Swagger documentation
+	"gfly/pkg/modules/auth/services"
+	"gfly/pkg/modules/auth/transformers"
+	"github.com/gflydev/core"
+	"github.com/gflydev/http"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// ConsumeMagicLinkApi API struct.
+type ConsumeMagicLinkApi struct {
+	Type auth.Type
+	core.Api
+}
+
+// NewConsumeMagicLinkApi is a constructor
+func NewConsumeMagicLinkApi(authType auth.Type) *ConsumeMagicLinkApi {
+	return &ConsumeMagicLinkApi{
+		Type: authType,
+	}
+}
+
+// ====================================================================
+// ======================== Request Validation ========================
+// ====================================================================
+
+// Validate data from request
+func (h *ConsumeMagicLinkApi) Validate(c *core.Ctx) error {
+	return http.ProcessData[request.ConsumeMagicLink](c)
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle func exchanges a signed sign-in link for access and refresh tokens
+// @Description Sign in with the link sent by `/auth/magic-link`. The link can be used once. Returns the same result as `/auth/signin`.
+// @Summary sign in with a sign-in link
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param data body request.ConsumeMagicLink true "ConsumeMagicLink payload"
+// @Success 200 {object} response.SignIn
+// @Success 202 {object} response.TwoFactorChallenge
+// @Failure 400 {object} http.Error
+// @Failure 429 {object} http.Error
+// @Router /auth/magic-link/consume [post]
+func (h *ConsumeMagicLinkApi) Handle(c *core.Ctx) error {
+	requestData := c.GetData(http.RequestKey).(request.ConsumeMagicLink)
+
+	if h.Type == auth.TypeWeb {
+		user, err := services.ConsumeMagicLink(requestData.ToDto())
+		if err != nil {
+			return c.Error(http.Error{
+				Message: err.Error(),
+			}, signInErrorStatus(err))
+		}
+
+		// Second factor is required before opening the web session
+		if services.IsTwoFactorEnabled(user.ID) {
+			challenge, err := services.CreateTwoFactorChallenge(user.ID)
+			if err != nil {
+				return c.Error(http.Error{
+					Message: err.Error(),
+				})
+			}
+
+			return c.Status(core.StatusAccepted).JSON(transformers.ToTwoFactorChallengeResponse(&auth.Token{
+				Challenge: challenge,
+			}))
+		}
+
+		c.SetSession(auth.SessionUsername, user.Email)
+
+		return c.NoContent()
+	}
+
+	tokens, err := services.MagicLinkSignIn(requestData.ToDto(), services.ExtractClient(c))
+	if err != nil {
+		return c.Error(http.Error{
+			Message: err.Error(),
+		}, signInErrorStatus(err))
+	}
+
+	// Second factor is required => return the challenge token
+	if tokens.Challenge != "" {
+		return c.Status(core.StatusAccepted).JSON(transformers.ToTwoFactorChallengeResponse(tokens))
+	}
+
+	return c.JSON(transformers.ToSignInResponse(tokens))
+}
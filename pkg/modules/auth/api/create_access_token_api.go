@@ -0,0 +1,66 @@
+package api
+
+import (
+	"gfly/internal/domain/models"
+	"gfly/pkg/modules/auth"
+	"gfly/pkg/modules/auth/request"
+	_ "gfly/pkg/modules/auth/response" // Used for Swagger documentation
+	"gfly/pkg/modules/auth/services"
+	"gfly/pkg/modules/auth/transformers"
+	"github.com/gflydev/core"
+	"github.com/gflydev/http"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewCreateAccessTokenApi As a constructor to create new API.
+func NewCreateAccessTokenApi() *CreateAccessTokenApi {
+	return &CreateAccessTokenApi{}
+}
+
+// CreateAccessTokenApi API struct.
+type CreateAccessTokenApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ======================== Request Validation ========================
+// ====================================================================
+
+// Validate data from request
+func (h *CreateAccessTokenApi) Validate(c *core.Ctx) error {
+	return http.ProcessData[request.CreateAccessToken](c)
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle method to create a personal access token for the current user.
+// @Description Create a long-lived token for machine clients (`Authorization: Bearer gfly_pat_...`). The plain text token is returned only once.
+// @Summary create a personal access token
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param data body request.CreateAccessToken true "CreateAccessToken payload"
+// @Success 201 {object} response.NewAccessToken
+// @Failure 400 {object} http.Error
+// @Failure 401 {object} http.Error
+// @Security ApiKeyAuth
+// @Router /users/profile/tokens [post]
+func (h *CreateAccessTokenApi) Handle(c *core.Ctx) error {
+	user := c.GetData(http.UserKey).(models.User)
+	grantedScopes, _ := c.GetData(auth.ScopesKey).([]string)
+	requestData := c.GetData(http.RequestKey).(request.CreateAccessToken)
+
+	accessToken, plainToken, err := services.CreateAccessToken(user.ID, grantedScopes, requestData.ToDto())
+	if err != nil {
+		return c.Error(http.Error{
+			Message: err.Error(),
+		})
+	}
+
+	return c.Status(core.StatusCreated).JSON(transformers.ToNewAccessTokenResponse(*accessToken, plainToken))
+}
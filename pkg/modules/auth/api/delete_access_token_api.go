@@ -0,0 +1,60 @@
+package api
+
+import (
+	"gfly/internal/domain/models"
+	"gfly/pkg/modules/auth/services"
+	"github.com/gflydev/core"
+	"github.com/gflydev/http"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewDeleteAccessTokenApi As a constructor to create new API.
+func NewDeleteAccessTokenApi() *DeleteAccessTokenApi {
+	return &DeleteAccessTokenApi{}
+}
+
+// DeleteAccessTokenApi API struct.
+type DeleteAccessTokenApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ======================== Request Validation ========================
+// ====================================================================
+
+// Validate data from request
+func (h *DeleteAccessTokenApi) Validate(c *core.Ctx) error {
+	return http.ProcessPathID(c)
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle method to revoke a personal access token of the current user.
+// @Description Revoke (delete) a personal access token of the current user.
+// @Summary revoke a personal access token
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path int true "Token ID"
+// @Success 204
+// @Failure 401 {object} http.Error
+// @Failure 404 {object} http.Error
+// @Security ApiKeyAuth
+// @Router /users/profile/tokens/{id} [delete]
+func (h *DeleteAccessTokenApi) Handle(c *core.Ctx) error {
+	user := c.GetData(http.UserKey).(models.User)
+	tokenID := c.GetData(http.PathIDKey).(int)
+
+	if err := services.DeleteAccessToken(user.ID, tokenID); err != nil {
+		return c.Error(http.Error{
+			Message: err.Error(),
+		}, core.StatusNotFound)
+	}
+
+	return c.NoContent()
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"gfly/internal/domain/models"
+	"gfly/pkg/modules/auth/request"
+	"gfly/pkg/modules/auth/services"
+	"github.com/gflydev/core"
+	"github.com/gflydev/http"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewDisableTwoFactorApi As a constructor to create new API.
+func NewDisableTwoFactorApi() *DisableTwoFactorApi {
+	return &DisableTwoFactorApi{}
+}
+
+// DisableTwoFactorApi API struct.
+type DisableTwoFactorApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ======================== Request Validation ========================
+// ====================================================================
+
+// Validate data from request
+func (h *DisableTwoFactorApi) Validate(c *core.Ctx) error {
+	return http.ProcessData[request.TwoFactorCode](c)
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle method to disable two-factor authentication of the current user.
+// @Description Disable two-factor authentication. Requires a code of the authenticator app or a recovery code.
+// @Summary disable two-factor authentication
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param data body request.TwoFactorCode true "TwoFactorCode payload"
+// @Success 204
+// @Failure 400 {object} http.Error
+// @Failure 401 {object} http.Error
+// @Security ApiKeyAuth
+// @Router /auth/2fa/disable [post]
+func (h *DisableTwoFactorApi) Handle(c *core.Ctx) error {
+	user := c.GetData(http.UserKey).(models.User)
+	requestData := c.GetData(http.RequestKey).(request.TwoFactorCode)
+
+	if err := services.DisableTwoFactor(user.ID, requestData.ToDto().Code); err != nil {
+		return c.Error(http.Error{
+			Message: err.Error(),
+		})
+	}
+
+	return c.NoContent()
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"gfly/internal/domain/models"
+	_ "gfly/pkg/modules/auth/response" // Used for Swagger documentation
+	"gfly/pkg/modules/auth/services"
+	"gfly/pkg/modules/auth/transformers"
+	"github.com/gflydev/core"
+	"github.com/gflydev/http"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewEnrollTwoFactorApi As a constructor to create new API.
+func NewEnrollTwoFactorApi() *EnrollTwoFactorApi {
+	return &EnrollTwoFactorApi{}
+}
+
+// EnrollTwoFactorApi API struct.
+type EnrollTwoFactorApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle method to start the TOTP enrollment of the current user.
+// @Description Generate a TOTP secret with its otpauth URI and QR code. Two-factor authentication is enabled after confirmation.
+// @Summary enroll two-factor authentication
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.TwoFactorEnrollment
+// @Failure 400 {object} http.Error
+// @Failure 401 {object} http.Error
+// @Security ApiKeyAuth
+// @Router /auth/2fa/enroll [post]
+func (h *EnrollTwoFactorApi) Handle(c *core.Ctx) error {
+	user := c.GetData(http.UserKey).(models.User)
+
+	enrollment, err := services.EnrollTwoFactor(user)
+	if err != nil {
+		return c.Error(http.Error{
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(transformers.ToTwoFactorEnrollmentResponse(enrollment))
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"gfly/internal/domain/models"
+	_ "gfly/pkg/modules/auth/response" // Used for Swagger documentation
+	"gfly/pkg/modules/auth/services"
+	"gfly/pkg/modules/auth/transformers"
+	"github.com/gflydev/core"
+	"github.com/gflydev/http"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewGetAccessTokenApi As a constructor to create new API.
+func NewGetAccessTokenApi() *GetAccessTokenApi {
+	return &GetAccessTokenApi{}
+}
+
+// GetAccessTokenApi API struct.
+type GetAccessTokenApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ======================== Request Validation ========================
+// ====================================================================
+
+// Validate data from request
+func (h *GetAccessTokenApi) Validate(c *core.Ctx) error {
+	return http.ProcessPathID(c)
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle method to get a personal access token of the current user.
+// @Description Get a personal access token of the current user.
+// @Summary get a personal access token
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path int true "Token ID"
+// @Success 200 {object} response.AccessToken
+// @Failure 401 {object} http.Error
+// @Failure 404 {object} http.Error
+// @Security ApiKeyAuth
+// @Router /users/profile/tokens/{id} [get]
+func (h *GetAccessTokenApi) Handle(c *core.Ctx) error {
+	user := c.GetData(http.UserKey).(models.User)
+	tokenID := c.GetData(http.PathIDKey).(int)
+
+	accessToken, err := services.GetAccessToken(user.ID, tokenID)
+	if err != nil {
+		return c.Error(http.Error{
+			Message: err.Error(),
+		}, core.StatusNotFound)
+	}
+
+	return c.JSON(transformers.ToAccessTokenResponse(*accessToken))
+}
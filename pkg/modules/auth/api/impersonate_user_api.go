@@ -0,0 +1,71 @@
+package api
+
+import (
+	"gfly/internal/domain/models"
+	"gfly/pkg/modules/auth/request"
+	_ "gfly/pkg/modules/auth/response" // Used for Swagger documentation
+	"gfly/pkg/modules/auth/services"
+	"gfly/pkg/modules/auth/transformers"
+	"github.com/gflydev/core"
+	"github.com/gflydev/http"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewImpersonateUserApi As a constructor to create new API.
+func NewImpersonateUserApi() *ImpersonateUserApi {
+	return &ImpersonateUserApi{}
+}
+
+// ImpersonateUserApi API struct.
+type ImpersonateUserApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ======================== Request Validation ========================
+// ====================================================================
+
+// Validate data from request
+func (h *ImpersonateUserApi) Validate(c *core.Ctx) error {
+	if err := http.ProcessPathID(c); err != nil {
+		return err
+	}
+
+	return http.ProcessData[request.Impersonate](c)
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle method to sign in as another user.
+// @Description Issue a short-lived access token of the user for the current admin (`act` claim). Sensitive endpoints are refused to the token. The start is recorded, stop it with `DELETE /auth/impersonation`. Admin accounts can not be impersonated.
+// @Summary impersonate a user
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param data body request.Impersonate true "Impersonate payload"
+// @Success 201 {object} response.Impersonation
+// @Failure 400 {object} http.Error
+// @Failure 401 {object} http.Error
+// @Failure 403 {object} http.Error
+// @Security ApiKeyAuth
+// @Router /users/{id}/impersonate [post]
+func (h *ImpersonateUserApi) Handle(c *core.Ctx) error {
+	actor := c.GetData(http.UserKey).(models.User)
+	userID := c.GetData(http.PathIDKey).(int)
+	requestData := c.GetData(http.RequestKey).(request.Impersonate)
+
+	impersonation, accessToken, err := services.StartImpersonation(actor, userID, requestData.ToDto(), services.ExtractClient(c))
+	if err != nil {
+		return c.Error(http.Error{
+			Message: err.Error(),
+		})
+	}
+
+	return c.Status(core.StatusCreated).JSON(transformers.ToImpersonationResponse(*impersonation, accessToken))
+}
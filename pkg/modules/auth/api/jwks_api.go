@@ -0,0 +1,37 @@
+package api
+
+import (
+	_ "gfly/pkg/modules/auth/response" // Used for Swagger documentation
+	"gfly/pkg/modules/auth/services"
+	"gfly/pkg/modules/auth/transformers"
+	"github.com/gflydev/core"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewJWKSApi As a constructor to create new API.
+func NewJWKSApi() *JWKSApi {
+	return &JWKSApi{}
+}
+
+// JWKSApi API struct.
+type JWKSApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle method to publish the public keys used to verify access tokens.
+// @Description Publish public keys (JWKS) so other services can verify access tokens without the shared secret.
+// @Summary public JSON Web Key Set
+// @Tags Auth
+// @Produce json
+// @Success 200 {object} response.JWKS
+// @Router /.well-known/jwks.json [get]
+func (h *JWKSApi) Handle(c *core.Ctx) error {
+	return c.JSON(transformers.ToJWKSResponse(services.PublicKeys()))
+}
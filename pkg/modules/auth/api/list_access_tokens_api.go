@@ -0,0 +1,53 @@
+package api
+
+import (
+	"gfly/internal/domain/models"
+	"gfly/pkg/modules/auth/response"
+	"gfly/pkg/modules/auth/services"
+	"gfly/pkg/modules/auth/transformers"
+	"github.com/gflydev/core"
+	"github.com/gflydev/http"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewListAccessTokensApi As a constructor to create new API.
+func NewListAccessTokensApi() *ListAccessTokensApi {
+	return &ListAccessTokensApi{}
+}
+
+// ListAccessTokensApi API struct.
+type ListAccessTokensApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle method to list personal access tokens of the current user.
+// @Description List personal access tokens of the current user. Plain text tokens are never returned.
+// @Summary list personal access tokens
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.ListAccessToken
+// @Failure 401 {object} http.Error
+// @Security ApiKeyAuth
+// @Router /users/profile/tokens [get]
+func (h *ListAccessTokensApi) Handle(c *core.Ctx) error {
+	user := c.GetData(http.UserKey).(models.User)
+
+	accessTokens := services.ListAccessTokens(user.ID)
+
+	data := make([]response.AccessToken, 0, len(accessTokens))
+	for _, accessToken := range accessTokens {
+		data = append(data, transformers.ToAccessTokenResponse(accessToken))
+	}
+
+	return c.JSON(response.ListAccessToken{
+		Data: data,
+	})
+}
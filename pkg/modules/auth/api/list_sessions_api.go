@@ -0,0 +1,55 @@
+package api
+
+import (
+	"gfly/internal/domain/models"
+	"gfly/pkg/modules/auth"
+	"gfly/pkg/modules/auth/response"
+	"gfly/pkg/modules/auth/services"
+	"gfly/pkg/modules/auth/transformers"
+	"github.com/gflydev/core"
+	"github.com/gflydev/http"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewListSessionsApi As a constructor to create new API.
+func NewListSessionsApi() *ListSessionsApi {
+	return &ListSessionsApi{}
+}
+
+// ListSessionsApi API struct.
+type ListSessionsApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle method to list signed-in devices of the current user.
+// @Description List active sessions (signed-in devices) of the current user.
+// @Summary list active sessions of the current user
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.ListSession
+// @Failure 401 {object} http.Error
+// @Security ApiKeyAuth
+// @Router /auth/sessions [get]
+func (h *ListSessionsApi) Handle(c *core.Ctx) error {
+	user := c.GetData(http.UserKey).(models.User)
+	currentSessionID, _ := c.GetData(auth.SessionIDKey).(string)
+
+	sessions := services.ListSessions(user.ID)
+
+	data := make([]response.Session, 0, len(sessions))
+	for _, session := range sessions {
+		data = append(data, transformers.ToSessionResponse(session, currentSessionID))
+	}
+
+	return c.JSON(response.ListSession{
+		Data: data,
+	})
+}
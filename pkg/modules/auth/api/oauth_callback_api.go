@@ -0,0 +1,128 @@
+package api
+
+import (
+	"gfly/pkg/modules/auth"
+	"gfly/pkg/modules/auth/dto"
+	"gfly/pkg/modules/auth/request"
+	_ "gfly/pkg/modules/auth/response" // Used for Swagger documentation
+	"gfly/pkg/modules/auth/services"
+	"gfly/pkg/modules/auth/transformers"
+	"github.com/gflydev/core"
+	"github.com/gflydev/http"
+	"net/url"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewOAuthCallbackApi As a constructor to create new API.
+func NewOAuthCallbackApi() *OAuthCallbackApi {
+	return &OAuthCallbackApi{}
+}
+
+// OAuthCallbackApi API struct.
+type OAuthCallbackApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ======================== Request Validation ========================
+// ====================================================================
+
+// Validate data from request
+func (h *OAuthCallbackApi) Validate(c *core.Ctx) error {
+	// The user denied the consent or the provider failed
+	if providerError := c.QueryStr("error"); providerError != "" {
+		return c.Error(http.Error{
+			Message: "Sign-in was refused by identity provider: " + providerError,
+		})
+	}
+
+	requestData := request.OAuthCallback{
+		OAuthCallback: dto.OAuthCallback{
+			Code:  c.QueryStr("code"),
+			State: c.QueryStr("state"),
+		},
+	}
+
+	if errData := http.Validate(requestData); errData != nil {
+		return c.Error(errData)
+	}
+
+	c.SetData(http.RequestKey, requestData)
+
+	return nil
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle func completes the sign-in at an identity provider
+// @Description Callback of an OAuth2 / OpenID Connect provider. The user is found by the linked account or the verified email, otherwise created. API sign-in returns access and refresh token (or a two-factor challenge). Web sign-in opens the session and redirects to `/profile` (errors and two-factor challenges are redirected to `/login`).
+// @Summary complete sign-in with an identity provider
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param provider path string true "Provider name. Ex: oidc"
+// @Param code query string true "The authorization code"
+// @Param state query string true "The state of the authorization request"
+// @Success 200 {object} response.SignIn
+// @Success 202 {object} response.TwoFactorChallenge
+// @Failure 400 {object} http.Error
+// @Failure 429 {object} http.Error
+// @Router /auth/oauth/{provider}/callback [get]
+func (h *OAuthCallbackApi) Handle(c *core.Ctx) error {
+	requestData := c.GetData(http.RequestKey).(request.OAuthCallback)
+
+	user, authType, err := services.CompleteOAuth(c.PathVal("provider"), requestData.ToDto())
+	if err != nil && authType == auth.TypeWeb {
+		return temporaryRedirect(c, "/login?error="+url.QueryEscape(err.Error()))
+	}
+
+	if err != nil {
+		return c.Error(http.Error{
+			Message: err.Error(),
+		}, signInErrorStatus(err))
+	}
+
+	if authType == auth.TypeWeb {
+		// Second factor is required before opening the web session
+		if services.IsTwoFactorEnabled(user.ID) {
+			challenge, err := services.CreateTwoFactorChallenge(user.ID)
+			if err != nil {
+				return temporaryRedirect(c, "/login?error="+url.QueryEscape(err.Error()))
+			}
+
+			return temporaryRedirect(c, "/login?challenge="+url.QueryEscape(challenge))
+		}
+
+		c.SetSession(auth.SessionUsername, user.Email)
+
+		return temporaryRedirect(c, "/profile")
+	}
+
+	// Second factor is required => return the challenge token
+	if services.IsTwoFactorEnabled(user.ID) {
+		challenge, err := services.CreateTwoFactorChallenge(user.ID)
+		if err != nil {
+			return c.Error(http.Error{
+				Message: err.Error(),
+			})
+		}
+
+		return c.Status(core.StatusAccepted).JSON(transformers.ToTwoFactorChallengeResponse(&auth.Token{
+			Challenge: challenge,
+		}))
+	}
+
+	tokens, err := services.IssueTokens(user, services.ExtractClient(c))
+	if err != nil {
+		return c.Error(http.Error{
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(transformers.ToSignInResponse(tokens))
+}
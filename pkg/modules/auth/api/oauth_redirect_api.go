@@ -0,0 +1,65 @@
+package api
+
+import (
+	"gfly/pkg/modules/auth"
+	"gfly/pkg/modules/auth/response"
+	"gfly/pkg/modules/auth/services"
+	"github.com/gflydev/core"
+	"github.com/gflydev/http"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// OAuthRedirectApi API struct.
+type OAuthRedirectApi struct {
+	Type auth.Type
+	core.Api
+}
+
+// NewOAuthRedirectApi is a constructor
+func NewOAuthRedirectApi(authType auth.Type) *OAuthRedirectApi {
+	return &OAuthRedirectApi{
+		Type: authType,
+	}
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle func starts the sign-in at an identity provider (authorization code flow with PKCE)
+// @Description Start the sign-in at an OAuth2 / OpenID Connect provider. API clients receive the authorization URL to open in a browser, Web clients are redirected to it. The provider redirects back to `/auth/oauth/{provider}/callback`.
+// @Summary start sign-in with an identity provider
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param provider path string true "Provider name. Ex: oidc"
+// @Success 200 {object} response.OAuthRedirect
+// @Failure 400 {object} http.Error
+// @Router /auth/oauth/{provider} [get]
+func (h *OAuthRedirectApi) Handle(c *core.Ctx) error {
+	authURL, err := services.StartOAuth(c.PathVal("provider"), h.Type)
+	if err != nil {
+		return c.Error(http.Error{
+			Message: err.Error(),
+		})
+	}
+
+	if h.Type == auth.TypeWeb {
+		return temporaryRedirect(c, authURL)
+	}
+
+	return c.JSON(response.OAuthRedirect{
+		URL: authURL,
+	})
+}
+
+// temporaryRedirect redirects with `302 Found`. Unlike `c.Redirect` (301), the
+// response is not cached by browsers, each sign-in gets a new state.
+func temporaryRedirect(c *core.Ctx, location string) error {
+	c.Root().Redirect(location, core.StatusFound)
+
+	return nil
+}
@@ -59,7 +59,7 @@ func (h *RefreshTokenApi) Handle(c *core.Ctx) error {
 
 	jwtToken := services.ExtractToken(c)
 	// Refresh new pairs of access token & refresh token
-	tokens, err := services.RefreshToken(jwtToken, requestData.ToDto().Token)
+	tokens, err := services.RefreshToken(jwtToken, requestData.ToDto().Token, services.ExtractClient(c))
 	if err != nil {
 		return c.Error(http.Error{
 			Message: err.Error(),
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"gfly/pkg/modules/auth/request"
+	"gfly/pkg/modules/auth/services"
+	"github.com/gflydev/core"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/http"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewResendVerifyEmailApi As a constructor to create new API.
+func NewResendVerifyEmailApi() *ResendVerifyEmailApi {
+	return &ResendVerifyEmailApi{}
+}
+
+// ResendVerifyEmailApi API struct.
+type ResendVerifyEmailApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ======================== Request Validation ========================
+// ====================================================================
+
+// Validate data from request
+func (h *ResendVerifyEmailApi) Validate(c *core.Ctx) error {
+	return http.ProcessData[request.ResendVerifyEmail](c)
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle method to send the email verification link again.
+// @Summary Resend verification email
+// @Description Send the email verification link again. Limited to one request per address within `AUTH_VERIFY_EMAIL_RESEND_SECONDS`.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param data body request.ResendVerifyEmail true "Resend verification email payload"
+// @Success 204
+// @Failure 400 {object} http.Error
+// @Failure 429 {object} http.Error
+// @Router /auth/verify-email/resend [post]
+func (h *ResendVerifyEmailApi) Handle(c *core.Ctx) error {
+	requestData := c.GetData(http.RequestKey).(request.ResendVerifyEmail)
+
+	if err := services.ResendVerificationEmail(requestData.ToDto()); err != nil {
+		if errors.Is(err, services.ErrResendTooEarly) {
+			return c.Error(http.Error{
+				Message: err.Error(),
+			}, core.StatusTooManyRequests)
+		}
+
+		return c.Error(http.Error{
+			Message: err.Error(),
+		})
+	}
+
+	return c.NoContent()
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"gfly/pkg/modules/auth/password"
 	"gfly/pkg/modules/auth/request"
 	"gfly/pkg/modules/auth/services"
 	"github.com/gflydev/core"
@@ -28,7 +29,7 @@ type ResetPWApi struct {
 
 // Validate Verify data from request.
 func (h *ResetPWApi) Validate(c *core.Ctx) error {
-	return http.ProcessData[request.ResetPassword](c)
+	return password.ProcessData[request.ResetPassword](c)
 }
 
 // ====================================================================
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"gfly/internal/domain/models"
+	"gfly/pkg/modules/auth"
+	"gfly/pkg/modules/auth/services"
+	"github.com/gflydev/core"
+	"github.com/gflydev/http"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewRevokeOtherSessionsApi As a constructor to create new API.
+func NewRevokeOtherSessionsApi() *RevokeOtherSessionsApi {
+	return &RevokeOtherSessionsApi{}
+}
+
+// RevokeOtherSessionsApi API struct.
+type RevokeOtherSessionsApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle method to sign out all other devices of the current user.
+// @Description Revoke all sessions (signed-in devices) of the current user except the current one.
+// @Summary revoke all other sessions of the current user
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} http.Error
+// @Failure 401 {object} http.Error
+// @Security ApiKeyAuth
+// @Router /auth/sessions [delete]
+func (h *RevokeOtherSessionsApi) Handle(c *core.Ctx) error {
+	user := c.GetData(http.UserKey).(models.User)
+	currentSessionID, _ := c.GetData(auth.SessionIDKey).(string)
+
+	if err := services.RevokeOtherSessions(user.ID, currentSessionID); err != nil {
+		return c.Error(http.Error{
+			Message: err.Error(),
+		})
+	}
+
+	return c.NoContent()
+}
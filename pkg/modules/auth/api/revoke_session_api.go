@@ -0,0 +1,71 @@
+package api
+
+import (
+	"gfly/internal/domain/models"
+	"gfly/pkg/modules/auth/services"
+	"github.com/gflydev/core"
+	"github.com/gflydev/http"
+	"github.com/google/uuid"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewRevokeSessionApi As a constructor to create new API.
+func NewRevokeSessionApi() *RevokeSessionApi {
+	return &RevokeSessionApi{}
+}
+
+// RevokeSessionApi API struct.
+type RevokeSessionApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ======================== Request Validation ========================
+// ====================================================================
+
+// Validate checks the session ID in path is a valid UUID.
+func (h *RevokeSessionApi) Validate(c *core.Ctx) error {
+	sessionID := c.PathVal("id")
+	if err := uuid.Validate(sessionID); err != nil {
+		return c.Error(http.Error{
+			Message: "Invalid session ID",
+		})
+	}
+
+	c.SetData(http.PathIDKey, sessionID)
+
+	return nil
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle method to sign out a device of the current user.
+// @Description Revoke a session (signed-in device) of the current user.
+// @Summary revoke a session of the current user
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 204
+// @Failure 400 {object} http.Error
+// @Failure 401 {object} http.Error
+// @Failure 404 {object} http.Error
+// @Security ApiKeyAuth
+// @Router /auth/sessions/{id} [delete]
+func (h *RevokeSessionApi) Handle(c *core.Ctx) error {
+	user := c.GetData(http.UserKey).(models.User)
+	sessionID := c.GetData(http.PathIDKey).(string)
+
+	if err := services.RevokeSession(user.ID, sessionID); err != nil {
+		return c.Error(http.Error{
+			Message: err.Error(),
+		}, core.StatusNotFound)
+	}
+
+	return c.NoContent()
+}
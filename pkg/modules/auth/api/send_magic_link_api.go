@@ -0,0 +1,65 @@
+package api
+
+import (
+	"gfly/pkg/modules/auth/request"
+	"gfly/pkg/modules/auth/services"
+	"github.com/gflydev/core"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/http"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewSendMagicLinkApi As a constructor to create new API.
+func NewSendMagicLinkApi() *SendMagicLinkApi {
+	return &SendMagicLinkApi{}
+}
+
+// SendMagicLinkApi API struct.
+type SendMagicLinkApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ======================== Request Validation ========================
+// ====================================================================
+
+// Validate data from request
+func (h *SendMagicLinkApi) Validate(c *core.Ctx) error {
+	return http.ProcessData[request.MagicLink](c)
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle method to email a passwordless sign-in link.
+// @Summary Send sign-in link
+// @Description Email a single-use, short-lived sign-in link. The response is the same for unknown addresses. Limited to one request per address within `AUTH_MAGIC_LINK_RESEND_SECONDS`.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param data body request.MagicLink true "MagicLink payload"
+// @Success 204
+// @Failure 400 {object} http.Error
+// @Failure 429 {object} http.Error
+// @Router /auth/magic-link [post]
+func (h *SendMagicLinkApi) Handle(c *core.Ctx) error {
+	requestData := c.GetData(http.RequestKey).(request.MagicLink)
+
+	if err := services.SendMagicLink(requestData.ToDto()); err != nil {
+		if errors.Is(err, services.ErrMagicLinkTooEarly) {
+			return c.Error(http.Error{
+				Message: err.Error(),
+			}, core.StatusTooManyRequests)
+		}
+
+		return c.Error(http.Error{
+			Message: err.Error(),
+		})
+	}
+
+	return c.NoContent()
+}
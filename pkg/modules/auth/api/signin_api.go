@@ -7,6 +7,7 @@ import (
 	"gfly/pkg/modules/auth/services"
 	"gfly/pkg/modules/auth/transformers"
 	"github.com/gflydev/core"
+	"github.com/gflydev/core/errors"
 	"github.com/gflydev/http"
 )
 
@@ -47,24 +48,61 @@ func (h *SignInApi) Validate(c *core.Ctx) error {
 // @Produce json
 // @Param data body request.SignIn true "Signin payload"
 // @Success 200 {object} response.SignIn
+// @Success 202 {object} response.TwoFactorChallenge
 // @Failure 400 {object} http.Error
+// @Failure 429 {object} http.Error
 // @Router /auth/signin [post]
 func (h *SignInApi) Handle(c *core.Ctx) error {
 	// Get valid data from context
 	requestData := c.GetData(http.RequestKey).(request.SignIn)
 
-	tokens, err := services.SignIn(requestData.ToDto())
+	if h.Type == auth.TypeWeb {
+		user, err := services.Authenticate(requestData.ToDto(), services.ExtractClient(c))
+		if err != nil {
+			return c.Error(http.Error{
+				Message: err.Error(),
+			}, signInErrorStatus(err))
+		}
+
+		// Second factor is required before opening the web session
+		if services.IsTwoFactorEnabled(user.ID) {
+			challenge, err := services.CreateTwoFactorChallenge(user.ID)
+			if err != nil {
+				return c.Error(http.Error{
+					Message: err.Error(),
+				})
+			}
+
+			return c.Status(core.StatusAccepted).JSON(transformers.ToTwoFactorChallengeResponse(&auth.Token{
+				Challenge: challenge,
+			}))
+		}
+
+		c.SetSession(auth.SessionUsername, user.Email)
+
+		return c.NoContent()
+	}
+
+	tokens, err := services.SignIn(requestData.ToDto(), services.ExtractClient(c))
 	if err != nil {
 		return c.Error(http.Error{
 			Message: err.Error(),
-		})
+		}, signInErrorStatus(err))
 	}
 
-	if h.Type == auth.TypeWeb {
-		c.SetSession(auth.SessionUsername, requestData.ToDto().Username)
-
-		return c.NoContent()
+	// Second factor is required => return the challenge token
+	if tokens.Challenge != "" {
+		return c.Status(core.StatusAccepted).JSON(transformers.ToTwoFactorChallengeResponse(tokens))
 	}
 
 	return c.JSON(transformers.ToSignInResponse(tokens))
 }
+
+// signInErrorStatus responds throttled and locked sign-in with `429 Too Many Requests`.
+func signInErrorStatus(err error) int {
+	if errors.Is(err, services.ErrTooManyAttempts) || errors.Is(err, services.ErrAccountLocked) {
+		return core.StatusTooManyRequests
+	}
+
+	return core.StatusBadRequest
+}
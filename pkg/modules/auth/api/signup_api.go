@@ -1,6 +1,7 @@
 package api
 
 import (
+	"gfly/pkg/modules/auth/password"
 	"gfly/pkg/modules/auth/request"
 	_ "gfly/pkg/modules/auth/response" // Used for Swagger documentation
 	"gfly/pkg/modules/auth/services"
@@ -26,7 +27,7 @@ func NewSignUpApi() *SignUp {
 // ====================================================================
 
 func (h *SignUp) Validate(c *core.Ctx) error {
-	return http.ProcessData[request.SignUp](c)
+	return password.ProcessData[request.SignUp](c)
 }
 
 // ====================================================================
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"gfly/pkg/modules/auth/services"
+	"github.com/gflydev/core"
+	"github.com/gflydev/http"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewStopImpersonationApi As a constructor to create new API.
+func NewStopImpersonationApi() *StopImpersonationApi {
+	return &StopImpersonationApi{}
+}
+
+// StopImpersonationApi API struct.
+type StopImpersonationApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle method to stop the impersonation of the current token.
+// @Description Stop the impersonation: the impersonation token is revoked and the stop is recorded.
+// @Summary stop impersonating a user
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} http.Error
+// @Failure 401 {object} http.Error
+// @Security ApiKeyAuth
+// @Router /auth/impersonation [delete]
+func (h *StopImpersonationApi) Handle(c *core.Ctx) error {
+	if err := services.StopImpersonation(services.ExtractToken(c), services.ExtractClient(c)); err != nil {
+		return c.Error(http.Error{
+			Message: err.Error(),
+		})
+	}
+
+	return c.NoContent()
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"gfly/internal/domain/models"
+	"gfly/pkg/modules/auth/request"
+	_ "gfly/pkg/modules/auth/response" // Used for Swagger documentation
+	"gfly/pkg/modules/auth/services"
+	"gfly/pkg/modules/auth/transformers"
+	"github.com/gflydev/core"
+	"github.com/gflydev/http"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewUpdateAccessTokenApi As a constructor to create new API.
+func NewUpdateAccessTokenApi() *UpdateAccessTokenApi {
+	return &UpdateAccessTokenApi{}
+}
+
+// UpdateAccessTokenApi API struct.
+type UpdateAccessTokenApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ======================== Request Validation ========================
+// ====================================================================
+
+// Validate data from request
+func (h *UpdateAccessTokenApi) Validate(c *core.Ctx) error {
+	if err := http.ProcessPathID(c); err != nil {
+		return err
+	}
+
+	return http.ProcessData[request.UpdateAccessToken](c)
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle method to rename a personal access token of the current user.
+// @Description Rename a personal access token of the current user. Scopes and expiry can not be changed.
+// @Summary rename a personal access token
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path int true "Token ID"
+// @Param data body request.UpdateAccessToken true "UpdateAccessToken payload"
+// @Success 200 {object} response.AccessToken
+// @Failure 400 {object} http.Error
+// @Failure 401 {object} http.Error
+// @Failure 404 {object} http.Error
+// @Security ApiKeyAuth
+// @Router /users/profile/tokens/{id} [put]
+func (h *UpdateAccessTokenApi) Handle(c *core.Ctx) error {
+	user := c.GetData(http.UserKey).(models.User)
+	tokenID := c.GetData(http.PathIDKey).(int)
+	requestData := c.GetData(http.RequestKey).(request.UpdateAccessToken)
+
+	accessToken, err := services.UpdateAccessToken(user.ID, tokenID, requestData.ToDto())
+	if err != nil {
+		return c.Error(http.Error{
+			Message: err.Error(),
+		}, core.StatusNotFound)
+	}
+
+	return c.JSON(transformers.ToAccessTokenResponse(*accessToken))
+}
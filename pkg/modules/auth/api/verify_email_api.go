@@ -0,0 +1,57 @@
+package api
+
+import (
+	"gfly/pkg/modules/auth/request"
+	"gfly/pkg/modules/auth/services"
+	"github.com/gflydev/core"
+	"github.com/gflydev/http"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// NewVerifyEmailApi As a constructor to create new API.
+func NewVerifyEmailApi() *VerifyEmailApi {
+	return &VerifyEmailApi{}
+}
+
+// VerifyEmailApi API struct.
+type VerifyEmailApi struct {
+	core.Api
+}
+
+// ====================================================================
+// ======================== Request Validation ========================
+// ====================================================================
+
+// Validate data from request
+func (h *VerifyEmailApi) Validate(c *core.Ctx) error {
+	return http.ProcessData[request.VerifyEmail](c)
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle method to verify the email address of a user.
+// @Summary Verify email
+// @Description Verify the email address with the data of the signed link sent at signup. Pending accounts are activated.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param data body request.VerifyEmail true "Verify email payload"
+// @Success 204
+// @Failure 400 {object} http.Error
+// @Router /auth/verify-email [post]
+func (h *VerifyEmailApi) Handle(c *core.Ctx) error {
+	requestData := c.GetData(http.RequestKey).(request.VerifyEmail)
+
+	if err := services.VerifyEmail(requestData.ToDto()); err != nil {
+		return c.Error(http.Error{
+			Message: err.Error(),
+		})
+	}
+
+	return c.NoContent()
+}
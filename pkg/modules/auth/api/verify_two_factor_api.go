@@ -0,0 +1,78 @@
+package api
+
+import (
+	"gfly/pkg/modules/auth"
+	"gfly/pkg/modules/auth/request"
+	_ "gfly/pkg/modules/auth/response" // Used for Swagger documentation
+	"gfly/pkg/modules/auth/services"
+	"gfly/pkg/modules/auth/transformers"
+	"github.com/gflydev/core"
+	"github.com/gflydev/http"
+)
+
+// ====================================================================
+// ======================== Controller Creation =======================
+// ====================================================================
+
+// VerifyTwoFactorApi API struct.
+type VerifyTwoFactorApi struct {
+	Type auth.Type
+	core.Api
+}
+
+// NewVerifyTwoFactorApi is a constructor
+func NewVerifyTwoFactorApi(authType auth.Type) *VerifyTwoFactorApi {
+	return &VerifyTwoFactorApi{
+		Type: authType,
+	}
+}
+
+// ====================================================================
+// ======================== Request Validation ========================
+// ====================================================================
+
+// Validate data from request
+func (h *VerifyTwoFactorApi) Validate(c *core.Ctx) error {
+	return http.ProcessData[request.VerifyTwoFactor](c)
+}
+
+// ====================================================================
+// ========================= Request Handling =========================
+// ====================================================================
+
+// Handle func exchanges a sign-in challenge and a two-factor code for access and refresh tokens
+// @Description Second step of the sign-in for users with two-factor authentication. Exchange the challenge token and a TOTP (or recovery) code for access and refresh token.
+// @Summary verify two-factor sign-in
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param data body request.VerifyTwoFactor true "VerifyTwoFactor payload"
+// @Success 200 {object} response.SignIn
+// @Failure 400 {object} http.Error
+// @Failure 401 {object} http.Error
+// @Router /auth/2fa/verify [post]
+func (h *VerifyTwoFactorApi) Handle(c *core.Ctx) error {
+	requestData := c.GetData(http.RequestKey).(request.VerifyTwoFactor)
+
+	user, err := services.VerifyTwoFactorChallenge(requestData.ToDto().Challenge, requestData.ToDto().Code)
+	if err != nil {
+		return c.Error(http.Error{
+			Message: err.Error(),
+		}, core.StatusUnauthorized)
+	}
+
+	if h.Type == auth.TypeWeb {
+		c.SetSession(auth.SessionUsername, user.Email)
+
+		return c.NoContent()
+	}
+
+	tokens, err := services.IssueTokens(user, services.ExtractClient(c))
+	if err != nil {
+		return c.Error(http.Error{
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(transformers.ToSignInResponse(tokens))
+}
@@ -0,0 +1,13 @@
+package dto
+
+// CreateAccessToken struct to describe a new personal access token.
+type CreateAccessToken struct {
+	Name          string   `json:"name" example:"CI pipeline" validate:"required,max=255" doc:"The name of the token, up to 255 characters and is required"`
+	Scopes        []string `json:"scopes" example:"role:admin" validate:"omitempty,dive,required,max=100" doc:"Scopes of the token, must be granted to the current user. All scopes of the current user by default"`
+	ExpiresInDays int      `json:"expires_in_days" example:"90" validate:"omitempty,gte=1,lte=3650" doc:"Number of days until the token expires. The token never expires when omitted"`
+}
+
+// UpdateAccessToken struct to rename a personal access token.
+type UpdateAccessToken struct {
+	Name string `json:"name" example:"Deploy bot" validate:"required,max=255" doc:"The new name of the token, up to 255 characters and is required"`
+}
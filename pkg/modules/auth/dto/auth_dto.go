@@ -3,13 +3,18 @@ package dto
 // SignUp struct to describe register a new user.
 type SignUp struct {
 	Email    string `json:"email" example:"john@jivecode.com" validate:"required,email,max=255" doc:"The email address of the user, must be a valid email address and is required"`
-	Password string `json:"password" example:"M1PassW@s" validate:"required,max=255" doc:"The password for the new user account, up to 255 characters and is required"` // #nosec G117 -- Legitimate password input field for user registration
+	Password string `json:"password" example:"M1PassW@s" validate:"required,max=255,password" doc:"The password for the new user account, up to 255 characters, must match the password policy and is required"` // #nosec G117 -- Legitimate password input field for user registration
 	Fullname string `json:"fullname" example:"John Doe" validate:"required,max=255" doc:"The full name of the user, up to 255 characters and is required"`
 	Phone    string `json:"phone" example:"0989831911" validate:"required,max=20" doc:"The phone number of the user, up to 20 characters and is required"`
 	Avatar   string `json:"avatar" example:"https://i.pravatar.cc/32" validate:"max=255" doc:"The avatar URL for the user, up to 255 characters and optional"`
 	Status   string `json:"status" example:"pending" validate:"omitempty" doc:"The status of the user, optional field"`
 }
 
+// PersonalData returns the data which must not be part of the password.
+func (d SignUp) PersonalData() []string {
+	return []string{d.Email, d.Fullname}
+}
+
 // SignIn struct to describe sign in user
 type SignIn struct {
 	Username string `json:"username" example:"admin@gfly.dev" validate:"required,email,max=255" doc:"The email address or username used for signing in, must be a valid email and is required"`
@@ -6,7 +6,8 @@ type ForgotPassword struct {
 }
 
 // ResetPassword struct to describe reset password.
+// The request has no personal data, services.ChangePassword checks the email and name of the user of the token.
 type ResetPassword struct {
-	Password string `json:"password" example:"M1PassW@s" validate:"required,gte=6"` // #nosec G117 -- Legitimate password input field for password reset
+	Password string `json:"password" example:"M1PassW@s" validate:"required,max=255,password"` // #nosec G117 -- Legitimate password input field for password reset
 	Token    string `json:"token" example:"293r823or832eioj2eo9282o423" validate:"required,lte=255"`
 }
@@ -0,0 +1,6 @@
+package dto
+
+// Impersonate struct to describe a request of an admin to act as another user.
+type Impersonate struct {
+	Reason string `json:"reason" example:"Reproduce ticket #1234" validate:"required,max=255" doc:"Why the admin acts as the user (audit trail)"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+// MagicLink struct to describe a request of a passwordless sign-in link.
+type MagicLink struct {
+	Email string `json:"email" example:"john@jivecode.com" validate:"required,email,max=255" doc:"The email address of the account"`
+}
+
+// ConsumeMagicLink struct to describe the data of a signed sign-in link.
+type ConsumeMagicLink struct {
+	ID        int    `json:"id" example:"1" validate:"required,gt=0" doc:"The user ID of the sign-in link"`
+	Expires   int64  `json:"expires" example:"1747914602" validate:"required,gt=0" doc:"The expiry (Unix time) of the sign-in link"`
+	Token     string `json:"token" example:"d1a4216a226cbf75eaefc9107c2c64b6b2c0f18cd8634e3a6f495146c38e1324" validate:"required,max=255" doc:"The single-use token of the sign-in link"`
+	Signature string `json:"signature" example:"9b1e2f0c6f3c4d6a8e7f5b4a3c2d1e0f9b1e2f0c6f3c4d6a8e7f5b4a3c2d1e0f" validate:"required,max=255" doc:"The signature of the sign-in link"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+// OAuthCallback struct to describe the redirect of an identity provider back to the callback.
+type OAuthCallback struct {
+	Code  string `json:"code" example:"SplxlOBeZQQYbYS6WxSbIA" validate:"required,max=2048" doc:"The authorization code"`
+	State string `json:"state" example:"af0ifjsldkj" validate:"required,max=255" doc:"The state returned by the authorization redirect"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+// TwoFactorCode struct to describe a TOTP code (or a recovery code).
+type TwoFactorCode struct {
+	Code string `json:"code" example:"123456" validate:"required,max=16" doc:"The 6-digit code of the authenticator app, or a recovery code"`
+}
+
+// VerifyTwoFactor struct to describe the second step of a two-factor sign-in.
+type VerifyTwoFactor struct {
+	Challenge string `json:"challenge" example:"c5a1e0b3f6d24e8f9a7b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f" validate:"required,max=255" doc:"The challenge token returned by sign-in"`
+	Code      string `json:"code" example:"123456" validate:"required,max=16" doc:"The 6-digit code of the authenticator app, or a recovery code"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+// VerifyEmail struct to describe the data of a signed email verification link.
+type VerifyEmail struct {
+	ID        int    `json:"id" example:"1" validate:"required,gt=0" doc:"The user ID of the verification link"`
+	Expires   int64  `json:"expires" example:"1747914602" validate:"required,gt=0" doc:"The expiry (Unix time) of the verification link"`
+	Signature string `json:"signature" example:"9b1e2f0c6f3c4d6a8e7f5b4a3c2d1e0f9b1e2f0c6f3c4d6a8e7f5b4a3c2d1e0f" validate:"required,max=255" doc:"The signature of the verification link"`
+}
+
+// ResendVerifyEmail struct to describe a request to send the verification link again.
+type ResendVerifyEmail struct {
+	Email string `json:"email" example:"john@jivecode.com" validate:"required,email,max=255" doc:"The email address to verify"`
+}
@@ -0,0 +1,93 @@
+package events
+
+import "time"
+
+// ---------------------------------------------------------------
+//                      Event name constants
+// ---------------------------------------------------------------
+
+const (
+	// EventRefreshTokenReused fires when an already rotated refresh token is presented again.
+	EventRefreshTokenReused = "auth.refresh_token_reused"
+
+	// EventAccountLocked fires when an account is locked after too many failed sign-in attempts.
+	EventAccountLocked = "auth.account_locked"
+
+	// EventImpersonationStarted fires when an admin starts to act as another user.
+	EventImpersonationStarted = "auth.impersonation_started"
+
+	// EventImpersonationStopped fires when an admin stops acting as another user.
+	EventImpersonationStopped = "auth.impersonation_stopped"
+)
+
+// ---------------------------------------------------------------
+//                        Auth Events
+// ---------------------------------------------------------------
+
+// RefreshTokenReused is dispatched after a replayed refresh token was detected
+// and its whole token family (session) has been revoked.
+type RefreshTokenReused struct {
+	// UserID is the ID of the session owner.
+	UserID int
+	// SessionID is the ID of the revoked session (token family).
+	SessionID string
+	// UserAgent is the user agent of the device which replayed the token.
+	UserAgent string
+	// IPAddress is the IP address of the device which replayed the token.
+	IPAddress string
+}
+
+// EventName returns the unique event identifier.
+func (e RefreshTokenReused) EventName() string { return EventRefreshTokenReused }
+
+// AccountLocked is dispatched after an account has been locked temporarily
+// because of too many failed sign-in attempts.
+type AccountLocked struct {
+	// UserID is the ID of the locked user.
+	UserID int
+	// Email is the email address of the locked user.
+	Email string
+	// Fullname is the name of the locked user.
+	Fullname string
+	// IPAddress is the IP address of the last failed attempt.
+	IPAddress string
+	// UnlockAt is the time the account is unlocked automatically.
+	UnlockAt time.Time
+}
+
+// EventName returns the unique event identifier.
+func (e AccountLocked) EventName() string { return EventAccountLocked }
+
+// ImpersonationStarted is dispatched after an admin received an impersonation token.
+type ImpersonationStarted struct {
+	// ActorID is the ID of the impersonating admin.
+	ActorID int
+	// UserID is the ID of the impersonated user.
+	UserID int
+	// SessionID is the `sid` claim of the impersonation token.
+	SessionID string
+	// Reason is the reason given by the admin.
+	Reason string
+	// IPAddress is the IP address of the admin.
+	IPAddress string
+	// ExpiresAt is the time the impersonation token expires.
+	ExpiresAt time.Time
+}
+
+// EventName returns the unique event identifier.
+func (e ImpersonationStarted) EventName() string { return EventImpersonationStarted }
+
+// ImpersonationStopped is dispatched after an impersonation was stopped.
+type ImpersonationStopped struct {
+	// ActorID is the ID of the impersonating admin.
+	ActorID int
+	// UserID is the ID of the impersonated user.
+	UserID int
+	// SessionID is the `sid` claim of the impersonation token.
+	SessionID string
+	// IPAddress is the IP address of the request which stopped the impersonation.
+	IPAddress string
+}
+
+// EventName returns the unique event identifier.
+func (e ImpersonationStopped) EventName() string { return EventImpersonationStopped }
@@ -0,0 +1,26 @@
+package events
+
+import (
+	"github.com/gflydev/event"
+)
+
+// ---------------------------------------------------------------
+//                        Auth Event Subscriber
+// ---------------------------------------------------------------
+
+// AuthSubscriber groups all listeners for auth-module events.
+type AuthSubscriber struct{}
+
+// Subscribe AuthSubscriber registers auth event listeners on the given dispatcher.
+//
+// Registered mappings:
+//   - auth.refresh_token_reused → LogSecurityEventListener
+//   - auth.account_locked       → SendAccountLockedEmailListener
+//   - auth.impersonation_started → LogImpersonationStartedListener
+//   - auth.impersonation_stopped → LogImpersonationStoppedListener
+func (s *AuthSubscriber) Subscribe(d *event.Dispatcher) {
+	event.ListenOn[RefreshTokenReused](d, &LogSecurityEventListener{})
+	event.ListenOn[AccountLocked](d, &SendAccountLockedEmailListener{})
+	event.ListenOn[ImpersonationStarted](d, &LogImpersonationStartedListener{})
+	event.ListenOn[ImpersonationStopped](d, &LogImpersonationStoppedListener{})
+}
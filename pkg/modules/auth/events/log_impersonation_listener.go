@@ -0,0 +1,40 @@
+package events
+
+import (
+	"github.com/gflydev/core/log"
+)
+
+// LogImpersonationStartedListener writes the start of an impersonation to the log.
+// The impersonation is persisted in table `impersonations` as well.
+type LogImpersonationStartedListener struct{}
+
+// Handle processes the ImpersonationStarted event.
+//
+// Parameters:
+//   - event (events.ImpersonationStarted): The concrete impersonation-started event.
+//
+// Returns:
+//   - error: Non-nil if the listener encounters a critical failure.
+func (l *LogImpersonationStartedListener) Handle(event ImpersonationStarted) error {
+	log.Warnf("[Security] Impersonation started: admin %d acts as user %d, session %s until %s (ip %s, reason %q)",
+		event.ActorID, event.UserID, event.SessionID, event.ExpiresAt.Format("2006-01-02 15:04:05"), event.IPAddress, event.Reason)
+
+	return nil
+}
+
+// LogImpersonationStoppedListener writes the stop of an impersonation to the log.
+type LogImpersonationStoppedListener struct{}
+
+// Handle processes the ImpersonationStopped event.
+//
+// Parameters:
+//   - event (events.ImpersonationStopped): The concrete impersonation-stopped event.
+//
+// Returns:
+//   - error: Non-nil if the listener encounters a critical failure.
+func (l *LogImpersonationStoppedListener) Handle(event ImpersonationStopped) error {
+	log.Warnf("[Security] Impersonation stopped: admin %d acted as user %d, session %s (ip %s)",
+		event.ActorID, event.UserID, event.SessionID, event.IPAddress)
+
+	return nil
+}
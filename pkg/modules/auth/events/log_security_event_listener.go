@@ -0,0 +1,23 @@
+package events
+
+import (
+	"github.com/gflydev/core/log"
+)
+
+// LogSecurityEventListener writes security related auth events to the log
+// so that they can be collected by the monitoring stack.
+type LogSecurityEventListener struct{}
+
+// Handle processes the RefreshTokenReused event.
+//
+// Parameters:
+//   - event (events.RefreshTokenReused): The concrete refresh-token-reused event.
+//
+// Returns:
+//   - error: Non-nil if the listener encounters a critical failure.
+func (l *LogSecurityEventListener) Handle(event RefreshTokenReused) error {
+	log.Warnf("[Security] Refresh token reused: user %d, session %s revoked (ip %s, agent %q)",
+		event.UserID, event.SessionID, event.IPAddress, event.UserAgent)
+
+	return nil
+}
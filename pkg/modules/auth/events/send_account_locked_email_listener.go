@@ -0,0 +1,33 @@
+package events
+
+import (
+	"gfly/pkg/modules/auth/notifications"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/notification"
+)
+
+// SendAccountLockedEmailListener informs the user that the account was locked
+// after too many failed sign-in attempts.
+type SendAccountLockedEmailListener struct{}
+
+// Handle processes the AccountLocked event.
+//
+// Parameters:
+//   - event (events.AccountLocked): The concrete account-locked event.
+//
+// Returns:
+//   - error: Non-nil if the listener encounters a critical failure.
+func (l *SendAccountLockedEmailListener) Handle(event AccountLocked) error {
+	log.Warnf("[Security] Account locked: user %d after too many failed sign-in attempts (ip %s) until %s",
+		event.UserID, event.IPAddress, event.UnlockAt.Format("2006-01-02 15:04:05"))
+
+	if err := notification.Send(notifications.AccountLocked{
+		Email:    event.Email,
+		Name:     event.Fullname,
+		UnlockAt: event.UnlockAt,
+	}); err != nil {
+		log.Errorf("Send account locked email error '%v'", err)
+	}
+
+	return nil
+}
@@ -5,6 +5,22 @@ type Type string
 const (
 	SessionUsername = "username"
 
+	// SessionIDKey context key storing the ID of the current device session (JWT `sid` claim).
+	SessionIDKey = "__session_id__"
+
+	// ScopesKey context key storing the scopes granted to the access token (JWT `scope` claim).
+	ScopesKey = "__scopes__"
+
+	// ActorKey context key storing the impersonating admin (models.User, JWT `act` claim).
+	// It is not set for regular tokens.
+	ActorKey = "__actor__"
+
+	// AccessTokenPrefix prefix of personal access tokens. Ex: `gfly_pat_3f1c...`
+	AccessTokenPrefix = "gfly_pat_"
+
+	// ScopeRolePrefix prefix of the scopes which are granted by user roles. Ex: `role:admin`
+	ScopeRolePrefix = "role:"
+
 	// ========== Auth Type ==========
 
 	TypeAPI Type = "api"
@@ -18,10 +34,88 @@ const (
 	TtlMinutes     = "JWT_TTL_MINUTES"
 	SecretKey      = "JWT_SECRET_KEY"
 	RefreshKey     = "JWT_REFRESH_KEY"
+	Algorithm      = "JWT_ALGORITHM"
+	SigningKeys    = "JWT_SIGNING_KEYS"
+	SigningKid     = "JWT_SIGNING_KID"
+	Issuer         = "JWT_ISSUER"
+	Audience       = "JWT_AUDIENCE"
+	Leeway         = "JWT_LEEWAY_SECONDS"
+
+	// ========== Signed links configurations ==========
+
+	AppKey = "APP_KEY" // Secret of the signed links (email verification, magic link). Required.
+
+	// ========== Two-factor authentication configurations ==========
+
+	TwoFactorIssuer       = "AUTH_2FA_ISSUER"
+	TwoFactorChallengeTtl = "AUTH_2FA_CHALLENGE_TTL_MINUTES"
+
+	// ========== Password reset configurations ==========
+
+	ResetPasswordUri = "AUTH_RESET_PASSWORD_URI"
+	ResetPasswordTtl = "AUTH_RESET_PASSWORD_TTL_MINUTES"
+
+	// ========== Password policy configurations ==========
+
+	PasswordMinLength     = "AUTH_PASSWORD_MIN_LENGTH"
+	PasswordRequireUpper  = "AUTH_PASSWORD_REQUIRE_UPPER"
+	PasswordRequireLower  = "AUTH_PASSWORD_REQUIRE_LOWER"
+	PasswordRequireDigit  = "AUTH_PASSWORD_REQUIRE_DIGIT"
+	PasswordRequireSymbol = "AUTH_PASSWORD_REQUIRE_SYMBOL"
+	PasswordDenylist      = "AUTH_PASSWORD_DENYLIST"
+	PasswordHistory       = "AUTH_PASSWORD_HISTORY"
+
+	// ========== Email verification configurations ==========
+
+	VerifyEmailUri       = "AUTH_VERIFY_EMAIL_URI"
+	VerifyEmailTtl       = "AUTH_VERIFY_EMAIL_TTL_MINUTES"
+	VerifyEmailResend    = "AUTH_VERIFY_EMAIL_RESEND_SECONDS"
+	RequireVerifiedEmail = "AUTH_REQUIRE_VERIFIED_EMAIL"
+
+	// ========== Sign-in throttle configurations ==========
+
+	ThrottleWindow        = "AUTH_THROTTLE_WINDOW_MINUTES"
+	ThrottleMaxAttempts   = "AUTH_THROTTLE_MAX_ATTEMPTS"
+	ThrottleIPMaxAttempts = "AUTH_THROTTLE_IP_MAX_ATTEMPTS"
+	ThrottleDelaySeconds  = "AUTH_THROTTLE_DELAY_SECONDS"
+	LockoutMinutes        = "AUTH_LOCKOUT_MINUTES"
+
+	// ========== Impersonation configurations ==========
+
+	ImpersonationTtl = "AUTH_IMPERSONATION_TTL_MINUTES"
+
+	// ========== Magic link sign-in configurations ==========
+
+	MagicLinkUri    = "AUTH_MAGIC_LINK_URI"
+	MagicLinkTtl    = "AUTH_MAGIC_LINK_TTL_MINUTES"
+	MagicLinkResend = "AUTH_MAGIC_LINK_RESEND_SECONDS"
+
+	// ========== OAuth2 / OpenID Connect configurations ==========
+
+	OAuthStateTtl    = "AUTH_OAUTH_STATE_TTL_MINUTES"
+	OIDCName         = "AUTH_OIDC_NAME"
+	OIDCDiscoveryURL = "AUTH_OIDC_DISCOVERY_URL"
+	OIDCClientID     = "AUTH_OIDC_CLIENT_ID"
+	OIDCClientSecret = "AUTH_OIDC_CLIENT_SECRET"
+	OIDCRedirectURL  = "AUTH_OIDC_REDIRECT_URL"
+	OIDCScopes       = "AUTH_OIDC_SCOPES"
 )
 
 // Token struct to describe tokens object.
+// Challenge is set instead of Access/Refresh when the sign-in needs a second factor.
 type Token struct {
-	Access  string
-	Refresh string
+	Access    string
+	Refresh   string
+	Challenge string
+}
+
+// Client struct to describe the device which signs in.
+type Client struct {
+	UserAgent string
+	IPAddress string
+}
+
+// RoleScope returns the scope granted by the given role slug.
+func RoleScope(roleSlug string) string {
+	return ScopeRolePrefix + roleSlug
 }
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"gfly/pkg/modules/auth"
+	"github.com/gflydev/core"
+	"github.com/gflydev/http"
+)
+
+// BlockImpersonation is a middleware that refuses sensitive endpoints (password
+// change, token creation, ...) to impersonation tokens. Must run after JWTAuth.
+//
+// Use:
+//
+//	profileRouter.POST("/tokens", profileRouter.Apply(middleware.BlockImpersonation)(api.NewCreateAccessTokenApi()))
+//
+// @Throws response.Error with code 403 when the request is made on behalf of another user
+func BlockImpersonation(c *core.Ctx) error {
+	if c.GetData(auth.ActorKey) != nil {
+		return c.Error(http.Error{
+			Message: "Not allowed while impersonating a user",
+		}, core.StatusForbidden)
+	}
+
+	return nil
+}
@@ -2,16 +2,22 @@ package middleware
 
 import (
 	"gfly/internal/domain/models"
+	"gfly/internal/domain/models/types"
+	"gfly/pkg/modules/auth"
 	"gfly/pkg/modules/auth/services"
 	"github.com/gflydev/core"
+	"github.com/gflydev/core/errors"
 	"github.com/gflydev/core/log"
 	mb "github.com/gflydev/db"
 	"github.com/gflydev/http"
+	"github.com/golang-jwt/jwt/v5"
 	"slices"
-	"time"
 )
 
 // JWTAuth an HTTP middleware that process login via JWT token.
+// Personal access tokens (`Authorization: Bearer gfly_pat_...`) are accepted as well.
+// For impersonation tokens, `http.UserKey` is the impersonated (effective) user and
+// `auth.ActorKey` is the admin (see BlockImpersonation).
 //
 // Use:
 //
@@ -33,7 +39,29 @@ func JWTAuth(excludes ...string) core.MiddlewareHandler {
 		c.Status(core.StatusUnauthorized)
 
 		jwtToken := services.ExtractToken(c)
-		isBlocked, err := services.IsBlockedToken(jwtToken)
+
+		// Personal access token `gfly_pat_...` (machine clients)
+		if services.IsAccessToken(jwtToken) {
+			return accessTokenAuth(c, jwtToken)
+		}
+
+		// Get claims from JWT (signature, exp, nbf, iss and aud are validated).
+		claims, err := services.ExtractTokenMetadata(jwtToken)
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return c.Error(http.Error{
+				Message: "JWT token expired",
+			}, core.StatusUnauthorized)
+		}
+
+		if err != nil {
+			log.Errorf("Parse JWT error '%v'", err)
+
+			return c.Error(http.Error{
+				Message: "Invalid JWT token",
+			}, core.StatusUnauthorized)
+		}
+
+		isBlocked, err := services.IsBlockedToken(claims.TokenID)
 		if err != nil {
 			log.Errorf("Check JWT error '%v'", err)
 
@@ -48,37 +76,64 @@ func JWTAuth(excludes ...string) core.MiddlewareHandler {
 			}, core.StatusUnauthorized)
 		}
 
-		// Get claims from JWT.
-		claims, err := services.ExtractTokenMetadata(jwtToken)
-		if err != nil {
-			log.Errorf("Parse JWT error '%v'", err)
-
+		// Check the device session was not revoked.
+		if claims.ActorID == 0 && !services.IsActiveSession(claims.UserID, claims.SessionID) {
 			return c.Error(http.Error{
-				Message: "Parse JWT error",
+				Message: "Session was revoked or expired",
 			}, core.StatusUnauthorized)
 		}
 
-		if claims.Expires < time.Now().Unix() {
-			log.Errorf("JWT token expired '%v'", jwtToken)
-
+		// Impersonation token => check the impersonation was not stopped.
+		if claims.ActorID != 0 && !services.IsActiveImpersonation(claims) {
 			return c.Error(http.Error{
-				Message: "JWT token expired",
+				Message: "Impersonation was stopped or expired",
 			}, core.StatusUnauthorized)
 		}
 
 		// Get user by ID.
 		user, err := mb.GetModelByID[models.User](claims.UserID)
-		if err != nil || user == nil {
-			log.Errorf("User not found '%v'", err)
+		if err != nil || user == nil || user.DeletedAt.Valid {
+			log.Errorf("User %d not found '%v'", claims.UserID, err)
 
 			return c.Error(http.Error{
 				Message: "User not found",
 			}, core.StatusUnauthorized)
 		}
 
+		if claims.ActorID != 0 {
+			// The impersonating admin must still be allowed to sign in.
+			actor, err := mb.GetModelByID[models.User](claims.ActorID)
+			if err != nil || actor == nil || actor.DeletedAt.Valid || actor.Status != types.UserStatusActive {
+				return c.Error(http.Error{
+					Message: "Impersonation was stopped or expired",
+				}, core.StatusUnauthorized)
+			}
+
+			c.SetData(auth.ActorKey, *actor)
+		}
+
 		c.Status(core.StatusOK)
 		c.SetData(http.UserKey, *user)
+		c.SetData(auth.SessionIDKey, claims.SessionID)
+		c.SetData(auth.ScopesKey, claims.Scopes)
 
 		return nil
 	}
 }
+
+// accessTokenAuth authenticates the request with a personal access token.
+// There is no device session, so `auth.SessionIDKey` is not set.
+func accessTokenAuth(c *core.Ctx, accessToken string) error {
+	user, scopes, err := services.AuthenticateAccessToken(accessToken)
+	if err != nil {
+		return c.Error(http.Error{
+			Message: err.Error(),
+		}, core.StatusUnauthorized)
+	}
+
+	c.Status(core.StatusOK)
+	c.SetData(http.UserKey, *user)
+	c.SetData(auth.ScopesKey, scopes)
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"gfly/pkg/modules/auth"
+	"gfly/pkg/modules/auth/services"
+	"github.com/gflydev/core"
+	"github.com/gflydev/http"
+)
+
+// RequireScopes is a middleware that verifies the access token was granted all given scopes.
+// The scopes come from the JWT `scope` claim (stored in context by JWTAuth), so no
+// database query is made. Must run after JWTAuth.
+//
+// Use:
+//
+//	userRouter.Use(middleware.RequireScopes(auth.RoleScope("admin")))
+//
+// @Throws response.Error with code 401 when user is not authenticated
+// @Throws response.Error with code 403 when the token lacks a required scope
+func RequireScopes(scopes ...string) core.MiddlewareHandler {
+	return func(c *core.Ctx) error {
+		granted, ok := c.GetData(auth.ScopesKey).([]string)
+		if !ok {
+			return c.Error(http.Error{
+				Message: "Unauthorized",
+			}, core.StatusUnauthorized)
+		}
+
+		if !services.HasScopes(granted, scopes...) {
+			return c.Error(http.Error{
+				Message: "Permission denied",
+			}, core.StatusForbidden)
+		}
+
+		c.Status(core.StatusOK)
+
+		return nil
+	}
+}
@@ -26,6 +26,9 @@ func processSession(c *core.Ctx) (err error) {
 
 		// Put logged-in user to request data pool.
 		user := repository.Pool.GetUserByEmail(username.(string))
+		if user == nil || user.DeletedAt.Valid {
+			try.Throw("User not found")
+		}
 		c.SetData(http.UserKey, *user)
 	}).Catch(func(e try.E) {
 		err = errors.New("%v", e)
@@ -0,0 +1,32 @@
+package notifications
+
+import (
+	"github.com/gflydev/core"
+	notifyMail "github.com/gflydev/notification/mail"
+	view "github.com/gflydev/view/pongo"
+	"time"
+)
+
+type AccountLocked struct {
+	Email    string
+	Name     string
+	UnlockAt time.Time
+}
+
+func (n AccountLocked) ToEmail() notifyMail.Data {
+	body := view.New().Parse("mails/account_locked", core.Data{
+		// For primary template
+		"title":    "Account locked",
+		"base_url": core.AppURL,
+		"email":    n.Email,
+		// For account_locked template
+		"user_name": n.Name,
+		"unlock_at": n.UnlockAt.Format("2006-01-02 15:04:05 MST"),
+	})
+
+	return notifyMail.Data{
+		To:      n.Email,
+		Subject: "Account locked",
+		Body:    body,
+	}
+}
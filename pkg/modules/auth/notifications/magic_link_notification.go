@@ -0,0 +1,33 @@
+package notifications
+
+import (
+	"github.com/gflydev/core"
+	notifyMail "github.com/gflydev/notification/mail"
+	view "github.com/gflydev/view/pongo"
+)
+
+type MagicLink struct {
+	Email      string
+	Name       string
+	URL        string
+	TtlMinutes int
+}
+
+func (n MagicLink) ToEmail() notifyMail.Data {
+	body := view.New().Parse("mails/magic_link", core.Data{
+		// For primary template
+		"title":    "Sign in link",
+		"base_url": core.AppURL,
+		"email":    n.Email,
+		// For magic_link template
+		"user_name":   n.Name,
+		"signin_url":  n.URL,
+		"ttl_minutes": n.TtlMinutes,
+	})
+
+	return notifyMail.Data{
+		To:      n.Email,
+		Subject: "Sign in link",
+		Body:    body,
+	}
+}
@@ -1,6 +1,7 @@
 package notifications
 
 import (
+	"gfly/pkg/modules/auth"
 	"github.com/gflydev/core"
 	"github.com/gflydev/core/utils"
 	notifyMail "github.com/gflydev/notification/mail"
@@ -15,7 +16,7 @@ type ResetPassword struct {
 }
 
 func (n ResetPassword) ToEmail() notifyMail.Data {
-	resetPasswordURI := utils.Getenv("AUTH_RESET_PASSWORD_URI", "/reset-password")
+	resetPasswordURI := utils.Getenv(auth.ResetPasswordUri, "/reset-password")
 
 	body := view.New().Parse("mails/forgot_password", core.Data{
 		// For primary template
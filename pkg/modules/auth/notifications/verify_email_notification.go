@@ -0,0 +1,31 @@
+package notifications
+
+import (
+	"github.com/gflydev/core"
+	notifyMail "github.com/gflydev/notification/mail"
+	view "github.com/gflydev/view/pongo"
+)
+
+type VerifyEmail struct {
+	Email string
+	Name  string
+	URL   string
+}
+
+func (n VerifyEmail) ToEmail() notifyMail.Data {
+	body := view.New().Parse("mails/verify_email", core.Data{
+		// For primary template
+		"title":    "Verify email address",
+		"base_url": core.AppURL,
+		"email":    n.Email,
+		// For verify_email template
+		"user_name":  n.Name,
+		"verify_url": n.URL,
+	})
+
+	return notifyMail.Data{
+		To:      n.Email,
+		Subject: "Verify email address",
+		Body:    body,
+	}
+}
@@ -0,0 +1,394 @@
+package oauth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/core/log"
+	"github.com/golang-jwt/jwt/v5"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// OIDCConfig struct to describe the configuration of a generic OpenID Connect provider.
+type OIDCConfig struct {
+	Name         string
+	DiscoveryURL string // Ex: `https://accounts.google.com/.well-known/openid-configuration`
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	HTTPClient   *http.Client // `http.Client` with 10 seconds timeout by default
+}
+
+// OIDCProvider is a generic OpenID Connect provider. Endpoints and signing
+// keys are resolved from the discovery document.
+type OIDCProvider struct {
+	config OIDCConfig
+
+	mu        sync.Mutex
+	discovery *oidcDiscovery
+	keys      map[string]any // Public keys of the JWKS by `kid`
+}
+
+// oidcDiscovery struct to describe the fields used of the discovery document.
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksURI               string `json:"jwks_uri"`
+}
+
+// oidcTokenResponse struct to describe the response of the token endpoint.
+type oidcTokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// oidcClaims struct to describe the claims used of an ID token.
+type oidcClaims struct {
+	Nonce         string `json:"nonce"`
+	Email         string `json:"email"`
+	EmailVerified any    `json:"email_verified"` // Some providers send a string "true"
+	Name          string `json:"name"`
+	Picture       string `json:"picture"`
+	jwt.RegisteredClaims
+}
+
+// jsonWebKey struct to describe a key of a JWKS (RSA and EC keys only).
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// NewOIDCProvider creates a generic OpenID Connect provider.
+// The discovery document is fetched on first use.
+func NewOIDCProvider(config OIDCConfig) *OIDCProvider {
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	if len(config.Scopes) == 0 {
+		config.Scopes = []string{"openid", "email", "profile"}
+	}
+
+	return &OIDCProvider{
+		config: config,
+	}
+}
+
+// ====================================================================
+// ========================= Main functions ===========================
+// ====================================================================
+
+// Name returns the provider name.
+func (p *OIDCProvider) Name() string {
+	return p.config.Name
+}
+
+// AuthCodeURL returns the URL of the authorization endpoint with the PKCE (S256) parameters.
+func (p *OIDCProvider) AuthCodeURL(state, codeChallenge, nonce string) (string, error) {
+	discovery, err := p.loadDiscovery()
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.config.ClientID},
+		"redirect_uri":          {p.config.RedirectURL},
+		"scope":                 {strings.Join(p.config.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+
+	separator := "?"
+	if strings.Contains(discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return discovery.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+// Exchange trades the authorization code for tokens at the token endpoint then
+// verifies the ID token.
+//
+// Flow:
+// 1. Posts the code and the PKCE code verifier with the client credentials
+// 2. Verifies the signature of the ID token against the JWKS of the provider
+// 3. Verifies `iss`, `aud`, `exp` and the nonce
+// 4. Returns the identity found in the claims
+func (p *OIDCProvider) Exchange(code, codeVerifier, nonce string) (*Identity, error) {
+	discovery, err := p.loadDiscovery()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.config.HTTPClient.PostForm(discovery.TokenEndpoint, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.config.RedirectURL},
+		"client_id":     {p.config.ClientID},
+		"client_secret": {p.config.ClientSecret},
+		"code_verifier": {codeVerifier},
+	})
+	if err != nil {
+		log.Errorf("OIDC token request error '%v'", err)
+
+		return nil, errors.New("Identity provider is not available")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var tokenResponse oidcTokenResponse
+	if err = json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&tokenResponse); err != nil {
+		return nil, errors.New("Invalid response of identity provider")
+	}
+
+	if resp.StatusCode != http.StatusOK || tokenResponse.Error != "" {
+		log.Errorf("OIDC token error %q '%s'", tokenResponse.Error, tokenResponse.ErrorDescription)
+
+		return nil, errors.New("Authorization code was rejected by identity provider")
+	}
+
+	if tokenResponse.IDToken == "" {
+		return nil, errors.New("Identity provider did not return an ID token")
+	}
+
+	claims, err := p.verifyIDToken(tokenResponse.IDToken, discovery.Issuer, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Identity{
+		Provider:      p.Name(),
+		Subject:       claims.Subject,
+		Email:         strings.ToLower(claims.Email),
+		EmailVerified: isTrue(claims.EmailVerified),
+		Name:          claims.Name,
+		Picture:       claims.Picture,
+	}, nil
+}
+
+// ====================================================================
+// ======================== Helper Functions ==========================
+// ====================================================================
+
+// verifyIDToken validates the ID token and returns its claims.
+func (p *OIDCProvider) verifyIDToken(idToken, issuer, nonce string) (*oidcClaims, error) {
+	claims := &oidcClaims{}
+
+	_, err := jwt.ParseWithClaims(idToken, claims, p.verificationKey,
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		log.Errorf("OIDC ID token error '%v'", err)
+
+		return nil, errors.New("Invalid ID token")
+	}
+
+	if claims.Nonce != nonce {
+		return nil, errors.New("Invalid ID token nonce")
+	}
+
+	if claims.Subject == "" {
+		return nil, errors.New("Invalid ID token subject")
+	}
+
+	return claims, nil
+}
+
+// verificationKey returns the public key of the JWKS matching the `kid` header.
+// The JWKS is fetched again once when the key is unknown (key rotation).
+func (p *OIDCProvider) verificationKey(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+
+	keys, err := p.fetchKeys()
+	if err != nil {
+		return nil, err
+	}
+	p.keys = keys
+
+	// Token without `kid` => accepted when the provider has a single key
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key, nil
+		}
+	}
+
+	key, ok := keys[kid]
+	if !ok {
+		return nil, errors.New("Unknown signing key %q", kid)
+	}
+
+	return key, nil
+}
+
+// loadDiscovery fetches the discovery document once.
+func (p *OIDCProvider) loadDiscovery() (*oidcDiscovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	var discovery oidcDiscovery
+	if err := p.getJSON(p.config.DiscoveryURL, &discovery); err != nil {
+		log.Errorf("OIDC discovery error '%v'", err)
+
+		return nil, errors.New("Identity provider is not available")
+	}
+
+	if discovery.Issuer == "" || discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JwksURI == "" {
+		return nil, errors.New("Invalid discovery document of identity provider")
+	}
+
+	p.discovery = &discovery
+
+	return p.discovery, nil
+}
+
+// fetchKeys fetches the JWKS of the provider. Caller must hold the mutex.
+func (p *OIDCProvider) fetchKeys() (map[string]any, error) {
+	if p.discovery == nil {
+		return nil, errors.New("Identity provider is not available")
+	}
+
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := p.getJSON(p.discovery.JwksURI, &jwks); err != nil {
+		log.Errorf("OIDC JWKS error '%v'", err)
+
+		return nil, errors.New("Identity provider is not available")
+	}
+
+	keys := map[string]any{}
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			log.Warnf("Skip OIDC signing key %q '%v'", jwk.Kid, err)
+			continue
+		}
+
+		keys[jwk.Kid] = key
+	}
+
+	return keys, nil
+}
+
+// getJSON sends a GET request and decodes the JSON response.
+func (p *OIDCProvider) getJSON(endpoint string, data any) error {
+	resp, err := p.config.HTTPClient.Get(endpoint)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.New("Unexpected status %d of %s", resp.StatusCode, endpoint)
+	}
+
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(data)
+}
+
+// publicKey converts the JWK to an RSA or ECDSA public key.
+func (k jsonWebKey) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errors.New("Unsupported curve %q", k.Crv)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	}
+
+	return nil, errors.New("Unsupported key type %q", k.Kty)
+}
+
+// isTrue reads a boolean claim which may be sent as a string.
+func isTrue(value any) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	}
+
+	return false
+}
+
+// Make sure the provider implements the interface.
+var _ Provider = (*OIDCProvider)(nil)
@@ -0,0 +1,28 @@
+package oauth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// NewCodeVerifier generates a random PKCE code verifier (RFC 7636, 43 characters).
+func NewCodeVerifier() string {
+	return RandomString(32)
+}
+
+// CodeChallenge returns the S256 challenge of a PKCE code verifier.
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// RandomString returns `size` random bytes encoded in base64 URL format.
+// It is used for the `state`, `nonce` and the code verifier.
+func RandomString(size int) string {
+	data := make([]byte, size)
+	_, _ = rand.Read(data) // Never returns an error (see crypto/rand)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
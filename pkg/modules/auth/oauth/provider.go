@@ -0,0 +1,150 @@
+package oauth
+
+import (
+	"fmt"
+	"gfly/pkg/modules/auth"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ====================================================================
+// ============================ Data Types ============================
+// ====================================================================
+
+// Identity struct to describe the account of a user at an identity provider.
+type Identity struct {
+	Provider      string // Provider name. Ex: `oidc`
+	Subject       string // Account ID at the provider (`sub` claim)
+	Email         string
+	EmailVerified bool
+	Name          string
+	Picture       string
+}
+
+// Provider is an OAuth2 identity provider which signs in users with the
+// authorization code flow and PKCE.
+type Provider interface {
+	// Name returns the unique name of the provider. It is used in the routes
+	// `/auth/oauth/{provider}` and `/auth/oauth/{provider}/callback`.
+	Name() string
+
+	// AuthCodeURL returns the URL of the provider's consent page.
+	//
+	// Parameters:
+	//   - state (string): Opaque value returned back to the callback (CSRF protection)
+	//   - codeChallenge (string): The S256 PKCE challenge of the code verifier
+	//   - nonce (string): Value which must be found in the ID token (replay protection)
+	AuthCodeURL(state, codeChallenge, nonce string) (string, error)
+
+	// Exchange trades the authorization code for the identity of the user.
+	//
+	// Parameters:
+	//   - code (string): The authorization code received by the callback
+	//   - codeVerifier (string): The PKCE code verifier
+	//   - nonce (string): The nonce sent with AuthCodeURL
+	Exchange(code, codeVerifier, nonce string) (*Identity, error)
+}
+
+var (
+	providers     = map[string]Provider{}
+	providersMu   sync.RWMutex
+	providersOnce sync.Once
+)
+
+// ====================================================================
+// ========================= Main functions ===========================
+// ====================================================================
+
+// Register adds a provider to the registry. A provider with the same name is replaced.
+func Register(provider Provider) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+
+	providers[provider.Name()] = provider
+}
+
+// Get returns the registered provider with the given name.
+func Get(name string) (Provider, error) {
+	loadProviders()
+
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+
+	provider, ok := providers[name]
+	if !ok {
+		return nil, errors.New("Unknown identity provider %q", name)
+	}
+
+	return provider, nil
+}
+
+// Names returns the sorted names of all registered providers.
+func Names() []string {
+	loadProviders()
+
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// CallbackPath returns the path of the callback route of a provider (without API prefix).
+func CallbackPath(name string) string {
+	return fmt.Sprintf("/auth/oauth/%s/callback", name)
+}
+
+// ====================================================================
+// ======================== Helper Functions ==========================
+// ====================================================================
+
+// loadProviders registers the generic OpenID Connect provider from `.env` once.
+//
+// Configurations:
+//   - AUTH_OIDC_DISCOVERY_URL: Discovery document. Ex: `https://accounts.google.com/.well-known/openid-configuration`
+//     The provider is disabled when empty.
+//   - AUTH_OIDC_NAME: Provider name (`oidc` by default)
+//   - AUTH_OIDC_CLIENT_ID, AUTH_OIDC_CLIENT_SECRET: Client credentials
+//   - AUTH_OIDC_REDIRECT_URL: Callback URL registered at the provider
+//     (`<APP_URL>/<API_PREFIX>/<API_VERSION>/auth/oauth/<name>/callback` by default)
+//   - AUTH_OIDC_SCOPES: Requested scopes (`openid email profile` by default)
+func loadProviders() {
+	providersOnce.Do(func() {
+		discoveryURL := utils.Getenv(auth.OIDCDiscoveryURL, "")
+		if discoveryURL == "" {
+			return
+		}
+
+		name := utils.Getenv(auth.OIDCName, "oidc")
+		redirectURL := utils.Getenv(auth.OIDCRedirectURL, "")
+		if redirectURL == "" {
+			redirectURL = fmt.Sprintf(
+				"%s/%s/%s%s",
+				strings.TrimRight(utils.Getenv("APP_URL", "http://localhost:7789"), "/"),
+				utils.Getenv("API_PREFIX", "api"),
+				utils.Getenv("API_VERSION", "v1"),
+				CallbackPath(name),
+			)
+		}
+
+		Register(NewOIDCProvider(OIDCConfig{
+			Name:         name,
+			DiscoveryURL: discoveryURL,
+			ClientID:     utils.Getenv(auth.OIDCClientID, ""),
+			ClientSecret: utils.Getenv(auth.OIDCClientSecret, ""),
+			RedirectURL:  redirectURL,
+			Scopes:       strings.Fields(utils.Getenv(auth.OIDCScopes, "openid email profile")),
+		}))
+
+		log.Infof("OpenID Connect provider %q is enabled", name)
+	})
+}
@@ -0,0 +1,70 @@
+package password
+
+import (
+	"gfly/internal/domain/models"
+	"gfly/internal/domain/repository"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	mb "github.com/gflydev/db"
+	"time"
+)
+
+// ====================================================================
+// ========================= Main functions ===========================
+// ====================================================================
+
+// CheckHistory refuses a new password which equals the current password or
+// one of the last `AUTH_PASSWORD_HISTORY` passwords of the user.
+//
+// Parameters:
+//   - user: *models.User - The user whose password changes
+//   - password: The new plain text password
+//
+// Returns:
+//   - error: Error with the message of the history rule, nil if the password was not used before
+func CheckHistory(user *models.User, password string) error {
+	limit := LoadPolicy().History
+	if limit <= 0 {
+		return nil
+	}
+
+	if utils.ComparePasswords(user.Password, password) {
+		return errors.New("must not be one of your last %d passwords", limit)
+	}
+
+	for _, history := range repository.Pool.GetPasswordHistories(user.ID, limit) {
+		if utils.ComparePasswords(history.Password, password) {
+			return errors.New("must not be one of your last %d passwords", limit)
+		}
+	}
+
+	return nil
+}
+
+// RememberPassword keeps the previous password hash of a user after a change
+// and removes hashes beyond `AUTH_PASSWORD_HISTORY`.
+//
+// Parameters:
+//   - userID: The unique identifier of the user
+//   - previousHash: The hash of the replaced password
+func RememberPassword(userID int, previousHash string) {
+	limit := LoadPolicy().History
+	if limit <= 0 {
+		return
+	}
+
+	if err := mb.CreateModel(&models.PasswordHistory{
+		UserID:    userID,
+		Password:  previousHash,
+		CreatedAt: time.Now(),
+	}); err != nil {
+		log.Errorf("Store password history error '%v'", err)
+
+		return
+	}
+
+	if err := repository.Pool.PrunePasswordHistories(userID, limit); err != nil {
+		log.Errorf("Prune password history error '%v'", err)
+	}
+}
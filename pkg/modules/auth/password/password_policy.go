@@ -0,0 +1,190 @@
+package password
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed" // Embed the denylist
+	"gfly/pkg/modules/auth"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/core/utils"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// commonPasswords the bundled denylist, one lowercase password per line.
+//
+//go:embed common_passwords.txt
+var commonPasswords []byte
+
+var (
+	denylist     map[string]struct{}
+	denylistOnce sync.Once
+)
+
+// Policy struct to describe the password rules.
+type Policy struct {
+	MinLength     int  // Minimum number of characters
+	RequireUpper  bool // At least one uppercase letter
+	RequireLower  bool // At least one lowercase letter
+	RequireDigit  bool // At least one digit
+	RequireSymbol bool // At least one character which is neither a letter nor a digit
+	Denylist      bool // Refuse common passwords
+	History       int  // Number of previous passwords which can not be reused (0 disables)
+}
+
+// ====================================================================
+// ========================= Main functions ===========================
+// ====================================================================
+
+// LoadPolicy reads the password policy from `.env`.
+//
+// Configurations:
+//   - AUTH_PASSWORD_MIN_LENGTH: Minimum length (Default 8)
+//   - AUTH_PASSWORD_REQUIRE_UPPER/LOWER/DIGIT/SYMBOL: Required character classes
+//   - AUTH_PASSWORD_DENYLIST: Refuse passwords of the bundled denylist
+//   - AUTH_PASSWORD_HISTORY: Number of previous passwords which can not be reused
+func LoadPolicy() Policy {
+	return Policy{
+		MinLength:     utils.Getenv(auth.PasswordMinLength, 8),
+		RequireUpper:  utils.Getenv(auth.PasswordRequireUpper, true),
+		RequireLower:  utils.Getenv(auth.PasswordRequireLower, true),
+		RequireDigit:  utils.Getenv(auth.PasswordRequireDigit, true),
+		RequireSymbol: utils.Getenv(auth.PasswordRequireSymbol, false),
+		Denylist:      utils.Getenv(auth.PasswordDenylist, true),
+		History:       utils.Getenv(auth.PasswordHistory, 5),
+	}
+}
+
+// Check verifies the password against all rules of the policy.
+//
+// Parameters:
+//   - password: The plain text password
+//   - personalData: Email, name... of the user which must not be part of the password
+//
+// Returns:
+//   - error: The message of the first broken rule, nil if the password is accepted
+func (p Policy) Check(password string, personalData ...string) error {
+	if err := p.CheckStrength(password); err != nil {
+		return err
+	}
+
+	return checkPersonalData(password, personalData)
+}
+
+// CheckPersonalData verifies that the password does not contain the email or the name of the user.
+// It is used when the request does not carry the personal data (Ex: the email of an updated user).
+//
+// Parameters:
+//   - password: The plain text password
+//   - personalData: Email, name... of the user which must not be part of the password
+//
+// Returns:
+//   - error: The message of the personal data rule, nil if the password is accepted
+func CheckPersonalData(password string, personalData ...string) error {
+	return checkPersonalData(password, personalData)
+}
+
+// CheckStrength verifies the password rules which do not depend on the user:
+// length, character classes and denylist.
+//
+// Parameters:
+//   - password: The plain text password
+//
+// Returns:
+//   - error: The message of the first broken rule, nil if the password is accepted
+func (p Policy) CheckStrength(password string) error {
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return errors.New("must be at least %d characters", p.MinLength)
+	}
+
+	if missing := p.missingClasses(password); len(missing) > 0 {
+		return errors.New("must contain at least one %s", strings.Join(missing, ", "))
+	}
+
+	if p.Denylist && isCommonPassword(password) {
+		return errors.New("is too common, choose a less guessable password")
+	}
+
+	return nil
+}
+
+// ====================================================================
+// ======================== Helper Functions ==========================
+// ====================================================================
+
+// missingClasses lists the required character classes which the password does not contain.
+func (p Policy) missingClasses(password string) []string {
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+
+	for _, char := range password {
+		switch {
+		case unicode.IsUpper(char):
+			hasUpper = true
+		case unicode.IsLower(char):
+			hasLower = true
+		case unicode.IsDigit(char):
+			hasDigit = true
+		case !unicode.IsLetter(char):
+			hasSymbol = true
+		}
+	}
+
+	missing := make([]string, 0)
+	if p.RequireUpper && !hasUpper {
+		missing = append(missing, "uppercase letter")
+	}
+	if p.RequireLower && !hasLower {
+		missing = append(missing, "lowercase letter")
+	}
+	if p.RequireDigit && !hasDigit {
+		missing = append(missing, "digit")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		missing = append(missing, "symbol")
+	}
+
+	return missing
+}
+
+// isCommonPassword checks the password (case-insensitive) against the bundled denylist.
+func isCommonPassword(password string) bool {
+	denylistOnce.Do(func() {
+		denylist = map[string]struct{}{}
+
+		scanner := bufio.NewScanner(bytes.NewReader(commonPasswords))
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				denylist[line] = struct{}{}
+			}
+		}
+	})
+
+	_, found := denylist[strings.ToLower(password)]
+
+	return found
+}
+
+// checkPersonalData refuses passwords containing the email (or its local part) or a part of the name.
+// Parts shorter than 3 characters are ignored.
+func checkPersonalData(password string, personalData []string) error {
+	lowerPassword := strings.ToLower(password)
+
+	for _, data := range personalData {
+		data = strings.ToLower(strings.TrimSpace(data))
+
+		parts := strings.Fields(data)
+		if localPart, _, found := strings.Cut(data, "@"); found {
+			parts = append(parts, localPart)
+		}
+
+		for _, part := range parts {
+			if utf8.RuneCountInString(part) >= 3 && strings.Contains(lowerPassword, part) {
+				return errors.New("must not contain your email or name")
+			}
+		}
+	}
+
+	return nil
+}
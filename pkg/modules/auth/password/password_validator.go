@@ -0,0 +1,135 @@
+package password
+
+import (
+	"github.com/gflydev/core"
+	"github.com/gflydev/http"
+	"github.com/gflydev/validation"
+	"github.com/go-playground/validator/v10"
+)
+
+// Tag validation tag of the password policy. Ex: `validate:"required,max=255,password"`
+const Tag = "password"
+
+// PersonalData is implemented by DTOs which carry data of the user (email, name...)
+// that must not be part of the password.
+type PersonalData interface {
+	PersonalData() []string
+}
+
+// rule custom validator of the password policy.
+type rule struct{}
+
+func init() {
+	validation.AddRule(rule{})
+}
+
+// GetTag returns the validation tag.
+func (r rule) GetTag() string {
+	return Tag
+}
+
+// Handler validates the field with the password policy.
+// Personal data is read from the struct holding the field when it implements PersonalData.
+func (r rule) Handler() validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		var personalData []string
+		if parent := fl.Parent(); parent.CanInterface() {
+			if data, ok := parent.Interface().(PersonalData); ok {
+				personalData = data.PersonalData()
+			}
+		}
+
+		return LoadPolicy().Check(fl.Field().String(), personalData...) == nil
+	}
+}
+
+// ====================================================================
+// ========================= Main functions ===========================
+// ====================================================================
+
+// MsgForTag builds the validation message of a field. The password tag gets
+// the message of the broken rule, other tags the default messages.
+//
+// Parameters:
+//   - fe: The validation error of a field
+//
+// Returns:
+//   - string: The validation message
+func MsgForTag(fe validator.FieldError) string {
+	if fe.Tag() != Tag {
+		return validation.MsgForTag(fe)
+	}
+
+	password, _ := fe.Value().(string)
+	if err := LoadPolicy().CheckStrength(password); err != nil {
+		return err.Error()
+	}
+
+	// Only the personal data rule is left
+	return "must not contain your email or name"
+}
+
+// ProcessData works like http.ProcessData and responds the messages of the password policy.
+//
+// Example Usage:
+//
+//	func (h *SignUp) Validate(c *core.Ctx) error {
+//		return password.ProcessData[request.SignUp](c)
+//	}
+func ProcessData[T http.AddData](c *core.Ctx) error {
+	// Receive request data
+	var requestData T
+	if errData := http.Parse(c, &requestData); errData != nil {
+		return c.Error(errData)
+	}
+
+	// Sanitize request data
+	http.SanitizeStruct(&requestData)
+
+	// Validate DTO
+	if errData := http.Validate(requestData, MsgForTag); errData != nil {
+		return c.Error(errData)
+	}
+
+	// Store data into context
+	c.SetData(http.RequestKey, requestData)
+
+	return nil
+}
+
+// ProcessUpdateData works like http.ProcessUpdateData and responds the messages of the password policy.
+//
+// Example Usage:
+//
+//	func (h *UpdateUserApi) Validate(c *core.Ctx) error {
+//		return password.ProcessUpdateData[request.UpdateUser](c)
+//	}
+func ProcessUpdateData[T http.UpdateData](c *core.Ctx) error {
+	// Receive path parameter ID
+	itemID, errData := http.PathID(c)
+	if errData != nil {
+		return c.Error(errData)
+	}
+
+	// Receive request data
+	var requestData T
+	if errData := http.Parse(c, &requestData); errData != nil {
+		return c.Error(errData)
+	}
+
+	// Sanitize request data
+	http.SanitizeStruct(&requestData)
+
+	// Set ID on the request body
+	requestData.SetID(itemID)
+
+	// Validate DTO
+	if errData := http.Validate(requestData, MsgForTag); errData != nil {
+		return c.Error(errData)
+	}
+
+	// Store data into context
+	c.SetData(http.RequestKey, requestData)
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package request
+
+import "gfly/pkg/modules/auth/dto"
+
+// CreateAccessToken struct to describe creating a personal access token.
+type CreateAccessToken struct {
+	dto.CreateAccessToken
+}
+
+// ToDto Convert to CreateAccessToken DTO object.
+func (r CreateAccessToken) ToDto() dto.CreateAccessToken {
+	return r.CreateAccessToken
+}
+
+// UpdateAccessToken struct to describe renaming a personal access token.
+type UpdateAccessToken struct {
+	dto.UpdateAccessToken
+}
+
+// ToDto Convert to UpdateAccessToken DTO object.
+func (r UpdateAccessToken) ToDto() dto.UpdateAccessToken {
+	return r.UpdateAccessToken
+}
@@ -0,0 +1,15 @@
+package request
+
+import (
+	"gfly/pkg/modules/auth/dto"
+)
+
+// Impersonate struct to describe an impersonation request.
+type Impersonate struct {
+	dto.Impersonate
+}
+
+// ToDto convert to Impersonate DTO object.
+func (r Impersonate) ToDto() dto.Impersonate {
+	return r.Impersonate
+}
@@ -0,0 +1,25 @@
+package request
+
+import (
+	"gfly/pkg/modules/auth/dto"
+)
+
+// MagicLink struct to describe a passwordless sign-in link request.
+type MagicLink struct {
+	dto.MagicLink
+}
+
+// ToDto convert to MagicLink DTO object.
+func (r MagicLink) ToDto() dto.MagicLink {
+	return r.MagicLink
+}
+
+// ConsumeMagicLink struct to describe a sign-in request with a signed link.
+type ConsumeMagicLink struct {
+	dto.ConsumeMagicLink
+}
+
+// ToDto convert to ConsumeMagicLink DTO object.
+func (r ConsumeMagicLink) ToDto() dto.ConsumeMagicLink {
+	return r.ConsumeMagicLink
+}
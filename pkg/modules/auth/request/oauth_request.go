@@ -0,0 +1,15 @@
+package request
+
+import (
+	"gfly/pkg/modules/auth/dto"
+)
+
+// OAuthCallback struct to describe the callback request of an identity provider.
+type OAuthCallback struct {
+	dto.OAuthCallback
+}
+
+// ToDto convert to OAuthCallback DTO object.
+func (r OAuthCallback) ToDto() dto.OAuthCallback {
+	return r.OAuthCallback
+}
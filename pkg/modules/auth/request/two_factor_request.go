@@ -0,0 +1,25 @@
+package request
+
+import (
+	"gfly/pkg/modules/auth/dto"
+)
+
+// TwoFactorCode struct to describe a TOTP code request.
+type TwoFactorCode struct {
+	dto.TwoFactorCode
+}
+
+// ToDto convert to TwoFactorCode DTO object.
+func (r TwoFactorCode) ToDto() dto.TwoFactorCode {
+	return r.TwoFactorCode
+}
+
+// VerifyTwoFactor struct to describe the second step of a two-factor sign-in.
+type VerifyTwoFactor struct {
+	dto.VerifyTwoFactor
+}
+
+// ToDto convert to VerifyTwoFactor DTO object.
+func (r VerifyTwoFactor) ToDto() dto.VerifyTwoFactor {
+	return r.VerifyTwoFactor
+}
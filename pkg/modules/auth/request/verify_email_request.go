@@ -0,0 +1,23 @@
+package request
+
+import "gfly/pkg/modules/auth/dto"
+
+// VerifyEmail struct to describe email verification.
+type VerifyEmail struct {
+	dto.VerifyEmail
+}
+
+// ToDto Convert to VerifyEmail DTO object.
+func (r VerifyEmail) ToDto() dto.VerifyEmail {
+	return r.VerifyEmail
+}
+
+// ResendVerifyEmail struct to describe resending the verification link.
+type ResendVerifyEmail struct {
+	dto.ResendVerifyEmail
+}
+
+// ToDto Convert to ResendVerifyEmail DTO object.
+func (r ResendVerifyEmail) ToDto() dto.ResendVerifyEmail {
+	return r.ResendVerifyEmail
+}
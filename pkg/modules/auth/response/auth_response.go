@@ -18,8 +18,92 @@ type SignUp struct {
 	Email     string           `json:"email" doc:"The email address of the user."`
 	Fullname  string           `json:"fullname" doc:"The full name of the user."`
 	Phone     string           `json:"phone" doc:"The phone number of the user."`
-	Token     *string          `json:"token" doc:"The authorization token of the user."`
 	Status    types.UserStatus `json:"status" doc:"The status of the user account."`
 	CreatedAt time.Time        `json:"created_at" doc:"The timestamp of when the user was created."`
 	UpdatedAt time.Time        `json:"updated_at" doc:"The timestamp of when the user was last updated."`
 }
+
+// Session struct to describe a signed-in device of the current user.
+type Session struct {
+	ID         string     `json:"id" example:"5f0c3a0e-8f1b-4a4e-9b3a-1f2d3c4b5a69" doc:"The unique identifier of the session."`
+	UserAgent  *string    `json:"user_agent" example:"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7)" doc:"The user agent of the device."`
+	IPAddress  *string    `json:"ip_address" example:"203.0.113.10" doc:"The IP address of the device at sign-in."`
+	Current    bool       `json:"current" example:"true" doc:"Whether the session belongs to the device sending the request."`
+	ExpiresAt  time.Time  `json:"expires_at" doc:"The timestamp of when the session expires."`
+	LastUsedAt *time.Time `json:"last_used_at" doc:"The timestamp of when the session was last refreshed."`
+	CreatedAt  time.Time  `json:"created_at" doc:"The timestamp of when the session was created."`
+}
+
+// ListSession struct to describe a list of sessions.
+type ListSession struct {
+	Data []Session `json:"data" doc:"List of active sessions"`
+}
+
+// JWK struct to describe a public JSON Web Key (RFC 7517).
+type JWK struct {
+	Kty string `json:"kty" example:"RSA" doc:"The key type (RSA or OKP)."`
+	Use string `json:"use" example:"sig" doc:"The intended use of the key."`
+	Alg string `json:"alg" example:"RS256" doc:"The signing algorithm of the key."`
+	Kid string `json:"kid" example:"2026-01" doc:"The key ID referenced by the token header."`
+	N   string `json:"n,omitempty" doc:"The RSA modulus (base64url)."`
+	E   string `json:"e,omitempty" doc:"The RSA public exponent (base64url)."`
+	Crv string `json:"crv,omitempty" example:"Ed25519" doc:"The curve of an OKP key."`
+	X   string `json:"x,omitempty" doc:"The OKP public key (base64url)."`
+}
+
+// JWKS struct to describe a JSON Web Key Set.
+type JWKS struct {
+	Keys []JWK `json:"keys" doc:"Public keys accepted for JWT verification"`
+}
+
+// TwoFactorChallenge struct to describe the sign-in response of a user with two-factor authentication.
+type TwoFactorChallenge struct {
+	TwoFactorRequired bool   `json:"two_factor_required" example:"true" doc:"Always true, the challenge must be verified at /auth/2fa/verify"`
+	Challenge         string `json:"challenge" doc:"The short-lived challenge token"`
+}
+
+// TwoFactorEnrollment struct to describe a pending TOTP enrollment.
+type TwoFactorEnrollment struct {
+	Secret string `json:"secret" example:"JBSWY3DPEHPK3PXP" doc:"The base32 secret for manual entry"`
+	URI    string `json:"uri" example:"otpauth://totp/gFly:admin@gfly.dev?issuer=gFly&secret=JBSWY3DPEHPK3PXP" doc:"The otpauth URI"`
+	QRCode string `json:"qr_code" example:"data:image/png;base64,iVBORw0KGgo..." doc:"The QR code of the URI as PNG data URI"`
+}
+
+// RecoveryCodes struct to describe one-time recovery codes.
+type RecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes" example:"k3v9q-7xw2m" doc:"One-time recovery codes, shown only once"`
+}
+
+// AccessToken struct to describe a personal access token.
+type AccessToken struct {
+	ID         int        `json:"id" example:"1" doc:"The unique identifier of the token."`
+	Name       string     `json:"name" example:"CI pipeline" doc:"The name of the token."`
+	Scopes     []string   `json:"scopes" example:"role:admin" doc:"Scopes granted to the token."`
+	ExpiresAt  *time.Time `json:"expires_at" doc:"The timestamp of when the token expires. Null never expires."`
+	LastUsedAt *time.Time `json:"last_used_at" doc:"The timestamp of when the token was last used."`
+	CreatedAt  time.Time  `json:"created_at" doc:"The timestamp of when the token was created."`
+}
+
+// ListAccessToken struct to describe the personal access tokens of the current user.
+type ListAccessToken struct {
+	Data []AccessToken `json:"data" doc:"Personal access tokens, the most recent first."`
+}
+
+// NewAccessToken struct to describe a created personal access token with its plain text value.
+type NewAccessToken struct {
+	AccessToken
+	Token string `json:"token" example:"gfly_pat_d1a4216a226cbf75eaefc9107c2c64b6b2c0f18cd8634e3a6f495146c38e1324" doc:"The plain text token. It is shown only once."`
+}
+
+// OAuthRedirect struct to describe the authorization URL of an identity provider.
+type OAuthRedirect struct {
+	URL string `json:"url" example:"https://idp.example.com/authorize?response_type=code&client_id=gfly&code_challenge_method=S256" doc:"Open this URL in a browser to sign in at the identity provider"`
+}
+
+// Impersonation struct to describe an impersonation token.
+type Impersonation struct {
+	Access    string    `json:"access" doc:"The short-lived access token of the user. It carries the admin in the act claim, no refresh token is issued."`
+	UserID    int       `json:"user_id" example:"2" doc:"The ID of the impersonated user."`
+	ActorID   int       `json:"actor_id" example:"1" doc:"The ID of the impersonating admin."`
+	ExpiresAt time.Time `json:"expires_at" doc:"The timestamp of when the token expires."`
+}
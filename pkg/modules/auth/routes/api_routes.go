@@ -5,30 +5,54 @@ import (
 	"gfly/pkg/modules/auth"
 	"gfly/pkg/modules/auth/api"
 	"gfly/pkg/modules/auth/middleware"
+	"gfly/pkg/modules/auth/oauth"
 	"github.com/gflydev/core"
 	"github.com/gflydev/core/utils"
 )
 
 // RegisterApi func for describe a group of API routes.
-func RegisterApi(apiRouter *core.Group) {
+// Public well-known routes (Ex: JWKS) are registered on the root router `r`.
+func RegisterApi(r core.IFly, apiRouter *core.Group) {
 	prefixAPI := fmt.Sprintf(
 		"/%s/%s",
 		utils.Getenv("API_PREFIX", "api"),
 		utils.Getenv("API_VERSION", "v1"),
 	)
 
-	apiRouter.Use(middleware.JWTAuth(
+	/* ============================ Well-known ============================ */
+	// curl -v -X GET http://localhost:7789/.well-known/jwks.json | jq
+	r.GET("/.well-known/jwks.json", api.NewJWKSApi())
+
+	excludes := []string{
 		// Frontend APIs
-		prefixAPI+"/frontend/auth/signin",
-		prefixAPI+"/frontend/auth/signout",
+		prefixAPI + "/frontend/auth/signin",
+		prefixAPI + "/frontend/auth/signout",
+		prefixAPI + "/frontend/auth/2fa/verify",
+		prefixAPI + "/frontend/auth/magic-link/consume",
 
 		// Backend APIs
-		prefixAPI+"/auth/signin",
-		prefixAPI+"/auth/signup",
-		prefixAPI+"/auth/refresh",
-		prefixAPI+"/password/forgot",
-		prefixAPI+"/password/reset",
-	))
+		prefixAPI + "/auth/signin",
+		prefixAPI + "/auth/signup",
+		prefixAPI + "/auth/refresh",
+		prefixAPI + "/auth/2fa/verify",
+		prefixAPI + "/auth/verify-email",
+		prefixAPI + "/auth/verify-email/resend",
+		prefixAPI + "/auth/magic-link",
+		prefixAPI + "/auth/magic-link/consume",
+		prefixAPI + "/password/forgot",
+		prefixAPI + "/password/reset",
+	}
+
+	// OAuth2 / OpenID Connect sign-in of each registered provider
+	for _, name := range oauth.Names() {
+		excludes = append(excludes,
+			prefixAPI+"/frontend/auth/oauth/"+name,
+			prefixAPI+"/auth/oauth/"+name,
+			prefixAPI+oauth.CallbackPath(name),
+		)
+	}
+
+	apiRouter.Use(middleware.JWTAuth(excludes...))
 
 	// Frontend APIs
 	apiRouter.Group("/frontend", func(frontendRouter *core.Group) {
@@ -36,15 +60,47 @@ func RegisterApi(apiRouter *core.Group) {
 		frontendRouter.Group("/auth", func(authGroup *core.Group) {
 			authGroup.POST("/signin", api.NewSignInApi(auth.TypeWeb))
 			authGroup.DELETE("/signout", api.NewSignOutApi(auth.TypeWeb))
+			authGroup.POST("/2fa/verify", api.NewVerifyTwoFactorApi(auth.TypeWeb))
+			authGroup.POST("/magic-link/consume", api.NewConsumeMagicLinkApi(auth.TypeWeb))
+			authGroup.GET("/oauth/{provider}", api.NewOAuthRedirectApi(auth.TypeWeb))
 		})
 	})
 
 	/* ============================ Auth Group ============================ */
 	apiRouter.Group("/auth", func(authGroup *core.Group) {
+		// Sensitive APIs are refused to impersonation tokens
+		blockImpersonationFunc := r.Apply(middleware.BlockImpersonation)
+
 		authGroup.POST("/signin", api.NewSignInApi(auth.TypeAPI))
 		authGroup.DELETE("/signout", api.NewSignOutApi(auth.TypeAPI))
 		authGroup.POST("/signup", api.NewSignUpApi())
 		authGroup.PUT("/refresh", api.NewRefreshTokenApi())
+
+		// Email verification
+		authGroup.POST("/verify-email", api.NewVerifyEmailApi())
+		authGroup.POST("/verify-email/resend", api.NewResendVerifyEmailApi())
+
+		// Passwordless sign-in
+		authGroup.POST("/magic-link", api.NewSendMagicLinkApi())
+		authGroup.POST("/magic-link/consume", api.NewConsumeMagicLinkApi(auth.TypeAPI))
+
+		// OAuth2 / OpenID Connect sign-in
+		authGroup.GET("/oauth/{provider}", api.NewOAuthRedirectApi(auth.TypeAPI))
+		authGroup.GET("/oauth/{provider}/callback", api.NewOAuthCallbackApi())
+
+		// Signed-in devices of the current user
+		authGroup.GET("/sessions", api.NewListSessionsApi())
+		authGroup.DELETE("/sessions", blockImpersonationFunc(api.NewRevokeOtherSessionsApi()))
+		authGroup.DELETE("/sessions/{id}", blockImpersonationFunc(api.NewRevokeSessionApi()))
+
+		// Two-factor authentication (TOTP)
+		authGroup.POST("/2fa/enroll", blockImpersonationFunc(api.NewEnrollTwoFactorApi()))
+		authGroup.POST("/2fa/confirm", blockImpersonationFunc(api.NewConfirmTwoFactorApi()))
+		authGroup.POST("/2fa/disable", blockImpersonationFunc(api.NewDisableTwoFactorApi()))
+		authGroup.POST("/2fa/verify", api.NewVerifyTwoFactorApi(auth.TypeAPI))
+
+		// Stop acting as another user (see `/users/{id}/impersonate`)
+		authGroup.DELETE("/impersonation", api.NewStopImpersonationApi())
 	})
 
 	/* ============================ Password Group ============================ */
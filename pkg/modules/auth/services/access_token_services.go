@@ -0,0 +1,214 @@
+package services
+
+import (
+	"gfly/internal/domain/models"
+	"gfly/internal/domain/repository"
+	"gfly/pkg/modules/auth"
+	"gfly/pkg/modules/auth/dto"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	mb "github.com/gflydev/db"
+	"github.com/gflydev/db/null"
+	"slices"
+	"strings"
+	"time"
+)
+
+// lastUsedPrecision minimum interval between two updates of `last_used_at`.
+const lastUsedPrecision = time.Minute
+
+// ====================================================================
+// ========================= Main functions ===========================
+// ====================================================================
+
+// IsAccessToken checks the bearer token is a personal access token (`gfly_pat_...`).
+func IsAccessToken(token string) bool {
+	return strings.HasPrefix(token, auth.AccessTokenPrefix)
+}
+
+// CreateAccessToken creates a personal access token for a user.
+//
+// Parameters:
+//   - userID: The unique identifier of the token owner
+//   - grantedScopes: Scopes of the current request. The token can not get more scopes.
+//   - createToken: dto.CreateAccessToken - Name, scopes and lifetime of the token
+//
+// Returns:
+//   - *models.PersonalAccessToken: The stored token
+//   - string: The plain text token. It is not stored, so it can be shown only once.
+//   - error: Error if a scope is not granted or storing fails
+func CreateAccessToken(userID int, grantedScopes []string, createToken dto.CreateAccessToken) (*models.PersonalAccessToken, string, error) {
+	scopes := createToken.Scopes
+	if len(scopes) == 0 {
+		scopes = grantedScopes
+	}
+
+	for _, scope := range scopes {
+		if !slices.Contains(grantedScopes, scope) {
+			return nil, "", errors.New("Scope %q is not granted", scope)
+		}
+	}
+
+	plainToken := auth.AccessTokenPrefix + utils.Token()
+
+	accessToken := &models.PersonalAccessToken{
+		UserID:    userID,
+		Name:      createToken.Name,
+		TokenHash: hashAccessToken(plainToken),
+		Scopes:    strings.Join(scopes, " "),
+		CreatedAt: time.Now(),
+		UpdatedAt: null.TimeNow(),
+	}
+
+	if createToken.ExpiresInDays > 0 {
+		accessToken.ExpiresAt = null.Time(time.Now().AddDate(0, 0, createToken.ExpiresInDays))
+	}
+
+	if err := mb.CreateModel(accessToken); err != nil {
+		log.Errorf("Create access token error '%v'", err)
+
+		return nil, "", errors.New("Error occurs while creating access token")
+	}
+
+	return accessToken, plainToken, nil
+}
+
+// ListAccessTokens retrieves all personal access tokens of a user.
+//
+// Parameters:
+//   - userID: The unique identifier of the user
+//
+// Returns:
+//   - []models.PersonalAccessToken: Tokens, the most recent first
+func ListAccessTokens(userID int) []models.PersonalAccessToken {
+	return repository.Pool.GetAccessTokensByUserID(userID)
+}
+
+// GetAccessToken retrieves a personal access token of a user.
+//
+// Parameters:
+//   - userID: The unique identifier of the token owner
+//   - tokenID: The unique identifier of the token
+//
+// Returns:
+//   - *models.PersonalAccessToken: The token
+//   - error: Error if the token is not found (or belongs to another user)
+func GetAccessToken(userID, tokenID int) (*models.PersonalAccessToken, error) {
+	accessToken, err := mb.GetModelByID[models.PersonalAccessToken](tokenID)
+	if err != nil || accessToken == nil || accessToken.UserID != userID {
+		return nil, errors.New("Access token not found")
+	}
+
+	return accessToken, nil
+}
+
+// UpdateAccessToken renames a personal access token of a user.
+//
+// Parameters:
+//   - userID: The unique identifier of the token owner
+//   - tokenID: The unique identifier of the token
+//   - updateToken: dto.UpdateAccessToken - The new name
+//
+// Returns:
+//   - *models.PersonalAccessToken: The updated token
+//   - error: Error if the token is not found or updating fails
+func UpdateAccessToken(userID, tokenID int, updateToken dto.UpdateAccessToken) (*models.PersonalAccessToken, error) {
+	accessToken, err := GetAccessToken(userID, tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken.Name = updateToken.Name
+	accessToken.UpdatedAt = null.TimeNow()
+
+	if err = mb.UpdateModel(accessToken); err != nil {
+		log.Errorf("Update access token error '%v'", err)
+
+		return nil, errors.New("Error occurs while updating access token")
+	}
+
+	return accessToken, nil
+}
+
+// DeleteAccessToken revokes a personal access token of a user.
+//
+// Parameters:
+//   - userID: The unique identifier of the token owner
+//   - tokenID: The unique identifier of the token
+//
+// Returns:
+//   - error: Error if the token is not found or deleting fails
+func DeleteAccessToken(userID, tokenID int) error {
+	accessToken, err := GetAccessToken(userID, tokenID)
+	if err != nil {
+		return err
+	}
+
+	if err = mb.DeleteModel(accessToken); err != nil {
+		log.Errorf("Delete access token error '%v'", err)
+
+		return errors.New("Error occurs while deleting access token")
+	}
+
+	return nil
+}
+
+// AuthenticateAccessToken resolves the owner of a personal access token.
+//
+// Parameters:
+//   - plainToken: The bearer token `gfly_pat_...`
+//
+// Returns:
+//   - *models.User: The token owner
+//   - []string: Scopes of the token which are still granted to the owner
+//   - error: Error if the token is unknown or expired, or the owner can not sign in
+//
+// Flow:
+// 1. Looks up the token by its hash and checks expiry
+// 2. Loads the owner and verifies the account can sign in
+// 3. Limits the token scopes to the current scopes of the owner (a removed role is not kept by old tokens)
+// 4. Adds the permission scopes granted by the role scopes of the token
+// 5. Updates `last_used_at` (at most once per minute)
+func AuthenticateAccessToken(plainToken string) (*models.User, []string, error) {
+	accessToken := repository.Pool.GetAccessTokenByHash(hashAccessToken(plainToken))
+	if accessToken == nil || !accessToken.IsActive() {
+		return nil, nil, errors.New("Invalid or expired access token")
+	}
+
+	user, err := mb.GetModelByID[models.User](accessToken.UserID)
+	if err != nil || user == nil {
+		return nil, nil, errors.New("User not found")
+	}
+
+	if err = checkUserStatus(user); err != nil {
+		return nil, nil, err
+	}
+
+	userScopes := ResolveScopes(user.ID)
+	scopes := make([]string, 0)
+	for _, scope := range accessToken.ScopeList() {
+		if slices.Contains(userScopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	scopes = withRolePermissions(user.ID, scopes)
+
+	if !accessToken.LastUsedAt.Valid || time.Since(accessToken.LastUsedAt.Time) > lastUsedPrecision {
+		accessToken.LastUsedAt = null.TimeNow()
+		if err = mb.UpdateModel(accessToken); err != nil {
+			log.Errorf("Update access token usage error '%v'", err)
+		}
+	}
+
+	return user, scopes, nil
+}
+
+// ====================================================================
+// ======================== Helper Functions ==========================
+// ====================================================================
+
+// hashAccessToken hashes a personal access token before persisting it.
+func hashAccessToken(plainToken string) string {
+	return utils.Sha256(plainToken)
+}
@@ -2,17 +2,21 @@ package services
 
 import (
 	"fmt"
+	userEvents "gfly/internal/domain/events"
 	"gfly/internal/domain/models"
 	"gfly/internal/domain/models/types"
 	"gfly/internal/domain/repository"
 	"gfly/pkg/modules/auth"
 	"gfly/pkg/modules/auth/dto"
+	authEvents "gfly/pkg/modules/auth/events"
 	"github.com/gflydev/cache"
 	"github.com/gflydev/core/errors"
 	"github.com/gflydev/core/log"
 	"github.com/gflydev/core/utils"
 	mb "github.com/gflydev/db"
 	"github.com/gflydev/db/null"
+	"github.com/gflydev/event"
+	"github.com/google/uuid"
 	"strconv"
 	"strings"
 	"time"
@@ -22,69 +26,142 @@ import (
 // ========================= Main functions ===========================
 // ====================================================================
 
-// SignIn authenticates a user and generates access/refresh token pair
+// Authenticate validates the given credentials and returns the matching user.
+// Failed attempts are throttled per email and per IP address, and too many
+// failures lock the account temporarily.
 //
 // Parameters:
-//   - signIn: *dto.SignIn - Contains validated login credentials:
+//   - signIn: dto.SignIn - Contains validated login credentials:
 //   - Username: Email address used for login
 //   - Password: Plain text password to validate
+//   - client: auth.Client - The device (user agent, IP address) which signs in
 //
 // Returns:
-//   - *auth.Token: Token pair containing access and refresh tokens if successful
+//   - *models.User: The authenticated user if successful
 //   - error: Error if authentication fails:
+//   - ErrTooManyAttempts while the email or the IP address is throttled
+//   - ErrAccountLocked while the account is locked
 //   - Invalid email/password
 //   - User account not active
-//   - Token generation failed
-//   - Redis caching failed
 //
 // Flow:
-// 1. Looks up user by email address
-// 2. Validates provided password against stored hash
-// 3. Verifies user account is active
-// 4. Generates new access/refresh token pair
-// 5. Caches refresh token in Redis with TTL
-//
-// Example:
-//
-//	 credentials := &dto.SignIn{
-//		Username: "user@example.com",
-//		Password: "secret123"
-//	 }
-//	 tokens, err := SignIn(credentials)
-func SignIn(signIn dto.SignIn) (*auth.Token, error) {
+// 1. Refuses throttled attempts (see checkSignInThrottle)
+// 2. Looks up user by email address and lifts an expired lockout
+// 3. Validates provided password against stored hash. A failure is recorded and
+// `AUTH_THROTTLE_MAX_ATTEMPTS` failures in the window lock the account
+// 4. Verifies user account is active
+func Authenticate(signIn dto.SignIn, client auth.Client) (*models.User, error) {
+	if err := checkSignInThrottle(signIn.Username, client); err != nil {
+		return nil, err
+	}
+
 	// Get user by email.
 	user := repository.Pool.GetUserByEmail(signIn.Username)
 	if user == nil {
+		recordSignInFailure(signIn.Username, client)
+
 		return nil, errors.New("Invalid email address or password")
 	}
+
+	if isLockedOut(user) {
+		return nil, ErrAccountLocked
+	}
+
 	// Compare a given user password with stored in found user.
 	isValidPassword := utils.ComparePasswords(user.Password, signIn.Password)
 	if !isValidPassword {
+		failures := recordSignInFailure(signIn.Username, client)
+		if failures >= utils.Getenv(auth.ThrottleMaxAttempts, 5) && user.Status == types.UserStatusActive {
+			clearSignInFailures(signIn.Username)
+			lockAccount(user, client)
+
+			return nil, ErrAccountLocked
+		}
+
 		return nil, errors.New("Invalid email address or password")
 	}
 
-	if user.Status != types.UserStatusActive {
-		return nil, errors.New("User is not activated")
+	clearSignInFailures(signIn.Username)
+
+	if err := checkUserStatus(user); err != nil {
+		return nil, err
 	}
 
-	userIDStr := strconv.Itoa(user.ID)
-	// Generate a new pair of access and refresh tokens.
-	tokens, err := GenerateTokens(userIDStr, make([]string, 0))
+	return user, nil
+}
+
+// SignIn authenticates a user and generates access/refresh token pair
+// for a new device session.
+//
+// Parameters:
+//   - signIn: dto.SignIn - Contains validated login credentials:
+//   - Username: Email address used for login
+//   - Password: Plain text password to validate
+//   - client: auth.Client - The device (user agent, IP address) which signs in
+//
+// Returns:
+//   - *auth.Token: Token pair containing access and refresh tokens if successful.
+//     For users with two-factor authentication only `Challenge` is set.
+//   - error: Error if authentication fails:
+//   - Throttled or locked sign-in (see Authenticate)
+//   - Invalid email/password
+//   - User account not active
+//   - Token generation failed
+//   - Session persistence failed
+//
+// Flow:
+// 1. Authenticates the user (see Authenticate)
+// 2. Returns a challenge token when two-factor authentication is enabled
+// (tokens are issued later by `/auth/2fa/verify`)
+// 3. Otherwise, issues the tokens (see IssueTokens)
+//
+// Example:
+//
+//	 credentials := dto.SignIn{
+//		Username: "user@example.com",
+//		Password: "secret123"
+//	 }
+//	 tokens, err := SignIn(credentials, ExtractClient(c))
+func SignIn(signIn dto.SignIn, client auth.Client) (*auth.Token, error) {
+	user, err := Authenticate(signIn, client)
 	if err != nil {
-		log.Errorf("Error while generating tokens %q", err)
 		return nil, err
 	}
 
-	// Set expired days from .env file
-	ttlDays := utils.Getenv(auth.TtlOverDays, 0) // 7 days by default
+	return issueTokensOrChallenge(user, client)
+}
 
-	// Save the refresh token to Redis.
-	expiredTime := time.Duration(ttlDays*24*3600) * time.Second // 604 800 seconds = 7 days
-	if err = cache.Set(userIDStr, tokens.Refresh, expiredTime); err != nil {
-		log.Errorf("Error while caching to token to Redis %q", err)
+// IssueTokens generates access/refresh token pair for a new device session of
+// an already authenticated user.
+//
+// Parameters:
+//   - user: *models.User - The authenticated user
+//   - client: auth.Client - The device (user agent, IP address) which signs in
+//
+// Returns:
+//   - *auth.Token: Token pair containing access and refresh tokens
+//   - error: Error if token generation or session persistence failed
+//
+// Flow:
+// 1. Generates new access/refresh token pair bound to a new session ID and
+// carrying the scopes resolved from the user roles
+// 2. Stores the session with the hashed refresh token
+func IssueTokens(user *models.User, client auth.Client) (*auth.Token, error) {
+	sessionID := uuid.NewString()
+
+	// Generate a new pair of access and refresh tokens.
+	tokens, err := GenerateTokens(strconv.Itoa(user.ID), sessionID, ResolveScopes(user.ID))
+	if err != nil {
+		log.Errorf("Error while generating tokens %q", err)
 		return nil, err
 	}
 
+	// Save the refresh token to the device session.
+	if err = createSession(sessionID, user.ID, tokens.Refresh, client); err != nil {
+		log.Errorf("Error while creating session %q", err)
+		return nil, errors.New("Error occurs while signin user")
+	}
+
 	return tokens, nil
 }
 
@@ -110,10 +187,11 @@ func SignIn(signIn dto.SignIn) (*auth.Token, error) {
 // 2. Checks if email is already registered
 // 3. Creates new user with provided details:
 //   - Hashes the password
-//   - Sets default status to active
+//   - Sets default status to pending
 //   - Sets creation/update timestamps
 //
 // 4. Saves user to database
+// 5. Sends the email verification link (see SendVerificationEmail)
 //
 // Example:
 //
@@ -141,7 +219,7 @@ func SignUp(signUp dto.SignUp) (*models.User, error) {
 	user.Fullname = signUp.Fullname
 	user.Phone = signUp.Phone
 	user.Token = null.String("")
-	user.Status = types.UserStatusActive
+	user.Status = types.UserStatusPending // Activated by email verification
 	user.CreatedAt = time.Now()
 	user.UpdatedAt = null.TimeNow()
 	user.LastAccessAt = null.TimeNow()
@@ -153,10 +231,17 @@ func SignUp(signUp dto.SignUp) (*models.User, error) {
 		return nil, errors.New("Error occurs while signup user")
 	}
 
+	// The account stays usable even when the mail fails. The link can be resent.
+	if err = SendVerificationEmail(user); err != nil {
+		log.Errorf("Error while sending verification email to %q '%v'", user.Email, err)
+	}
+
+	_ = event.Dispatch(userEvents.UserRegistered{User: user})
+
 	return user, nil
 }
 
-// SignOut handles user logout by invalidating both refresh and access tokens
+// SignOut handles user logout by revoking the current session and invalidating the access token
 //
 // Parameters:
 //   - jwtToken: The current access token to be invalidated
@@ -165,17 +250,15 @@ func SignUp(signUp dto.SignUp) (*models.User, error) {
 //   - error: Error if token invalidation fails
 //
 // Flow:
-// 1. Extracts user ID and metadata from the access token
-// 2. Uses the user ID to find and delete the refresh token from Redis
+// 1. Extracts session ID and metadata from the access token
+// 2. Revokes the session so its refresh token can not be used anymore
 // 3. Adds the access token to the blacklist to invalidate it
 //
-// Note that this implements a "logout everywhere" approach by:
-// - Deleting the refresh token to prevent getting new access tokens
-// - Blacklisting the current access token to immediately invalidate it
+// Other devices of the same user stay signed in.
 //
 // Errors:
 //   - Returns error if access token metadata extraction fails
-//   - Returns error if refresh token deletion from Redis fails
+//   - Returns error if the session revocation fails
 //   - Continues execution if blacklisting access token fails (best effort)
 func SignOut(jwtToken string) error {
 	// Extract access token metadata
@@ -185,84 +268,122 @@ func SignOut(jwtToken string) error {
 		return errors.New("Logout error")
 	}
 
-	userID := strconv.Itoa(claims.UserID)
-
-	// Delete refresh token from Redis.
-	if err = cache.Del(userID); err != nil {
-		log.Errorf("Error while delete refresh token from Redis %q", err)
-		return err
+	// Revoke the current device session.
+	session := repository.Pool.GetSessionByID(claims.SessionID)
+	if session != nil && session.UserID == claims.UserID && !session.RevokedAt.Valid {
+		if err = repository.Pool.RevokeSession(session); err != nil {
+			log.Errorf("Error while revoking session %q", err)
+			return errors.New("Logout error")
+		}
 	}
 
 	// Delete access token by send it to black-list
-	deleteToken(jwtToken)
+	deleteToken(claims)
 	return nil
 }
 
-// RefreshToken creates new tokens by validating the existing access and refresh tokens.
+// RefreshToken rotates the refresh token of a session and creates a new access token.
 //
 // Parameters:
 //   - jwtToken: The current access token to be refreshed
-//   - refreshToken: The current refresh token to validate against stored token
+//   - refreshToken: The current refresh token of the session
+//   - client: The device (user agent, IP address) which sends the request
 //
 // Returns:
 //   - *auth.Token: New token pair containing fresh access and refresh tokens
 //   - error: Error if token validation fails or token generation encounters issues
 //
 // Flow:
-// 1. Extracts user ID and metadata from the access token
-// 2. Validates the provided refresh token matches the one stored in Redis for the user
-// 3. Generates new access and refresh token pair
-// 4. Updates the new refresh token in Redis with TTL
-// 5. Blacklists the old access token
+// 1. Extracts user ID and session ID from the access token (an expired access token is accepted)
+// 2. Looks up the refresh token in its family (the session)
+// 3. If the refresh token was already used, revokes the whole family and dispatches RefreshTokenReused
+// 4. Validates the session is active and owned by the token subject
+// 5. Marks the refresh token as used and issues a new one in the same family (scopes are resolved again)
+// 6. Blacklists the old access token
 //
 // Errors:
 //   - Returns error if access token metadata extraction fails
-//   - Returns error if refresh token validation against Redis fails
-//   - Returns error if refresh tokens don't match
-//   - Returns error if generating new tokens fails
-//   - Returns error if storing new refresh token in Redis fails
-func RefreshToken(jwtToken, refreshToken string) (*auth.Token, error) {
-	// Get claims from JWT.
-	claims, err := ExtractTokenMetadata(jwtToken)
+//   - Returns error if the refresh token is unknown or was reused
+//   - Returns error if the session is not found, revoked or expired
+//   - Returns error if generating or storing new tokens fails
+func RefreshToken(jwtToken, refreshToken string, client auth.Client) (*auth.Token, error) {
+	// Get claims from JWT. The access token may already be expired, the session
+	// lifetime is checked below. All other claims are still validated.
+	claims, err := extractTokenMetadata(jwtToken, true)
 	if err != nil {
 		log.Errorf("Error while extracting token metadata %q", err)
 		return nil, errors.New("Refresh token error")
 	}
-	// Define user ID.
-	userID := claims.UserID
-	userIDStr := strconv.Itoa(userID)
 
-	// Get refresh token from Redis.
-	val, err := cache.Get(userIDStr)
-	if err != nil {
-		log.Errorf("Error while getting refresh token from Redis %q", err)
-		return nil, errors.New("Refresh token error")
+	// Get the refresh token from its family.
+	storedToken := repository.Pool.GetRefreshTokenByHash(hashRefreshToken(refreshToken))
+	if storedToken == nil || storedToken.SessionID != claims.SessionID {
+		return nil, errors.New("Refresh token mismatch")
 	}
 
-	if refreshToken != val {
+	// Get the device session.
+	session := repository.Pool.GetSessionByID(storedToken.SessionID)
+	if session == nil || session.UserID != claims.UserID {
 		return nil, errors.New("Refresh token mismatch")
 	}
 
+	// A rotated token was presented again => the family is compromised.
+	if storedToken.UsedAt.Valid {
+		revokeTokenFamily(session, client)
+
+		return nil, errors.New("Refresh token was already used")
+	}
+
+	if !session.IsActive() || storedToken.ExpiresAt.Before(time.Now()) {
+		return nil, errors.New("Session was revoked or expired")
+	}
+
+	// The user was deleted after signing in.
+	user, err := mb.GetModelByID[models.User](claims.UserID)
+	if err != nil || user.DeletedAt.Valid {
+		return nil, errors.New("Session was revoked or expired")
+	}
+
 	// Generate a new pair of access and refresh tokens.
-	tokens, err := GenerateTokens(userIDStr, make([]string, 0))
+	tokens, err := GenerateTokens(strconv.Itoa(claims.UserID), session.ID, ResolveScopes(claims.UserID))
 	if err != nil {
 		log.Errorf("Error while generating JWT Token")
 		return nil, errors.New("Refresh token error")
 	}
 
-	// Set expired days from .env file.
-	ttlDays := utils.Getenv(auth.TtlOverDays, 0)
-	duration := time.Duration(ttlDays*7*24*3600) * time.Second
+	// Rotate refresh token of the session. A concurrent refresh with the same token
+	// may have used it since it was read => the family is compromised.
+	rotated, err := repository.Pool.UseRefreshToken(storedToken, hashRefreshToken(tokens.Refresh))
+	if err != nil {
+		log.Errorf("Refresh token error '%v'", err)
 
-	// Update refresh token to Redis.
-	if err = cache.Set(userIDStr, tokens.Refresh, duration); err != nil {
+		return nil, errors.New("Refresh token error")
+	}
+
+	if !rotated {
+		revokeTokenFamily(session, client)
+
+		return nil, errors.New("Refresh token was already used")
+	}
+
+	if err = issueRefreshToken(session.ID, tokens.Refresh); err != nil {
+		log.Errorf("Refresh token error '%v'", err)
+
+		return nil, errors.New("Refresh token error")
+	}
+
+	session.ExpiresAt = sessionExpiresAt()
+	session.LastUsedAt = null.TimeNow()
+	session.UpdatedAt = null.TimeNow()
+
+	if err = mb.UpdateModel(session); err != nil {
 		log.Errorf("Refresh token error '%v'", err)
 
 		return nil, errors.New("Refresh token error")
 	}
 
 	// Delete JWT token by sending it to blacklist
-	deleteToken(jwtToken)
+	deleteToken(claims)
 
 	return tokens, nil
 }
@@ -270,7 +391,7 @@ func RefreshToken(jwtToken, refreshToken string) (*auth.Token, error) {
 // IsBlockedToken checks if a JWT token has been blacklisted/blocked
 //
 // Parameters:
-//   - jwtToken: The JWT token string to check
+//   - tokenID: The `jti` claim of the JWT token to check
 //
 // Returns:
 //   - bool: true if token is blocked, false otherwise
@@ -279,19 +400,17 @@ func RefreshToken(jwtToken, refreshToken string) (*auth.Token, error) {
 // Flow:
 // 1. Checks if blacklist checking is enabled in config
 // 2. If disabled, returns false immediately
-// 3. Constructs Redis key by combining blacklist prefix with JWT token
+// 3. Constructs Redis key by combining blacklist prefix with the token ID
 // 4. Queries Redis to check if token exists in blacklist
 // 5. Returns true if token value matches blocked status
-func IsBlockedToken(jwtToken string) (bool, error) {
+func IsBlockedToken(tokenID string) (bool, error) {
 	isCheckBlacklist := utils.Getenv(auth.CheckBlacklist, false)
 	if !isCheckBlacklist {
 		return false, nil
 	}
 
-	key := fmt.Sprintf("%s:%s", utils.Getenv(auth.Blacklist, ""), jwtToken)
-
 	// Get blocked JWT in Redis.
-	val, err := cache.Get(key)
+	val, err := cache.Get(blacklistKey(tokenID))
 	if err != nil {
 		return false, nil
 	}
@@ -304,34 +423,87 @@ func IsBlockedToken(jwtToken string) (bool, error) {
 // ======================== Helper Functions ==========================
 // ====================================================================
 
+// checkUserStatus verifies the user account is allowed to sign in.
+func checkUserStatus(user *models.User) error {
+	// Soft-deleted users can not sign in until they are restored.
+	if user.DeletedAt.Valid {
+		return errors.New("User not found")
+	}
+
+	// Signed-up users wait for email verification. Other unverified users are refused by configuration.
+	if !user.VerifiedAt.Valid && (user.Status == types.UserStatusPending || utils.Getenv(auth.RequireVerifiedEmail, false)) {
+		return errors.New("Email address is not verified")
+	}
+
+	if user.Status != types.UserStatusActive {
+		return errors.New("User is not activated")
+	}
+
+	return nil
+}
+
+// issueTokensOrChallenge returns a challenge token when two-factor authentication
+// is enabled (tokens are issued later by `/auth/2fa/verify`). Otherwise, issues the tokens.
+func issueTokensOrChallenge(user *models.User, client auth.Client) (*auth.Token, error) {
+	if IsTwoFactorEnabled(user.ID) {
+		challenge, err := CreateTwoFactorChallenge(user.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &auth.Token{
+			Challenge: challenge,
+		}, nil
+	}
+
+	return IssueTokens(user, client)
+}
+
+// revokeTokenFamily revokes the session owning a replayed refresh token and
+// dispatches the RefreshTokenReused security event.
+func revokeTokenFamily(session *models.UserSession, client auth.Client) {
+	if !session.RevokedAt.Valid {
+		if err := repository.Pool.RevokeSession(session); err != nil {
+			log.Errorf("Error while revoking token family %q", err)
+		}
+	}
+
+	_ = event.Dispatch(authEvents.RefreshTokenReused{
+		UserID:    session.UserID,
+		SessionID: session.ID,
+		UserAgent: client.UserAgent,
+		IPAddress: client.IPAddress,
+	})
+}
+
 // deleteToken adds the JWT token to a blacklist in Redis cache to invalidate it
 //
 // Parameters:
-//   - jwtToken: The JWT access token to be blacklisted
+//   - claims: The metadata of the JWT access token to be blacklisted
 //
 // Returns:
 //   - bool: true if token was successfully blacklisted, false if there was an error
 //
 // Flow:
-// 1. Constructs Redis key by combining blacklist prefix with JWT token
-// 2. Gets TTL duration from environment config
-// 3. Adds token to Redis blacklist with TTL
+// 1. Constructs Redis key by combining blacklist prefix with the token ID (`jti`)
+// 2. Calculates the remaining lifetime of the token
+// 3. Adds token to Redis blacklist with that TTL
 //
-// The blacklisted token will automatically expire after the configured TTL period.
+// The blacklisted token will automatically expire when the token itself expires.
 // This prevents the accumulation of expired blacklist entries in Redis.
 //
 // Errors:
 //   - Returns false if setting token in Redis cache fails
 //   - Logs error details but continues execution
-func deleteToken(jwtToken string) bool {
-	key := fmt.Sprintf("%s:%s", utils.Getenv(auth.Blacklist, ""), jwtToken)
-
-	// Set expired minutes count for a secret key from .env file.
-	ttlMinutes := utils.Getenv(auth.TtlMinutes, 0)
-	expiresTime := time.Duration(ttlMinutes*60) * time.Second
+func deleteToken(claims *TokenMetadata) bool {
+	expiresTime := time.Until(time.Unix(claims.Expires, 0))
+	if expiresTime <= 0 {
+		// Already expired => nothing to block
+		return true
+	}
 
 	// Update refresh token to Redis.
-	if err := cache.Set(key, "blocked", expiresTime); err != nil {
+	if err := cache.Set(blacklistKey(claims.TokenID), "blocked", expiresTime); err != nil {
 		log.Errorf("Delete JWT token error '%v'", err)
 
 		return false
@@ -339,3 +511,8 @@ func deleteToken(jwtToken string) bool {
 
 	return true
 }
+
+// blacklistKey builds the Redis key of a blacklisted token ID.
+func blacklistKey(tokenID string) string {
+	return fmt.Sprintf("%s:%s", utils.Getenv(auth.Blacklist, ""), tokenID)
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"gfly/internal/domain/models"
+	"gfly/internal/domain/models/types"
+	"gfly/internal/domain/repository"
+	"gfly/pkg/modules/auth"
+	"gfly/pkg/modules/auth/dto"
+	authEvents "gfly/pkg/modules/auth/events"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	mb "github.com/gflydev/db"
+	"github.com/gflydev/db/null"
+	"github.com/gflydev/event"
+	"github.com/google/uuid"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// ====================================================================
+// ========================= Main functions ===========================
+// ====================================================================
+
+// StartImpersonation issues a short-lived access token of a user for an admin.
+//
+// Parameters:
+//   - actor: models.User - The admin who impersonates
+//   - userID: int - The ID of the user to impersonate
+//   - impersonate: dto.Impersonate - Contains the reason (audit trail)
+//   - client: auth.Client - The device (user agent, IP address) of the admin
+//
+// Returns:
+//   - *models.Impersonation: The recorded impersonation
+//   - string: The access token of the user with the `act` claim of the admin
+//   - error: Error if the user is the admin, another admin or not found
+//
+// Flow:
+// 1. Refuses to impersonate yourself and other admins (no privilege escalation)
+// 2. Records the impersonation with a new session ID
+// 3. Generates the access token (`AUTH_IMPERSONATION_TTL_MINUTES`, no refresh token)
+// carrying the user scopes and the `act` claim
+// 4. Dispatches ImpersonationStarted
+func StartImpersonation(actor models.User, userID int, impersonate dto.Impersonate, client auth.Client) (*models.Impersonation, string, error) {
+	if actor.ID == userID {
+		return nil, "", errors.New("You can not impersonate yourself")
+	}
+
+	user, err := mb.GetModelByID[models.User](userID)
+	if err != nil || user == nil || user.DeletedAt.Valid {
+		return nil, "", errors.New("User not found")
+	}
+
+	scopes := ResolveScopes(user.ID)
+	if slices.Contains(scopes, auth.RoleScope(string(types.RoleAdmin))) {
+		return nil, "", errors.New("Admin accounts can not be impersonated")
+	}
+
+	ttl := time.Duration(utils.Getenv(auth.ImpersonationTtl, 15)) * time.Minute
+	impersonation := &models.Impersonation{
+		SessionID:  uuid.NewString(),
+		ActorID:    null.Int64(int64(actor.ID)),
+		ActorEmail: actor.Email,
+		UserID:     null.Int64(int64(user.ID)),
+		UserEmail:  user.Email,
+		Reason:     impersonate.Reason,
+		UserAgent:  null.String(truncate(client.UserAgent, 255)),
+		IPAddress:  null.String(client.IPAddress),
+		StartedAt:  time.Now(),
+		ExpiresAt:  time.Now().Add(ttl),
+	}
+
+	if err = mb.CreateModel(impersonation); err != nil {
+		log.Errorf("Error while recording impersonation '%v'", err)
+
+		return nil, "", errors.New("Error occurs while impersonating user")
+	}
+
+	token, err := GenerateImpersonationToken(strconv.Itoa(user.ID), strconv.Itoa(actor.ID), impersonation.SessionID, scopes, ttl)
+	if err != nil {
+		log.Errorf("Error while generating impersonation token '%v'", err)
+
+		return nil, "", errors.New("Error occurs while impersonating user")
+	}
+
+	_ = event.Dispatch(authEvents.ImpersonationStarted{
+		ActorID:   actor.ID,
+		UserID:    user.ID,
+		SessionID: impersonation.SessionID,
+		Reason:    impersonation.Reason,
+		IPAddress: client.IPAddress,
+		ExpiresAt: impersonation.ExpiresAt,
+	})
+
+	return impersonation, token, nil
+}
+
+// StopImpersonation ends the impersonation of the given access token.
+//
+// Parameters:
+//   - jwtToken: string - The impersonation token
+//   - client: auth.Client - The device (user agent, IP address) which stops
+//
+// Returns:
+//   - error: Error if the token is not an impersonation token
+//
+// Flow:
+// 1. Records the end of the impersonation
+// 2. Blacklists the impersonation token
+// 3. Dispatches ImpersonationStopped
+func StopImpersonation(jwtToken string, client auth.Client) error {
+	claims, err := ExtractTokenMetadata(jwtToken)
+	if err != nil || claims.ActorID == 0 {
+		return errors.New("Not an impersonation token")
+	}
+
+	impersonation := repository.Pool.GetImpersonationBySessionID(claims.SessionID)
+	if impersonation == nil || !impersonation.IsBetween(claims.UserID, claims.ActorID) {
+		return errors.New("Not an impersonation token")
+	}
+
+	if !impersonation.EndedAt.Valid {
+		impersonation.EndedAt = null.TimeNow()
+		if err = mb.UpdateModel(impersonation); err != nil {
+			log.Errorf("Error while stopping impersonation '%v'", err)
+
+			return errors.New("Error occurs while stopping impersonation")
+		}
+	}
+
+	deleteToken(claims)
+
+	_ = event.Dispatch(authEvents.ImpersonationStopped{
+		ActorID:   claims.ActorID,
+		UserID:    claims.UserID,
+		SessionID: impersonation.SessionID,
+		IPAddress: client.IPAddress,
+	})
+
+	return nil
+}
+
+// IsActiveImpersonation checks the impersonation of a token was neither stopped nor expired.
+//
+// Parameters:
+//   - claims: *TokenMetadata - The metadata of an impersonation token
+//
+// Returns:
+//   - bool: true if the impersonation is active
+func IsActiveImpersonation(claims *TokenMetadata) bool {
+	impersonation := repository.Pool.GetImpersonationBySessionID(claims.SessionID)
+
+	return impersonation != nil &&
+		impersonation.IsBetween(claims.UserID, claims.ActorID) &&
+		impersonation.IsActive()
+}
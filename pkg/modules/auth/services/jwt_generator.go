@@ -1,29 +1,53 @@
 package services
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"gfly/pkg/modules/auth"
 	"github.com/gflydev/core"
+	"github.com/gflydev/core/errors"
 	"github.com/gflydev/core/log"
 	"github.com/gflydev/core/utils"
 	"github.com/golang-jwt/jwt/v5"
-	"os"
+	"github.com/google/uuid"
 	"strconv"
 	"strings"
 	"time"
 )
 
+// Claims struct to describe the claims of an access token.
+// It carries the RFC 7519 registered claims (exp, iat, nbf, iss, aud, sub, jti)
+// and the private claims of gFly.
+type Claims struct {
+	SessionID string       `json:"sid,omitempty"`
+	Scope     string       `json:"scope,omitempty"` // Space-delimited scopes (RFC 8693)
+	Actor     *ActorClaims `json:"act,omitempty"`   // The impersonating admin (RFC 8693)
+	jwt.RegisteredClaims
+}
+
+// ActorClaims struct to describe the `act` claim of an impersonation token.
+type ActorClaims struct {
+	Subject string `json:"sub"`
+}
+
 // TokenMetadata struct to describe metadata in JWT.
+// ActorID is the ID of the impersonating admin, 0 for regular tokens.
 type TokenMetadata struct {
-	UserID      int
-	Credentials core.Data
-	Expires     int64
+	UserID    int
+	ActorID   int
+	SessionID string
+	TokenID   string
+	Scopes    []string
+	Expires   int64
 }
 
 // GenerateTokens func for generate a new Access & Refresh tokens.
-func GenerateTokens(id string, credentials []string) (*auth.Token, error) {
+// The access token is bound to the given device session via the `sid` claim
+// and carries the granted scopes in the `scope` claim.
+func GenerateTokens(id, sessionID string, scopes []string) (*auth.Token, error) {
 	// Generate JWT Access token.
-	accessToken, err := generateAccessToken(id, credentials)
+	accessToken, err := generateAccessToken(id, sessionID, scopes)
 	if err != nil {
 		// Return token generation error.
 		return nil, err
@@ -42,30 +66,26 @@ func GenerateTokens(id string, credentials []string) (*auth.Token, error) {
 	}, nil
 }
 
-func generateAccessToken(id string, credentials []string) (string, error) {
-	// Get secret key from .env file.
-	secret := utils.Getenv(auth.SecretKey, "")
+// GenerateImpersonationToken func for generate a short-lived access token of the user `id`
+// on behalf of the admin `actorID` (`act` claim). No refresh token is issued.
+func GenerateImpersonationToken(id, actorID, sessionID string, scopes []string, ttl time.Duration) (string, error) {
+	claims := newClaims(id, sessionID, scopes, ttl)
+	claims.Actor = &ActorClaims{
+		Subject: actorID,
+	}
+
+	return signToken(claims)
+}
 
+func generateAccessToken(id, sessionID string, scopes []string) (string, error) {
 	// Set expired minutes count for a secret key from .env file.
 	ttlMinutes := utils.Getenv(auth.TtlMinutes, 0)
 
 	// Create a new claims.
-	claims := jwt.MapClaims{}
-
-	// Set public claims:
-	claims["id"] = id
-	claims["expires"] = time.Now().Add(time.Minute * time.Duration(ttlMinutes)).Unix()
-
-	// Set private token credentials:
-	for _, credential := range credentials {
-		claims[credential] = true
-	}
-
-	// Create a new JWT access token with claims.
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	claims := newClaims(id, sessionID, scopes, time.Minute*time.Duration(ttlMinutes))
 
-	// Generate token.
-	t, err := token.SignedString([]byte(secret))
+	// Create and sign a new JWT access token with the current signing key.
+	t, err := signToken(claims)
 	if err != nil {
 		// Return error, it JWT token generation failed.
 		return "", err
@@ -74,6 +94,25 @@ func generateAccessToken(id string, credentials []string) (string, error) {
 	return t, nil
 }
 
+// newClaims creates the claims of an access token which expires after `ttl`.
+func newClaims(id, sessionID string, scopes []string, ttl time.Duration) Claims {
+	now := time.Now()
+
+	return Claims{
+		SessionID: sessionID,
+		Scope:     strings.Join(scopes, " "),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Subject:   id,
+			Issuer:    utils.Getenv(auth.Issuer, ""),
+			Audience:  audiences(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+}
+
 // IsValidRefreshToken func for parse second argument from refresh token.
 // A refresh token is valid is not expired.
 func IsValidRefreshToken(refreshToken string) bool {
@@ -110,33 +149,58 @@ func ExtractToken(c *core.Ctx) string {
 	return ""
 }
 
-// ExtractTokenMetadata func to extract metadata from JWT.
+// ExtractTokenMetadata func to extract metadata from a valid JWT.
+// Signature, algorithm and registered claims (exp, nbf, iat, iss, aud) are verified.
 func ExtractTokenMetadata(tokenString string) (*TokenMetadata, error) {
-	token, err := verifyToken(tokenString)
+	return extractTokenMetadata(tokenString, false)
+}
+
+// extractTokenMetadata parses the JWT and converts its claims to TokenMetadata.
+// With `allowExpired`, an expired token is accepted (see verifyToken).
+func extractTokenMetadata(tokenString string, allowExpired bool) (*TokenMetadata, error) {
+	token, err := verifyToken(tokenString, allowExpired)
 	if err != nil {
 		return nil, err
 	}
 
-	// Setting and checking token and credentials.
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if ok && token.Valid {
-		userID, _ := strconv.Atoi(claims["id"].(string))
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid {
+		return nil, errors.New("Invalid JWT claims")
+	}
 
-		expires := int64(claims["expires"].(float64))
+	userID, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		return nil, errors.New("Invalid JWT subject %q", claims.Subject)
+	}
 
-		credentials := make(core.Data)
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		return nil, errors.New("Missing JWT claims jti/exp")
+	}
 
-		return &TokenMetadata{
-			UserID:      userID,
-			Credentials: credentials,
-			Expires:     expires,
-		}, nil
+	actorID := 0
+	if claims.Actor != nil {
+		if actorID, err = strconv.Atoi(claims.Actor.Subject); err != nil || actorID == 0 {
+			return nil, errors.New("Invalid JWT actor %q", claims.Actor.Subject)
+		}
 	}
-	return nil, err
+
+	return &TokenMetadata{
+		UserID:    userID,
+		ActorID:   actorID,
+		SessionID: claims.SessionID,
+		TokenID:   claims.ID,
+		Scopes:    strings.Fields(claims.Scope),
+		Expires:   claims.ExpiresAt.Unix(),
+	}, nil
 }
 
 func generateRefreshToken() (string, error) {
-	hash := utils.Sha256(utils.Getenv(auth.RefreshKey, "") + time.Now().String())
+	// The hash of the refresh token is its lookup key, so it must be unpredictable and unique.
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	hash := hex.EncodeToString(random)
 
 	// Get expired days for refresh key from .env file.
 	overDays := utils.Getenv(auth.TtlOverDays, 0)
@@ -150,17 +214,72 @@ func generateRefreshToken() (string, error) {
 	return t, nil
 }
 
-// verifyToken function will parse, validate and verify the signature
-func verifyToken(tokenString string) (*jwt.Token, error) {
-	token, err := jwt.Parse(tokenString, jwtKeyFunc)
+// verifyToken function will parse, validate and verify the signature.
+// The signing algorithm is pinned to the configured `JWT_ALGORITHM`, the issuer
+// and audience are validated against `JWT_ISSUER` and `JWT_AUDIENCE` when configured.
+// With `allowExpired`, only the `exp` claim is not validated: the signature and all
+// other claims still are.
+func verifyToken(tokenString string, allowExpired bool) (*jwt.Token, error) {
+	ring, err := loadKeyring()
 	if err != nil {
 		return nil, err
 	}
+
+	validatorOptions := []jwt.ParserOption{
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(time.Duration(utils.Getenv(auth.Leeway, 0)) * time.Second),
+	}
+
+	if issuer := utils.Getenv(auth.Issuer, ""); issuer != "" {
+		validatorOptions = append(validatorOptions, jwt.WithIssuer(issuer))
+	}
+
+	if aud := audiences(); len(aud) > 0 {
+		validatorOptions = append(validatorOptions, jwt.WithAudience(aud...))
+	}
+
+	if !allowExpired {
+		parserOptions := append(validatorOptions, jwt.WithValidMethods([]string{ring.method.Alg()}), jwt.WithExpirationRequired())
+
+		token, err := jwt.ParseWithClaims(tokenString, &Claims{}, ring.verificationKey, parserOptions...)
+		if err != nil {
+			return nil, err
+		}
+		return token, nil
+	}
+
+	// Verify the signature only, then validate the claims without `exp`.
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, ring.verificationKey,
+		jwt.WithValidMethods([]string{ring.method.Alg()}),
+		jwt.WithoutClaimsValidation(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*Claims)
+	if !ok {
+		return nil, errors.New("Invalid JWT claims")
+	}
+
+	unexpiredClaims := *claims
+	unexpiredClaims.ExpiresAt = nil
+	if err = jwt.NewValidator(validatorOptions...).Validate(unexpiredClaims); err != nil {
+		return nil, err
+	}
+
 	return token, nil
 }
 
-// jwtKeyFunc will receive the parsed token and should return the cryptographic key
-// for verifying the signature
-func jwtKeyFunc(_ *jwt.Token) (interface{}, error) {
-	return []byte(os.Getenv(auth.SecretKey)), nil
+// audiences reads the comma separated `JWT_AUDIENCE` setting.
+func audiences() jwt.ClaimStrings {
+	var aud jwt.ClaimStrings
+
+	for _, item := range strings.Split(utils.Getenv(auth.Audience, ""), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			aud = append(aud, item)
+		}
+	}
+
+	return aud
 }
@@ -0,0 +1,227 @@
+package services
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"gfly/pkg/modules/auth"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	"github.com/golang-jwt/jwt/v5"
+	"os"
+	"strings"
+	"sync"
+)
+
+// PublicKey struct to describe a public verification key of the keyring.
+type PublicKey struct {
+	ID        string
+	Algorithm string
+	Key       crypto.PublicKey
+}
+
+// signingKey struct to describe a private key loaded from file.
+type signingKey struct {
+	id         string
+	privateKey crypto.PrivateKey
+	publicKey  crypto.PublicKey
+}
+
+// keyring struct to describe all configured signing keys.
+type keyring struct {
+	method  jwt.SigningMethod
+	keys    map[string]signingKey
+	ordered []string // Key IDs in configuration order
+	current string   // Key ID used for signing new tokens
+}
+
+var (
+	jwtKeyring     *keyring
+	jwtKeyringLock sync.Mutex
+)
+
+// ====================================================================
+// ========================= Main functions ===========================
+// ====================================================================
+
+// PublicKeys returns all public keys which are accepted for token verification.
+// HS256 (shared secret) has no public key, so the list is empty in that case.
+func PublicKeys() []PublicKey {
+	ring, err := loadKeyring()
+	if err != nil {
+		return []PublicKey{}
+	}
+
+	publicKeys := make([]PublicKey, 0, len(ring.ordered))
+	for _, kid := range ring.ordered {
+		publicKeys = append(publicKeys, PublicKey{
+			ID:        kid,
+			Algorithm: ring.method.Alg(),
+			Key:       ring.keys[kid].publicKey,
+		})
+	}
+
+	return publicKeys
+}
+
+// ====================================================================
+// ======================== Helper Functions ==========================
+// ====================================================================
+
+// loadKeyring loads the signing method and keys from `.env` once.
+// Only a successful load is cached: after an error (Ex: a key file which can not be read yet),
+// the next call tries again.
+//
+// Configurations:
+//   - JWT_ALGORITHM: HS256 (default), RS256 or EdDSA
+//   - JWT_SIGNING_KEYS: "kid:path/to/private.pem,kid2:path/to/other.pem" (RS256/EdDSA only)
+//   - JWT_SIGNING_KID: Key ID used for signing (the first key by default)
+func loadKeyring() (*keyring, error) {
+	jwtKeyringLock.Lock()
+	defer jwtKeyringLock.Unlock()
+
+	if jwtKeyring != nil {
+		return jwtKeyring, nil
+	}
+
+	ring, err := newKeyring(
+		utils.Getenv(auth.Algorithm, jwt.SigningMethodHS256.Alg()),
+		utils.Getenv(auth.SigningKeys, ""),
+		utils.Getenv(auth.SigningKid, ""),
+	)
+	if err != nil {
+		log.Errorf("Load JWT signing keys error '%v'", err)
+
+		return nil, err
+	}
+
+	jwtKeyring = ring
+
+	return jwtKeyring, nil
+}
+
+// newKeyring parses the key configuration and loads all key files.
+func newKeyring(algorithm, keyFiles, currentKid string) (*keyring, error) {
+	ring := &keyring{
+		keys: map[string]signingKey{},
+	}
+
+	switch algorithm {
+	case jwt.SigningMethodHS256.Alg():
+		ring.method = jwt.SigningMethodHS256
+
+		return ring, nil
+	case jwt.SigningMethodRS256.Alg():
+		ring.method = jwt.SigningMethodRS256
+	case jwt.SigningMethodEdDSA.Alg():
+		ring.method = jwt.SigningMethodEdDSA
+	default:
+		return nil, errors.New("Unsupported JWT algorithm %q", algorithm)
+	}
+
+	for _, entry := range strings.Split(keyFiles, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		kid, path, found := strings.Cut(entry, ":")
+		if !found || kid == "" || path == "" {
+			return nil, errors.New("Invalid JWT signing key %q, expected \"kid:path\"", entry)
+		}
+
+		key, err := readSigningKey(ring.method, kid, path)
+		if err != nil {
+			return nil, err
+		}
+
+		ring.keys[kid] = key
+		ring.ordered = append(ring.ordered, kid)
+	}
+
+	if len(ring.ordered) == 0 {
+		return nil, errors.New("Missing JWT signing keys for algorithm %q", algorithm)
+	}
+
+	ring.current = ring.ordered[0]
+	if currentKid != "" {
+		if _, ok := ring.keys[currentKid]; !ok {
+			return nil, errors.New("Unknown JWT signing key ID %q", currentKid)
+		}
+		ring.current = currentKid
+	}
+
+	return ring, nil
+}
+
+// readSigningKey reads a PEM private key file for the given signing method.
+func readSigningKey(method jwt.SigningMethod, kid, path string) (signingKey, error) {
+	pemData, err := os.ReadFile(path) // #nosec G304 -- Key path comes from trusted configuration
+	if err != nil {
+		return signingKey{}, errors.New("Read JWT signing key %q error '%v'", kid, err)
+	}
+
+	switch method {
+	case jwt.SigningMethodRS256:
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(pemData)
+		if err != nil {
+			return signingKey{}, errors.New("Parse RSA signing key %q error '%v'", kid, err)
+		}
+
+		return signingKey{id: kid, privateKey: privateKey, publicKey: &privateKey.PublicKey}, nil
+	default:
+		privateKey, err := jwt.ParseEdPrivateKeyFromPEM(pemData)
+		if err != nil {
+			return signingKey{}, errors.New("Parse Ed25519 signing key %q error '%v'", kid, err)
+		}
+
+		edKey, ok := privateKey.(ed25519.PrivateKey)
+		if !ok {
+			return signingKey{}, errors.New("JWT signing key %q is not an Ed25519 key", kid)
+		}
+
+		return signingKey{id: kid, privateKey: edKey, publicKey: edKey.Public()}, nil
+	}
+}
+
+// signToken signs the claims with the current signing key and sets the `kid` header.
+func signToken(claims jwt.Claims) (string, error) {
+	ring, err := loadKeyring()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(ring.method, claims)
+
+	// Shared secret (HS256)
+	if ring.method == jwt.SigningMethodHS256 {
+		return token.SignedString([]byte(utils.Getenv(auth.SecretKey, "")))
+	}
+
+	key := ring.keys[ring.current]
+	token.Header["kid"] = key.id
+
+	return token.SignedString(key.privateKey)
+}
+
+// verificationKey returns the key which verifies the given token.
+// The token must reference a known key via its `kid` header (RS256/EdDSA).
+func (r *keyring) verificationKey(token *jwt.Token) (any, error) {
+	if r.method == jwt.SigningMethodHS256 {
+		return []byte(utils.Getenv(auth.SecretKey, "")), nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, ok := r.keys[kid]
+	if !ok {
+		return nil, errors.New("Unknown JWT signing key ID %q", kid)
+	}
+
+	switch publicKey := key.publicKey.(type) {
+	case *rsa.PublicKey, ed25519.PublicKey:
+		return publicKey, nil
+	default:
+		return nil, errors.New("Unsupported JWT signing key %q", kid)
+	}
+}
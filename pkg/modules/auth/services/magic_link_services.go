@@ -0,0 +1,188 @@
+package services
+
+import (
+	"crypto/hmac"
+	"fmt"
+	"gfly/internal/domain/models"
+	"gfly/internal/domain/repository"
+	"gfly/pkg/modules/auth"
+	"gfly/pkg/modules/auth/dto"
+	"gfly/pkg/modules/auth/notifications"
+	pkgUtils "gfly/pkg/utils"
+	"github.com/gflydev/cache"
+	"github.com/gflydev/core"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	mb "github.com/gflydev/db"
+	"github.com/gflydev/notification"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrMagicLinkTooEarly error when a sign-in link is requested again too early.
+var ErrMagicLinkTooEarly = errors.New("Sign-in link was sent recently, please try again later")
+
+// ====================================================================
+// ========================= Main functions ===========================
+// ====================================================================
+
+// SendMagicLink emails a single-use, short-lived signed sign-in link.
+// Unknown addresses and accounts which can not sign in are ignored silently,
+// so the endpoint can not be used to discover registered emails.
+//
+// Parameters:
+//   - magicLink: dto.MagicLink - Contains the email address
+//
+// Returns:
+//   - error: ErrMagicLinkTooEarly within `AUTH_MAGIC_LINK_RESEND_SECONDS` after the previous request,
+//     or an error if sending fails
+//
+// Link format: `<APP_URL><AUTH_MAGIC_LINK_URI>?id=<id>&expires=<unix>&token=<token>&signature=<hmac>`
+// The token is kept in cache (`AUTH_MAGIC_LINK_TTL_MINUTES`) and removed on first use.
+func SendMagicLink(magicLink dto.MagicLink) error {
+	email := strings.ToLower(magicLink.Email)
+	key := magicLinkResendKey(email)
+
+	if val, err := cache.Get(key); err == nil && val != nil {
+		return ErrMagicLinkTooEarly
+	}
+
+	interval := utils.Getenv(auth.MagicLinkResend, 60)
+	if err := cache.Set(key, "1", time.Duration(interval)*time.Second); err != nil {
+		log.Errorf("Store magic link resend error '%v'", err)
+	}
+
+	user := repository.Pool.GetUserByEmail(email)
+	if user == nil || isLockedOut(user) || checkUserStatus(user) != nil {
+		return nil
+	}
+
+	ttlMinutes := utils.Getenv(auth.MagicLinkTtl, 15)
+	expires := time.Now().Add(time.Duration(ttlMinutes) * time.Minute).Unix()
+	token := utils.Token(strconv.Itoa(user.ID))
+
+	signature, err := magicLinkSignature(user.ID, user.Email, expires, token)
+	if err != nil {
+		log.Errorf("Sign magic link error '%v'", err)
+
+		return errors.New("Error occurs while sending sign-in link")
+	}
+
+	if err = cache.Set(magicLinkKey(token), strconv.Itoa(user.ID), time.Duration(ttlMinutes)*time.Minute); err != nil {
+		log.Errorf("Store magic link error '%v'", err)
+
+		return errors.New("Error occurs while sending sign-in link")
+	}
+
+	query := url.Values{}
+	query.Set("id", strconv.Itoa(user.ID))
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("token", token)
+	query.Set("signature", signature)
+
+	if err := notification.Send(notifications.MagicLink{
+		Email:      user.Email,
+		Name:       user.Fullname,
+		URL:        fmt.Sprintf("%s%s?%s", core.AppURL, utils.Getenv(auth.MagicLinkUri, "/magic-link"), query.Encode()),
+		TtlMinutes: ttlMinutes,
+	}); err != nil {
+		log.Errorf("Send magic link error '%v'", err)
+
+		return errors.New("Error occurs while sending sign-in link")
+	}
+
+	return nil
+}
+
+// ConsumeMagicLink checks the signed link and returns the user who can sign in.
+//
+// Parameters:
+//   - consume: dto.ConsumeMagicLink - Contains ID, expiry, token and signature of the link
+//
+// Returns:
+//   - *models.User: The user of the link
+//   - error: Error if the link is invalid, expired or already used, or the user can not sign in
+//
+// Flow:
+// 1. Refuses expired links
+// 2. Loads the user and compares the signature (the email is signed, so a link
+// becomes invalid when the email changes)
+// 3. Reads and removes the token from cache in a single command (single use)
+// 4. Applies the same lockout and status checks as the password sign-in
+func ConsumeMagicLink(consume dto.ConsumeMagicLink) (*models.User, error) {
+	if time.Now().Unix() > consume.Expires {
+		return nil, errors.New("Sign-in link is expired")
+	}
+
+	user, err := mb.GetModelByID[models.User](consume.ID)
+	if err != nil || user == nil {
+		return nil, errors.New("Invalid sign-in link")
+	}
+
+	expected, err := magicLinkSignature(user.ID, user.Email, consume.Expires, consume.Token)
+	if err != nil {
+		log.Errorf("Check magic link error '%v'", err)
+
+		return nil, errors.New("Invalid sign-in link")
+	}
+
+	if !hmac.Equal([]byte(expected), []byte(consume.Signature)) {
+		return nil, errors.New("Invalid sign-in link")
+	}
+
+	// Read and delete at once: only one of concurrent requests gets the token
+	val, err := pkgUtils.CachePull(magicLinkKey(consume.Token))
+	if err != nil || val != strconv.Itoa(user.ID) {
+		return nil, errors.New("Sign-in link was already used or is expired")
+	}
+
+	if isLockedOut(user) {
+		return nil, ErrAccountLocked
+	}
+
+	if err = checkUserStatus(user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// MagicLinkSignIn exchanges a signed link for the same result as SignIn.
+//
+// Parameters:
+//   - consume: dto.ConsumeMagicLink - Contains ID, expiry, token and signature of the link
+//   - client: auth.Client - The device (user agent, IP address) which signs in
+//
+// Returns:
+//   - *auth.Token: Token pair, or only `Challenge` for users with two-factor authentication
+//   - error: Error if the link is invalid (see ConsumeMagicLink) or token generation fails
+func MagicLinkSignIn(consume dto.ConsumeMagicLink, client auth.Client) (*auth.Token, error) {
+	user, err := ConsumeMagicLink(consume)
+	if err != nil {
+		return nil, err
+	}
+
+	return issueTokensOrChallenge(user, client)
+}
+
+// ====================================================================
+// ======================== Helper Functions ==========================
+// ====================================================================
+
+// magicLinkSignature signs the link data (see signLink).
+func magicLinkSignature(userID int, email string, expires int64, token string) (string, error) {
+	return signLink(fmt.Sprintf("magic_link:%d:%s:%d:%s", userID, strings.ToLower(email), expires, token))
+}
+
+// magicLinkKey builds the cache key of a sign-in link token.
+func magicLinkKey(token string) string {
+	return fmt.Sprintf("magic_link:%s", utils.Sha256(token))
+}
+
+// magicLinkResendKey builds the cache key limiting sign-in link requests of an email address.
+func magicLinkResendKey(email string) string {
+	return fmt.Sprintf("magic_link_resend:%s", utils.Sha256(email))
+}
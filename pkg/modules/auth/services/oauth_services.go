@@ -0,0 +1,236 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"gfly/internal/domain/models"
+	"gfly/internal/domain/models/types"
+	"gfly/internal/domain/repository"
+	"gfly/pkg/modules/auth"
+	"gfly/pkg/modules/auth/dto"
+	"gfly/pkg/modules/auth/oauth"
+	"github.com/gflydev/cache"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	mb "github.com/gflydev/db"
+	"github.com/gflydev/db/null"
+	"time"
+)
+
+// oauthState struct to describe a pending authorization request.
+type oauthState struct {
+	Provider     string    `json:"provider"`
+	Type         auth.Type `json:"type"`
+	CodeVerifier string    `json:"code_verifier"`
+	Nonce        string    `json:"nonce"`
+}
+
+// ====================================================================
+// ========================= Main functions ===========================
+// ====================================================================
+
+// StartOAuth starts the authorization code flow (with PKCE) of an identity provider.
+//
+// Parameters:
+//   - providerName: string - The name of a registered provider (see oauth.Register)
+//   - authType: auth.Type - Issue tokens (TypeAPI) or open a session (TypeWeb) at the callback
+//
+// Returns:
+//   - string: The URL of the provider's consent page
+//   - error: Error if the provider is unknown or not available
+//
+// Flow:
+// 1. Generates the state, the nonce and the PKCE code verifier
+// 2. Stores them in cache (`AUTH_OAUTH_STATE_TTL_MINUTES`) under the state
+// 3. Returns the authorization URL with the S256 code challenge
+func StartOAuth(providerName string, authType auth.Type) (string, error) {
+	provider, err := oauth.Get(providerName)
+	if err != nil {
+		return "", err
+	}
+
+	state := oauth.RandomString(32)
+	pending := oauthState{
+		Provider:     provider.Name(),
+		Type:         authType,
+		CodeVerifier: oauth.NewCodeVerifier(),
+		Nonce:        oauth.RandomString(16),
+	}
+
+	authURL, err := provider.AuthCodeURL(state, oauth.CodeChallenge(pending.CodeVerifier), pending.Nonce)
+	if err != nil {
+		return "", err
+	}
+
+	value, _ := json.Marshal(pending)
+	ttlMinutes := utils.Getenv(auth.OAuthStateTtl, 10)
+	if err = cache.Set(oauthStateKey(state), string(value), time.Duration(ttlMinutes)*time.Minute); err != nil {
+		log.Errorf("Store OAuth state error '%v'", err)
+
+		return "", errors.New("Error occurs while signin user")
+	}
+
+	return authURL, nil
+}
+
+// CompleteOAuth finishes the authorization code flow and returns the signed-in user.
+//
+// Parameters:
+//   - providerName: string - The provider name of the callback route
+//   - callback: dto.OAuthCallback - The authorization code and the state
+//
+// Returns:
+//   - *models.User: The linked or created user
+//   - auth.Type: The auth type given to StartOAuth (also set with the error once the state is valid)
+//   - error: Error if the state is invalid, the code is rejected, the email is
+//     not verified by the provider or the user can not sign in
+//
+// Flow:
+// 1. Consumes the state (single use)
+// 2. Exchanges the code and the code verifier for the verified identity
+// 3. Finds the user by the linked identity, then by the verified email. Otherwise,
+// creates an active user. The identity is linked to the user.
+// 4. Applies the same lockout and status checks as the password sign-in
+func CompleteOAuth(providerName string, callback dto.OAuthCallback) (*models.User, auth.Type, error) {
+	pending, err := consumeOAuthState(callback.State)
+	if err != nil || pending.Provider != providerName {
+		return nil, "", errors.New("Invalid or expired OAuth state")
+	}
+
+	provider, err := oauth.Get(providerName)
+	if err != nil {
+		return nil, pending.Type, err
+	}
+
+	identity, err := provider.Exchange(callback.Code, pending.CodeVerifier, pending.Nonce)
+	if err != nil {
+		return nil, pending.Type, err
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, pending.Type, errors.New("Email address is not verified by the identity provider")
+	}
+
+	user, err := linkOAuthUser(identity)
+	if err != nil {
+		return nil, pending.Type, err
+	}
+
+	if isLockedOut(user) {
+		return nil, pending.Type, ErrAccountLocked
+	}
+
+	if err = checkUserStatus(user); err != nil {
+		return nil, pending.Type, err
+	}
+
+	return user, pending.Type, nil
+}
+
+// ====================================================================
+// ======================== Helper Functions ==========================
+// ====================================================================
+
+// linkOAuthUser finds or creates the user of an external identity and links them.
+func linkOAuthUser(identity *oauth.Identity) (*models.User, error) {
+	link := repository.Pool.GetUserIdentity(identity.Provider, identity.Subject)
+	if link != nil {
+		user, err := mb.GetModelByID[models.User](link.UserID)
+		if err != nil || user == nil {
+			return nil, errors.New("User not found")
+		}
+
+		return user, nil
+	}
+
+	user := repository.Pool.GetUserByEmail(identity.Email)
+	if user == nil {
+		var err error
+		if user, err = createOAuthUser(identity); err != nil {
+			return nil, err
+		}
+	} else if !user.VerifiedAt.Valid {
+		// The provider verified the email address => same as the verification link
+		user.VerifiedAt = null.TimeNow()
+		if user.Status == types.UserStatusPending {
+			user.Status = types.UserStatusActive
+		}
+		user.UpdatedAt = null.TimeNow()
+
+		if err := mb.UpdateModel(user); err != nil {
+			log.Errorf("Error while verifying email of user %d '%v'", user.ID, err)
+
+			return nil, errors.New("Error occurs while signin user")
+		}
+	}
+
+	if err := mb.CreateModel(&models.UserIdentity{
+		UserID:    user.ID,
+		Provider:  identity.Provider,
+		Subject:   identity.Subject,
+		Email:     identity.Email,
+		CreatedAt: time.Now(),
+		UpdatedAt: null.TimeNow(),
+	}); err != nil {
+		log.Errorf("Error while linking %s identity to user %d '%v'", identity.Provider, user.ID, err)
+
+		return nil, errors.New("Error occurs while signin user")
+	}
+
+	return user, nil
+}
+
+// createOAuthUser creates an active user with a random password for an external identity.
+// The user can set a password later with the forgot password flow.
+func createOAuthUser(identity *oauth.Identity) (*models.User, error) {
+	fullname := identity.Name
+	if fullname == "" {
+		fullname = identity.Email
+	}
+
+	user := &models.User{}
+	user.Email = identity.Email
+	user.Password = utils.GeneratePassword(utils.Token())
+	user.Fullname = fullname
+	user.Token = null.String("")
+	user.Status = types.UserStatusActive
+	user.VerifiedAt = null.TimeNow()
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = null.TimeNow()
+	user.LastAccessAt = null.TimeNow()
+	if identity.Picture != "" {
+		user.Avatar = null.String(identity.Picture)
+	}
+
+	if err := mb.CreateModel(user); err != nil {
+		log.Errorf("Error while creating new user %q with data '%v'", err, user)
+
+		return nil, errors.New("Error occurs while signin user")
+	}
+
+	return user, nil
+}
+
+// consumeOAuthState reads and deletes a pending authorization request.
+func consumeOAuthState(state string) (*oauthState, error) {
+	key := oauthStateKey(state)
+
+	val, err := cache.Get(key)
+	if err != nil || val == nil {
+		return nil, errors.New("Invalid or expired OAuth state")
+	}
+	_ = cache.Del(key)
+
+	var pending oauthState
+	if err = json.Unmarshal([]byte(fmt.Sprint(val)), &pending); err != nil {
+		return nil, err
+	}
+
+	return &pending, nil
+}
+
+// oauthStateKey builds the cache key of a pending authorization request.
+func oauthStateKey(state string) string {
+	return fmt.Sprintf("oauth_state:%s", utils.Sha256(state))
+}
@@ -2,10 +2,12 @@ package services
 
 import (
 	"errors"
-	"fmt"
+	"gfly/internal/domain/models"
 	"gfly/internal/domain/repository"
+	"gfly/pkg/modules/auth"
 	"gfly/pkg/modules/auth/dto"
 	"gfly/pkg/modules/auth/notifications"
+	"gfly/pkg/modules/auth/password"
 	"github.com/gflydev/core/log"
 	"github.com/gflydev/core/utils"
 	mb "github.com/gflydev/db"
@@ -23,9 +25,10 @@ import (
 //
 // This function handles the forgot password flow by:
 // 1. Looking up the user by their email address
-// 2. Generating a secure reset token using SHA256 hash of email + timestamp
-// 3. Saving the reset token to the user's record
-// 4. Sending a password reset email with instructions
+// 2. Generating a cryptographically random reset token
+// 3. Invalidating older reset tokens of the user
+// 4. Saving only the SHA256 hash of the token with its expiry (`AUTH_RESET_PASSWORD_TTL_MINUTES`)
+// 5. Sending a password reset email with the link to `AUTH_RESET_PASSWORD_URI`
 //
 // Parameters:
 //   - forgotPassword: dto.ForgotPassword struct containing the user's email address
@@ -44,23 +47,38 @@ func ForgotPassword(forgotPassword dto.ForgotPassword) error {
 	// Get user by ID.
 	user := repository.Pool.GetUserByEmail(forgotPassword.Username)
 	// Item not found error
-	if user == nil {
+	if user == nil || user.DeletedAt.Valid {
 		return errors.New("invalid input data")
 	}
 
-	// Create a new SHA256 hash.
-	hash := utils.Sha256(user.Email, time.Now().Unix())
+	// Only the latest token can be used
+	if err := repository.Pool.DeletePasswordResetTokensByUserID(user.ID); err != nil {
+		log.Errorf("Service forgot password error '%v'", err)
 
-	user.Token = dbNull.String(interpolateToken(hash))
-	user.UpdatedAt = dbNull.TimeNow()
+		return errors.New("service error")
+	}
+
+	// Create a random token.
+	token := utils.Token()
+	ttlMinutes := utils.Getenv(auth.ResetPasswordTtl, 60)
+
+	if err := mb.CreateModel(&models.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hashResetToken(token),
+		ExpiresAt: time.Now().Add(time.Duration(ttlMinutes) * time.Minute),
+		CreatedAt: time.Now(),
+	}); err != nil {
+		log.Errorf("Service forgot password error '%v'", err)
 
-	if err := mb.UpdateModel(user); err != nil {
 		return errors.New("service error")
 	}
 
 	// Send notification via mail
 	if err := notification.Send(notifications.ResetPassword{
+		ID:    user.ID,
 		Email: user.Email,
+		Name:  user.Fullname,
+		Token: token,
 	}); err != nil {
 		log.Errorf("Service forgot password error '%v'", err)
 
@@ -73,10 +91,13 @@ func ForgotPassword(forgotPassword dto.ForgotPassword) error {
 // ChangePassword processes a password reset request and updates the user's password.
 //
 // This function handles the password reset flow by:
-// 1. Verifying the reset token and retrieving associated user
-// 2. Clearing the reset token
-// 3. Updating the password with a new hashed value
-// 4. Sending email notification about password change
+// 1. Verifying the reset token is known, unused and not expired
+// 2. Refusing a password with the email or name of the user, or which was used recently (see password.CheckHistory)
+// 3. Marking the reset token as used, a concurrent request with the same token fails
+// 4. Updating the password with a new hashed value and remembering the previous one
+// 5. Removing all reset tokens of the user
+// 6. Revoking all device sessions of the user
+// 7. Sending email notification about password change
 //
 // Parameters:
 //   - resetPassword: dto.ResetPassword struct containing the new password and reset token
@@ -93,25 +114,61 @@ func ForgotPassword(forgotPassword dto.ForgotPassword) error {
 //		Token: "abc123token",
 //	})
 func ChangePassword(resetPassword dto.ResetPassword) error {
+	resetToken := repository.Pool.GetPasswordResetTokenByHash(hashResetToken(resetPassword.Token))
+	if resetToken == nil || !resetToken.IsUsable() {
+		return errors.New("invalid input data")
+	}
+
 	// Get user by ID.
-	user := repository.Pool.GetUserByToken(interpolateToken(resetPassword.Token))
+	user, err := mb.GetModelByID[models.User](resetToken.UserID)
 	// Item not found error
-	if user == nil {
+	if err != nil || user == nil || user.DeletedAt.Valid {
 		return errors.New("invalid input data")
 	}
 
-	user.Token = dbNull.String("")
+	if err = password.CheckPersonalData(resetPassword.Password, user.Email, user.Fullname); err != nil {
+		return errors.New("password " + err.Error())
+	}
+
+	if err = password.CheckHistory(user, resetPassword.Password); err != nil {
+		return errors.New("password " + err.Error())
+	}
+
+	// Single use
+	used, err := repository.Pool.UsePasswordResetToken(resetToken)
+	if err != nil {
+		log.Errorf("Change password error '%v'", err)
+
+		return errors.New("service error")
+	}
+
+	if !used {
+		return errors.New("invalid input data")
+	}
+
+	previousPassword := user.Password
 	user.UpdatedAt = dbNull.TimeNow()
 	user.Password = utils.GeneratePassword(resetPassword.Password)
 
-	if err := mb.UpdateModel(user); err != nil {
+	if err = mb.UpdateModel(user); err != nil {
 		log.Errorf("Change password error '%v'", err)
 
 		return errors.New("service error")
 	}
 
+	password.RememberPassword(user.ID, previousPassword)
+
+	if err = repository.Pool.DeletePasswordResetTokensByUserID(user.ID); err != nil {
+		log.Errorf("Change password error '%v'", err)
+	}
+
+	// Sign out all devices
+	if err = repository.Pool.RevokeSessionsByUserID(user.ID); err != nil {
+		log.Errorf("Change password error '%v'", err)
+	}
+
 	// Send notification via mail
-	if err := notification.Send(notifications.ChangePassword{
+	if err = notification.Send(notifications.ChangePassword{
 		Email: user.Email,
 		Name:  user.Fullname,
 	}); err != nil {
@@ -127,6 +184,7 @@ func ChangePassword(resetPassword dto.ResetPassword) error {
 // ======================== Helper Functions ==========================
 // ====================================================================
 
-func interpolateToken(token string) string {
-	return fmt.Sprintf("reset_password:%s", token)
+// hashResetToken hashes a reset token before persisting it.
+func hashResetToken(token string) string {
+	return utils.Sha256(token)
 }
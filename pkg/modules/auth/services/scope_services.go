@@ -0,0 +1,79 @@
+package services
+
+import (
+	"gfly/internal/domain/models"
+	userServices "gfly/internal/services"
+	"gfly/pkg/modules/auth"
+	"slices"
+)
+
+// ====================================================================
+// ========================= Main functions ===========================
+// ====================================================================
+
+// ResolveScopes collects the scopes granted to a user: a `role:<slug>` scope per role
+// and a scope per permission of these roles (the permission slug).
+// The scopes are embedded in the access token at sign-in (and refresh) so that
+// authorization does not need to query the database for every request.
+//
+// Parameters:
+//   - userID: The unique identifier of the user
+//
+// Returns:
+//   - []string: Granted scopes. Ex: ["role:admin", "role:member", "users.read", "users.update"]
+func ResolveScopes(userID int) []string {
+	// Inherited roles are granted too. Ex: `admin` inherits `moderator`
+	roles := userServices.UserRoles(userID)
+	permissions := userServices.RolePermissions(roles)
+
+	scopes := make([]string, 0, len(roles)+len(permissions))
+	for _, role := range roles {
+		scopes = append(scopes, auth.RoleScope(string(role.Slug)))
+	}
+
+	for _, permission := range permissions {
+		scopes = append(scopes, string(permission))
+	}
+
+	return scopes
+}
+
+// HasScopes checks all required scopes were granted.
+//
+// Parameters:
+//   - granted: The scopes of the access token
+//   - required: The scopes required by the route
+//
+// Returns:
+//   - bool: true if every required scope is granted
+func HasScopes(granted []string, required ...string) bool {
+	for _, scope := range required {
+		if !slices.Contains(granted, scope) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ====================================================================
+// ======================== Helper Functions ==========================
+// ====================================================================
+
+// withRolePermissions adds the permission scopes granted by the role scopes of a personal
+// access token. Ex: a token restricted to `role:moderator` gets the permissions of `moderator`
+// and of the roles it inherits.
+func withRolePermissions(userID int, scopes []string) []string {
+	roles := userServices.UserRoles(userID)
+	scopedRoles := slices.DeleteFunc(slices.Clone(roles), func(role models.Role) bool {
+		return !slices.Contains(scopes, auth.RoleScope(string(role.Slug)))
+	})
+
+	for _, permission := range userServices.RolePermissions(models.InheritRoles(roles, scopedRoles)) {
+		if !slices.Contains(scopes, string(permission)) {
+			scopes = append(scopes, string(permission))
+		}
+	}
+
+	return scopes
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"gfly/internal/domain/models"
+	"gfly/internal/domain/repository"
+	"gfly/pkg/modules/auth"
+	"github.com/gflydev/core"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	mb "github.com/gflydev/db"
+	"github.com/gflydev/db/null"
+	"time"
+)
+
+// ====================================================================
+// ========================= Main functions ===========================
+// ====================================================================
+
+// ExtractClient collects information of the device which sends the request.
+//
+// Parameters:
+//   - c: *core.Ctx - The request context
+//
+// Returns:
+//   - auth.Client: User agent and IP address of the device
+func ExtractClient(c *core.Ctx) auth.Client {
+	return auth.Client{
+		UserAgent: c.GetHeader(core.HeaderUserAgent),
+		IPAddress: c.ClientIP(),
+	}
+}
+
+// ListSessions retrieves all active device sessions of a user.
+//
+// Parameters:
+//   - userID: The unique identifier of the user
+//
+// Returns:
+//   - []models.UserSession: Active sessions, the most recent first
+func ListSessions(userID int) []models.UserSession {
+	return repository.Pool.GetActiveSessionsByUserID(userID)
+}
+
+// RevokeSession revokes a device session of a user.
+// The refresh token of the session can not be used anymore and
+// access tokens issued for the session are refused by JWTAuth.
+//
+// Parameters:
+//   - userID: The unique identifier of the session owner
+//   - sessionID: The unique identifier of the session
+//
+// Returns:
+//   - error: Error if the session is not found (or belongs to another user) or revoking fails
+func RevokeSession(userID int, sessionID string) error {
+	session := repository.Pool.GetSessionByID(sessionID)
+	if session == nil || session.UserID != userID || !session.IsActive() {
+		return errors.New("Session not found")
+	}
+
+	if err := repository.Pool.RevokeSession(session); err != nil {
+		log.Errorf("Error while revoking session %q", err)
+
+		return errors.New("Error occurs while revoking session")
+	}
+
+	return nil
+}
+
+// RevokeOtherSessions revokes all device sessions of a user except the current one.
+//
+// Parameters:
+//   - userID: The unique identifier of the user
+//   - currentSessionID: The session which stays signed in
+//
+// Returns:
+//   - error: Error if revoking fails
+func RevokeOtherSessions(userID int, currentSessionID string) error {
+	if err := repository.Pool.RevokeSessionsByUserID(userID, currentSessionID); err != nil {
+		log.Errorf("Error while revoking sessions %q", err)
+
+		return errors.New("Error occurs while revoking sessions")
+	}
+
+	return nil
+}
+
+// IsActiveSession checks the given session exists, belongs to the user and
+// was neither revoked nor expired.
+//
+// Parameters:
+//   - userID: The unique identifier of the user
+//   - sessionID: The unique identifier of the session
+//
+// Returns:
+//   - bool: true if the session can be used
+func IsActiveSession(userID int, sessionID string) bool {
+	session := repository.Pool.GetSessionByID(sessionID)
+
+	return session != nil && session.UserID == userID && session.IsActive()
+}
+
+// ====================================================================
+// ======================== Helper Functions ==========================
+// ====================================================================
+
+// createSession stores a new device session together with the first refresh token of its family.
+func createSession(sessionID string, userID int, refreshToken string, client auth.Client) error {
+	session := &models.UserSession{
+		ID:         sessionID,
+		UserID:     userID,
+		UserAgent:  null.String(truncate(client.UserAgent, 255)),
+		IPAddress:  null.String(client.IPAddress),
+		ExpiresAt:  sessionExpiresAt(),
+		LastUsedAt: null.TimeNow(),
+		CreatedAt:  time.Now(),
+		UpdatedAt:  null.TimeNow(),
+	}
+
+	if err := mb.CreateModel(session); err != nil {
+		return err
+	}
+
+	return issueRefreshToken(sessionID, refreshToken)
+}
+
+// issueRefreshToken adds a refresh token to the token family of the given session.
+func issueRefreshToken(sessionID, refreshToken string) error {
+	return mb.CreateModel(&models.UserRefreshToken{
+		SessionID: sessionID,
+		TokenHash: hashRefreshToken(refreshToken),
+		ExpiresAt: sessionExpiresAt(),
+		CreatedAt: time.Now(),
+	})
+}
+
+// sessionExpiresAt calculates the expiry of a session from `JWT_TTL_OVER_DAYS`.
+func sessionExpiresAt() time.Time {
+	ttlDays := utils.Getenv(auth.TtlOverDays, 0)
+
+	return time.Now().Add(time.Duration(ttlDays*24) * time.Hour)
+}
+
+// hashRefreshToken hashes a refresh token before persisting it.
+func hashRefreshToken(refreshToken string) string {
+	return utils.Sha256(refreshToken)
+}
+
+// truncate cuts the given string to maximum `size` bytes.
+func truncate(value string, size int) string {
+	if len(value) > size {
+		return value[:size]
+	}
+
+	return value
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"gfly/internal/domain/models"
+	"gfly/internal/domain/models/types"
+	"gfly/pkg/modules/auth"
+	authEvents "gfly/pkg/modules/auth/events"
+	"github.com/gflydev/cache"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	mb "github.com/gflydev/db"
+	"github.com/gflydev/db/null"
+	"github.com/gflydev/event"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrTooManyAttempts error when sign-in is throttled for the email or the IP address.
+	ErrTooManyAttempts = errors.New("Too many sign-in attempts, please try again later")
+
+	// ErrAccountLocked error when the account is temporarily locked after too many failures.
+	ErrAccountLocked = errors.New("Account is temporarily locked, please try again later")
+)
+
+// ====================================================================
+// ======================== Helper Functions ==========================
+// ====================================================================
+
+// checkSignInThrottle refuses a sign-in attempt while the IP address reached
+// `AUTH_THROTTLE_IP_MAX_ATTEMPTS` failures in the window, or while the progressive
+// delay of the email is running (`AUTH_THROTTLE_DELAY_SECONDS`, doubled per failure).
+func checkSignInThrottle(email string, client auth.Client) error {
+	now := time.Now()
+
+	ipFailures := recentFailures(throttleIPKey(client.IPAddress), now)
+	if len(ipFailures) >= utils.Getenv(auth.ThrottleIPMaxAttempts, 20) {
+		return ErrTooManyAttempts
+	}
+
+	emailFailures := recentFailures(throttleEmailKey(email), now)
+	if len(emailFailures) == 0 {
+		return nil
+	}
+
+	lastFailure := emailFailures[len(emailFailures)-1]
+	if now.Before(lastFailure.Add(progressiveDelay(len(emailFailures)))) {
+		return ErrTooManyAttempts
+	}
+
+	return nil
+}
+
+// recordSignInFailure adds a failed attempt to the sliding windows of the email
+// and the IP address. Returns the number of failures of the email in the window.
+func recordSignInFailure(email string, client auth.Client) int {
+	now := time.Now()
+
+	addFailure(throttleIPKey(client.IPAddress), now)
+
+	return addFailure(throttleEmailKey(email), now)
+}
+
+// clearSignInFailures resets the failures of the email after a successful sign-in or a lockout.
+func clearSignInFailures(email string) {
+	_ = cache.Del(throttleEmailKey(email))
+}
+
+// isLockedOut checks the user is locked by too many failures and the lockout is still running.
+// An expired lockout is lifted (status `active`, `blocked_at` cleared).
+//
+// Note: Only lockouts set `blocked_at`. Users blocked by an administrator are not unlocked.
+func isLockedOut(user *models.User) bool {
+	if user.Status != types.UserStatusBlocked || !user.BlockedAt.Valid {
+		return false
+	}
+
+	if time.Now().Before(lockoutUnlockAt(user)) {
+		return true
+	}
+
+	user.Status = types.UserStatusActive
+	user.BlockedAt = sql.NullTime{}
+	user.UpdatedAt = null.TimeNow()
+	if err := mb.UpdateModel(user); err != nil {
+		log.Errorf("Unlock user %d error '%v'", user.ID, err)
+
+		return true
+	}
+
+	return false
+}
+
+// lockAccount blocks the user temporarily (`AUTH_LOCKOUT_MINUTES`) and dispatches AccountLocked.
+func lockAccount(user *models.User, client auth.Client) {
+	user.Status = types.UserStatusBlocked
+	user.BlockedAt = null.TimeNow()
+	user.UpdatedAt = null.TimeNow()
+	if err := mb.UpdateModel(user); err != nil {
+		log.Errorf("Lock user %d error '%v'", user.ID, err)
+
+		return
+	}
+
+	event.Dispatch(authEvents.AccountLocked{
+		UserID:    user.ID,
+		Email:     user.Email,
+		Fullname:  user.Fullname,
+		IPAddress: client.IPAddress,
+		UnlockAt:  lockoutUnlockAt(user),
+	})
+}
+
+// lockoutUnlockAt calculates the auto-unlock time of a locked user.
+func lockoutUnlockAt(user *models.User) time.Time {
+	return user.BlockedAt.Time.Add(time.Duration(utils.Getenv(auth.LockoutMinutes, 30)) * time.Minute)
+}
+
+// progressiveDelay calculates the wait time after the given number of failures.
+// Ex: 1s, 2s, 4s, 8s... limited to the throttle window.
+func progressiveDelay(failures int) time.Duration {
+	delay := time.Duration(utils.Getenv(auth.ThrottleDelaySeconds, 1)) * time.Second
+	for i := 1; i < failures && delay < throttleWindow(); i++ {
+		delay *= 2
+	}
+
+	return min(delay, throttleWindow())
+}
+
+// addFailure appends a failure to the sliding window of the key and returns the number of failures in the window.
+func addFailure(key string, now time.Time) int {
+	failures := append(recentFailures(key, now), now)
+
+	values := make([]string, 0, len(failures))
+	for _, failure := range failures {
+		values = append(values, strconv.FormatInt(failure.UnixMilli(), 10))
+	}
+
+	if err := cache.Set(key, strings.Join(values, ","), throttleWindow()); err != nil {
+		log.Errorf("Store sign-in failures error '%v'", err)
+	}
+
+	return len(failures)
+}
+
+// recentFailures loads the failures of the key which are still in the sliding window.
+// Failures are stored as comma separated Unix milliseconds.
+func recentFailures(key string, now time.Time) []time.Time {
+	val, err := cache.Get(key)
+	if err != nil || val == nil {
+		return []time.Time{}
+	}
+
+	windowStart := now.Add(-throttleWindow())
+	failures := make([]time.Time, 0)
+	for _, value := range strings.Split(fmt.Sprint(val), ",") {
+		millis, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		if failure := time.UnixMilli(millis); failure.After(windowStart) {
+			failures = append(failures, failure)
+		}
+	}
+
+	return failures
+}
+
+// throttleWindow length of the sliding window from `AUTH_THROTTLE_WINDOW_MINUTES`.
+func throttleWindow() time.Duration {
+	return time.Duration(utils.Getenv(auth.ThrottleWindow, 15)) * time.Minute
+}
+
+// throttleEmailKey builds the cache key of the failures of an email address.
+func throttleEmailKey(email string) string {
+	return fmt.Sprintf("signin_failures:email:%s", utils.Sha256(strings.ToLower(email)))
+}
+
+// throttleIPKey builds the cache key of the failures of an IP address.
+func throttleIPKey(ipAddress string) string {
+	return fmt.Sprintf("signin_failures:ip:%s", ipAddress)
+}
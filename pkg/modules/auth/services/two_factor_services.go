@@ -0,0 +1,398 @@
+package services
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base32"
+	"fmt"
+	"gfly/internal/domain/models"
+	"gfly/internal/domain/repository"
+	"gfly/pkg/modules/auth"
+	"github.com/gflydev/cache"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	mb "github.com/gflydev/db"
+	"github.com/gflydev/db/null"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+	"image/png"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// recoveryCodeCount number of recovery codes generated at confirmation.
+	recoveryCodeCount = 10
+
+	// maxChallengeAttempts number of wrong codes allowed for a challenge token.
+	maxChallengeAttempts = 5
+
+	// qrCodeSize width and height of the QR code image.
+	qrCodeSize = 256
+
+	// totpPeriod seconds of a TOTP time step.
+	totpPeriod = 30
+)
+
+// totpOptions options of the TOTP codes, the defaults of the authenticator apps.
+var totpOptions = totp.ValidateOpts{
+	Period:    totpPeriod,
+	Digits:    otp.DigitsSix,
+	Algorithm: otp.AlgorithmSHA1,
+}
+
+// TwoFactorEnrollment struct to describe a pending TOTP enrollment.
+type TwoFactorEnrollment struct {
+	Secret string // Base32 secret for manual entry
+	URI    string // otpauth:// URI
+	QRCode []byte // PNG image of the URI
+}
+
+// ====================================================================
+// ========================= Main functions ===========================
+// ====================================================================
+
+// IsTwoFactorEnabled checks the user has a confirmed TOTP enrollment.
+//
+// Parameters:
+//   - userID: The unique identifier of the user
+//
+// Returns:
+//   - bool: true if sign-in requires a second factor
+func IsTwoFactorEnabled(userID int) bool {
+	twoFactor := repository.Pool.GetTwoFactorByUserID(userID)
+
+	return twoFactor != nil && twoFactor.ConfirmedAt.Valid
+}
+
+// EnrollTwoFactor creates (or renews) a pending TOTP enrollment for the user.
+//
+// Parameters:
+//   - user: The current user
+//
+// Returns:
+//   - *TwoFactorEnrollment: Secret, otpauth URI and QR code PNG to scan
+//   - error: Error if two-factor authentication is already enabled or storing fails
+//
+// Flow:
+// 1. Refuses when an enrollment is already confirmed
+// 2. Generates a new TOTP secret (RFC 6238, SHA1, 6 digits, 30 seconds)
+// 3. Stores the unconfirmed secret
+// 4. Renders the otpauth URI as QR code
+func EnrollTwoFactor(user models.User) (*TwoFactorEnrollment, error) {
+	twoFactor := repository.Pool.GetTwoFactorByUserID(user.ID)
+	if twoFactor != nil && twoFactor.ConfirmedAt.Valid {
+		return nil, errors.New("Two-factor authentication is already enabled")
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      utils.Getenv(auth.TwoFactorIssuer, utils.Getenv("APP_NAME", "gFly")),
+		AccountName: user.Email,
+	})
+	if err != nil {
+		log.Errorf("Generate TOTP secret error '%v'", err)
+
+		return nil, errors.New("Error occurs while enrolling two-factor authentication")
+	}
+
+	if twoFactor == nil {
+		err = mb.CreateModel(&models.UserTwoFactor{
+			UserID:    user.ID,
+			Secret:    key.Secret(),
+			CreatedAt: time.Now(),
+			UpdatedAt: null.TimeNow(),
+		})
+	} else {
+		twoFactor.Secret = key.Secret()
+		twoFactor.UpdatedAt = null.TimeNow()
+		err = mb.UpdateModel(twoFactor)
+	}
+
+	if err != nil {
+		log.Errorf("Store TOTP secret error '%v'", err)
+
+		return nil, errors.New("Error occurs while enrolling two-factor authentication")
+	}
+
+	// Render QR code
+	image, err := key.Image(qrCodeSize, qrCodeSize)
+	if err != nil {
+		log.Errorf("Render TOTP QR code error '%v'", err)
+
+		return nil, errors.New("Error occurs while enrolling two-factor authentication")
+	}
+
+	var qrCode bytes.Buffer
+	if err = png.Encode(&qrCode, image); err != nil {
+		log.Errorf("Encode TOTP QR code error '%v'", err)
+
+		return nil, errors.New("Error occurs while enrolling two-factor authentication")
+	}
+
+	return &TwoFactorEnrollment{
+		Secret: key.Secret(),
+		URI:    key.URL(),
+		QRCode: qrCode.Bytes(),
+	}, nil
+}
+
+// ConfirmTwoFactor enables two-factor authentication after the user proved
+// the authenticator app works by sending a valid code.
+//
+// Parameters:
+//   - userID: The unique identifier of the user
+//   - code: The current TOTP code
+//
+// Returns:
+//   - []string: One-time recovery codes in plain text (shown only once)
+//   - error: Error if there is no pending enrollment or the code is invalid
+func ConfirmTwoFactor(userID int, code string) ([]string, error) {
+	twoFactor := repository.Pool.GetTwoFactorByUserID(userID)
+	if twoFactor == nil || twoFactor.ConfirmedAt.Valid {
+		return nil, errors.New("No pending two-factor enrollment")
+	}
+
+	step := totpStep(strings.TrimSpace(code), twoFactor.Secret)
+	if step < 0 {
+		return nil, errors.New("Invalid two-factor code")
+	}
+
+	twoFactor.LastUsedStep = null.Int64(step)
+	twoFactor.ConfirmedAt = null.TimeNow()
+	twoFactor.UpdatedAt = null.TimeNow()
+	if err := mb.UpdateModel(twoFactor); err != nil {
+		log.Errorf("Confirm two-factor error '%v'", err)
+
+		return nil, errors.New("Error occurs while confirming two-factor authentication")
+	}
+
+	codes, hashes := generateRecoveryCodes()
+	if err := repository.Pool.ReplaceRecoveryCodes(userID, hashes); err != nil {
+		log.Errorf("Store recovery codes error '%v'", err)
+
+		return nil, errors.New("Error occurs while confirming two-factor authentication")
+	}
+
+	return codes, nil
+}
+
+// DisableTwoFactor removes the two-factor authentication of a user.
+//
+// Parameters:
+//   - userID: The unique identifier of the user
+//   - code: A current TOTP code or an unused recovery code
+//
+// Returns:
+//   - error: Error if two-factor authentication is not enabled or the code is invalid
+func DisableTwoFactor(userID int, code string) error {
+	twoFactor := repository.Pool.GetTwoFactorByUserID(userID)
+	if twoFactor == nil || !twoFactor.ConfirmedAt.Valid {
+		return errors.New("Two-factor authentication is not enabled")
+	}
+
+	if !validateTwoFactorCode(twoFactor, code) {
+		return errors.New("Invalid two-factor code")
+	}
+
+	if err := repository.Pool.DeleteTwoFactor(userID); err != nil {
+		log.Errorf("Disable two-factor error '%v'", err)
+
+		return errors.New("Error occurs while disabling two-factor authentication")
+	}
+
+	return nil
+}
+
+// CreateTwoFactorChallenge issues a short-lived challenge token after a
+// successful password check of a 2FA user.
+//
+// Parameters:
+//   - userID: The unique identifier of the user
+//
+// Returns:
+//   - string: The challenge token to exchange with a code at `/auth/2fa/verify`
+//   - error: Error if storing the challenge fails
+func CreateTwoFactorChallenge(userID int) (string, error) {
+	challenge := utils.Token(strconv.Itoa(userID))
+
+	ttlMinutes := utils.Getenv(auth.TwoFactorChallengeTtl, 5)
+	if err := cache.Set(challengeKey(challenge), challengeValue(userID, 0), time.Duration(ttlMinutes)*time.Minute); err != nil {
+		log.Errorf("Store two-factor challenge error '%v'", err)
+
+		return "", errors.New("Error occurs while signin user")
+	}
+
+	return challenge, nil
+}
+
+// VerifyTwoFactorChallenge exchanges a challenge token and a TOTP (or recovery) code
+// for the user who passed the password check.
+//
+// Parameters:
+//   - challenge: The challenge token returned by sign-in
+//   - code: A current TOTP code or an unused recovery code
+//
+// Returns:
+//   - *models.User: The authenticated user
+//   - error: Error if the challenge is unknown/expired or the code is invalid
+//
+// Flow:
+// 1. Loads the challenge from cache
+// 2. Validates the code; a wrong code increases the attempts counter and the
+// challenge is dropped after too many attempts
+// 3. Deletes the challenge (single use)
+// 4. Re-checks the user account is still active
+func VerifyTwoFactorChallenge(challenge, code string) (*models.User, error) {
+	key := challengeKey(challenge)
+
+	val, err := cache.Get(key)
+	if err != nil || val == nil {
+		return nil, errors.New("Invalid or expired two-factor challenge")
+	}
+
+	userID, attempts, err := parseChallengeValue(fmt.Sprint(val))
+	if err != nil {
+		return nil, errors.New("Invalid or expired two-factor challenge")
+	}
+
+	twoFactor := repository.Pool.GetTwoFactorByUserID(userID)
+	if twoFactor == nil || !twoFactor.ConfirmedAt.Valid {
+		_ = cache.Del(key)
+
+		return nil, errors.New("Invalid or expired two-factor challenge")
+	}
+
+	if !validateTwoFactorCode(twoFactor, code) {
+		attempts++
+		if attempts >= maxChallengeAttempts {
+			_ = cache.Del(key)
+		} else {
+			ttlMinutes := utils.Getenv(auth.TwoFactorChallengeTtl, 5)
+			_ = cache.Set(key, challengeValue(userID, attempts), time.Duration(ttlMinutes)*time.Minute)
+		}
+
+		return nil, errors.New("Invalid two-factor code")
+	}
+
+	_ = cache.Del(key)
+
+	user, err := mb.GetModelByID[models.User](userID)
+	if err != nil || user == nil {
+		return nil, errors.New("User not found")
+	}
+
+	if err = checkUserStatus(user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// ====================================================================
+// ======================== Helper Functions ==========================
+// ====================================================================
+
+// validateTwoFactorCode checks a TOTP code, or consumes an unused recovery code.
+// A TOTP code is accepted once (RFC 6238 §5.2): its time step must be after the last accepted one.
+func validateTwoFactorCode(twoFactor *models.UserTwoFactor, code string) bool {
+	code = strings.TrimSpace(code)
+
+	if step := totpStep(code, twoFactor.Secret); step >= 0 {
+		used, err := repository.Pool.UseTwoFactorStep(twoFactor, step)
+		if err != nil {
+			log.Errorf("Use two-factor code error '%v'", err)
+
+			return false
+		}
+
+		return used
+	}
+
+	codeHash := utils.Sha256(strings.ToLower(code))
+	for _, recoveryCode := range repository.Pool.GetUnusedRecoveryCodes(twoFactor.UserID) {
+		if recoveryCode.CodeHash != codeHash {
+			continue
+		}
+
+		used, err := repository.Pool.UseRecoveryCode(&recoveryCode)
+		if err != nil {
+			log.Errorf("Use recovery code error '%v'", err)
+
+			return false
+		}
+
+		return used
+	}
+
+	return false
+}
+
+// totpStep returns the time step of a valid TOTP code, or -1 for an invalid code.
+// The steps before and after the current one are accepted too (clock skew).
+func totpStep(code, secret string) int64 {
+	current := time.Now().Unix() / totpPeriod
+
+	for _, step := range []int64{current - 1, current, current + 1} {
+		expected, err := totp.GenerateCodeCustom(secret, time.Unix(step*totpPeriod, 0).UTC(), totpOptions)
+		if err == nil && subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step
+		}
+	}
+
+	return -1
+}
+
+// generateRecoveryCodes creates random recovery codes. Ex: `k3v9q-7xw2m`
+// Returns the plain codes and their SHA256 hashes.
+func generateRecoveryCodes() ([]string, []string) {
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+
+	for range recoveryCodeCount {
+		random := make([]byte, 7)
+		_, _ = rand.Read(random)
+
+		raw := strings.ToLower(encoding.EncodeToString(random))[:10]
+		code := raw[:5] + "-" + raw[5:]
+
+		codes = append(codes, code)
+		hashes = append(hashes, utils.Sha256(code))
+	}
+
+	return codes, hashes
+}
+
+// challengeKey builds the cache key of a challenge token.
+func challengeKey(challenge string) string {
+	return fmt.Sprintf("2fa_challenge:%s", utils.Sha256(challenge))
+}
+
+// challengeValue encodes the user ID and the number of failed attempts.
+func challengeValue(userID, attempts int) string {
+	return fmt.Sprintf("%d:%d", userID, attempts)
+}
+
+// parseChallengeValue decodes the value created by challengeValue.
+func parseChallengeValue(value string) (int, int, error) {
+	userIDStr, attemptsStr, found := strings.Cut(value, ":")
+	if !found {
+		return 0, 0, errors.New("Invalid challenge value %q", value)
+	}
+
+	userID, err := strconv.Atoi(userIDStr)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	attempts, err := strconv.Atoi(attemptsStr)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return userID, attempts, nil
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"gfly/internal/domain/models"
+	"gfly/internal/domain/models/types"
+	"gfly/internal/domain/repository"
+	"gfly/pkg/modules/auth"
+	"gfly/pkg/modules/auth/dto"
+	"gfly/pkg/modules/auth/notifications"
+	"github.com/gflydev/cache"
+	"github.com/gflydev/core"
+	"github.com/gflydev/core/errors"
+	"github.com/gflydev/core/log"
+	"github.com/gflydev/core/utils"
+	mb "github.com/gflydev/db"
+	"github.com/gflydev/db/null"
+	"github.com/gflydev/notification"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrResendTooEarly error when a verification email is requested again too early.
+var ErrResendTooEarly = errors.New("Verification email was sent recently, please try again later")
+
+// errMissingAppKey error when links must be signed but `APP_KEY` is not set.
+var errMissingAppKey = errors.New("Missing APP_KEY to sign links")
+
+// ====================================================================
+// ========================= Main functions ===========================
+// ====================================================================
+
+// SendVerificationEmail sends a signed and expiring verification link to the user.
+//
+// Parameters:
+//   - user: *models.User - The user whose email address must be verified
+//
+// Returns:
+//   - error: Error if sending the notification fails
+//
+// Link format: `<APP_URL><AUTH_VERIFY_EMAIL_URI>?id=<id>&expires=<unix>&signature=<hmac>`
+func SendVerificationEmail(user *models.User) error {
+	ttlMinutes := utils.Getenv(auth.VerifyEmailTtl, 1440)
+	expires := time.Now().Add(time.Duration(ttlMinutes) * time.Minute).Unix()
+
+	signature, err := verificationSignature(user.ID, user.Email, expires)
+	if err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	query.Set("id", strconv.Itoa(user.ID))
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", signature)
+
+	return notification.Send(notifications.VerifyEmail{
+		Email: user.Email,
+		Name:  user.Fullname,
+		URL:   fmt.Sprintf("%s%s?%s", core.AppURL, utils.Getenv(auth.VerifyEmailUri, "/verify-email"), query.Encode()),
+	})
+}
+
+// ResendVerificationEmail sends the verification link again.
+// Unknown and already verified addresses are ignored silently, so the
+// endpoint can not be used to discover registered emails.
+//
+// Parameters:
+//   - resend: dto.ResendVerifyEmail - Contains the email address
+//
+// Returns:
+//   - error: ErrResendTooEarly within `AUTH_VERIFY_EMAIL_RESEND_SECONDS` after the previous request,
+//     or an error if sending fails
+func ResendVerificationEmail(resend dto.ResendVerifyEmail) error {
+	email := strings.ToLower(resend.Email)
+	key := resendKey(email)
+
+	if val, err := cache.Get(key); err == nil && val != nil {
+		return ErrResendTooEarly
+	}
+
+	interval := utils.Getenv(auth.VerifyEmailResend, 60)
+	if err := cache.Set(key, "1", time.Duration(interval)*time.Second); err != nil {
+		log.Errorf("Store verification resend error '%v'", err)
+	}
+
+	user := repository.Pool.GetUserByEmail(email)
+	if user == nil || user.VerifiedAt.Valid {
+		return nil
+	}
+
+	if err := SendVerificationEmail(user); err != nil {
+		log.Errorf("Resend verification email error '%v'", err)
+
+		return errors.New("Error occurs while sending verification email")
+	}
+
+	return nil
+}
+
+// VerifyEmail checks the signed link and marks the email address of the user as verified.
+//
+// Parameters:
+//   - verifyEmail: dto.VerifyEmail - Contains ID, expiry and signature of the link
+//
+// Returns:
+//   - error: Error if the link is invalid or expired, or updating fails
+//
+// Flow:
+// 1. Refuses expired links
+// 2. Loads the user and compares the signature (the email is signed, so a link
+// becomes invalid when the email changes)
+// 3. Sets `verified_at` and activates pending accounts. Blocked accounts stay blocked.
+func VerifyEmail(verifyEmail dto.VerifyEmail) error {
+	if time.Now().Unix() > verifyEmail.Expires {
+		return errors.New("Verification link is expired")
+	}
+
+	user, err := mb.GetModelByID[models.User](verifyEmail.ID)
+	if err != nil || user == nil {
+		return errors.New("Invalid verification link")
+	}
+
+	expected, err := verificationSignature(user.ID, user.Email, verifyEmail.Expires)
+	if err != nil {
+		log.Errorf("Verify email error '%v'", err)
+
+		return errors.New("Invalid verification link")
+	}
+
+	if !hmac.Equal([]byte(expected), []byte(verifyEmail.Signature)) {
+		return errors.New("Invalid verification link")
+	}
+
+	// Link opened twice
+	if user.VerifiedAt.Valid {
+		return nil
+	}
+
+	user.VerifiedAt = null.TimeNow()
+	user.UpdatedAt = null.TimeNow()
+	if user.Status == types.UserStatusPending {
+		user.Status = types.UserStatusActive
+	}
+
+	if err = mb.UpdateModel(user); err != nil {
+		log.Errorf("Verify email error '%v'", err)
+
+		return errors.New("Error occurs while verifying email")
+	}
+
+	return nil
+}
+
+// ====================================================================
+// ======================== Helper Functions ==========================
+// ====================================================================
+
+// verificationSignature signs the link data (see signLink).
+func verificationSignature(userID int, email string, expires int64) (string, error) {
+	return signLink(fmt.Sprintf("verify_email:%d:%s:%d", userID, strings.ToLower(email), expires))
+}
+
+// signLink signs the data of a link with `APP_KEY` (HMAC-SHA256).
+// Without the key anyone could sign links, so no link is signed nor accepted.
+func signLink(data string) (string, error) {
+	key := utils.Getenv(auth.AppKey, "")
+	if key == "" {
+		return "", errMissingAppKey
+	}
+
+	mac := hmac.New(sha256.New, []byte(key))
+	_, _ = mac.Write([]byte(data))
+
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// resendKey builds the cache key limiting resend requests of an email address.
+func resendKey(email string) string {
+	return fmt.Sprintf("verify_email_resend:%s", utils.Sha256(email))
+}
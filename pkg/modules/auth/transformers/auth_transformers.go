@@ -1,9 +1,15 @@
 package transformers
 
 import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
 	"gfly/internal/domain/models"
 	"gfly/pkg/modules/auth"
 	"gfly/pkg/modules/auth/response"
+	"gfly/pkg/modules/auth/services"
+	dbNull "github.com/gflydev/db/null"
+	"math/big"
 )
 
 // ToSignInResponse function JWTTokens struct to SignIn response object.
@@ -14,6 +20,24 @@ func ToSignInResponse(tokens *auth.Token) response.SignIn {
 	}
 }
 
+// ToTwoFactorChallengeResponse converts a sign-in challenge to TwoFactorChallenge response object.
+func ToTwoFactorChallengeResponse(tokens *auth.Token) response.TwoFactorChallenge {
+	return response.TwoFactorChallenge{
+		TwoFactorRequired: true,
+		Challenge:         tokens.Challenge,
+	}
+}
+
+// ToTwoFactorEnrollmentResponse converts a pending enrollment to TwoFactorEnrollment response object.
+// The QR code PNG is embedded as data URI.
+func ToTwoFactorEnrollmentResponse(enrollment *services.TwoFactorEnrollment) response.TwoFactorEnrollment {
+	return response.TwoFactorEnrollment{
+		Secret: enrollment.Secret,
+		URI:    enrollment.URI,
+		QRCode: "data:image/png;base64," + base64.StdEncoding.EncodeToString(enrollment.QRCode),
+	}
+}
+
 // ToSignUpResponse converts a User model to a User response object
 // with all fields populated for signup response
 //
@@ -33,3 +57,105 @@ func ToSignUpResponse(user models.User) response.SignUp {
 		UpdatedAt: user.UpdatedAt.Time,
 	}
 }
+
+// ToSessionResponse converts a UserSession model to a Session response object
+//
+// Parameters:
+//   - session: models.UserSession - The session model to convert
+//   - currentSessionID: string - The session ID of the requesting device
+//
+// Returns:
+//   - response.Session: The converted session response object
+func ToSessionResponse(session models.UserSession, currentSessionID string) response.Session {
+	return response.Session{
+		ID:         session.ID,
+		UserAgent:  dbNull.StringNil(session.UserAgent),
+		IPAddress:  dbNull.StringNil(session.IPAddress),
+		Current:    session.ID == currentSessionID,
+		ExpiresAt:  session.ExpiresAt,
+		LastUsedAt: dbNull.TimeNil(session.LastUsedAt),
+		CreatedAt:  session.CreatedAt,
+	}
+}
+
+// ToJWKSResponse converts public verification keys to a JSON Web Key Set
+//
+// Parameters:
+//   - keys: []services.PublicKey - The public keys of the keyring
+//
+// Returns:
+//   - response.JWKS: The key set (RSA and Ed25519 keys)
+func ToJWKSResponse(keys []services.PublicKey) response.JWKS {
+	jwks := response.JWKS{
+		Keys: make([]response.JWK, 0, len(keys)),
+	}
+
+	for _, key := range keys {
+		jwk := response.JWK{
+			Use: "sig",
+			Alg: key.Algorithm,
+			Kid: key.ID,
+		}
+
+		switch publicKey := key.Key.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+		default:
+			continue
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return jwks
+}
+
+// ToAccessTokenResponse converts a personal access token model into response data.
+//
+// Parameters:
+//   - accessToken: models.PersonalAccessToken - The token model
+//
+// Returns:
+//   - response.AccessToken: The token without its hash
+func ToAccessTokenResponse(accessToken models.PersonalAccessToken) response.AccessToken {
+	return response.AccessToken{
+		ID:         accessToken.ID,
+		Name:       accessToken.Name,
+		Scopes:     accessToken.ScopeList(),
+		ExpiresAt:  dbNull.TimeNil(accessToken.ExpiresAt),
+		LastUsedAt: dbNull.TimeNil(accessToken.LastUsedAt),
+		CreatedAt:  accessToken.CreatedAt,
+	}
+}
+
+// ToNewAccessTokenResponse converts a created personal access token into response data
+// which includes the plain text token (shown only once).
+//
+// Parameters:
+//   - accessToken: models.PersonalAccessToken - The token model
+//   - plainToken: string - The plain text token
+//
+// Returns:
+//   - response.NewAccessToken: The token with its plain text value
+func ToNewAccessTokenResponse(accessToken models.PersonalAccessToken, plainToken string) response.NewAccessToken {
+	return response.NewAccessToken{
+		AccessToken: ToAccessTokenResponse(accessToken),
+		Token:       plainToken,
+	}
+}
+
+// ToImpersonationResponse converts a recorded impersonation and its token to Impersonation response object.
+func ToImpersonationResponse(impersonation models.Impersonation, accessToken string) response.Impersonation {
+	return response.Impersonation{
+		Access:    accessToken,
+		UserID:    int(impersonation.UserID.Int64),
+		ActorID:   int(impersonation.ActorID.Int64),
+		ExpiresAt: impersonation.ExpiresAt,
+	}
+}
@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gflydev/cache"
+	"github.com/gflydev/core/utils"
+	"github.com/redis/go-redis/v9"
+	"sync"
+)
+
+// ErrCacheMiss is returned when a pulled key does not exist (or expired)
+var ErrCacheMiss = errors.New("cache key not found")
+
+var (
+	pullClient     *redis.Client
+	pullClientOnce sync.Once
+)
+
+// CachePull reads and deletes a key of the Redis cache in a single command (GETDEL).
+// Only one of many concurrent calls gets the value, so it consumes single-use values
+// (Ex: the token of a sign-in link). The cache package has no atomic read-and-delete,
+// so it uses its own connection, with the same settings and key prefix as the cache.
+func CachePull(key string) (string, error) {
+	pullClientOnce.Do(func() {
+		pullClient = redis.NewClient(&redis.Options{
+			Addr:     fmt.Sprintf("%s:%d", utils.Getenv("REDIS_HOST", "localhost"), utils.Getenv("REDIS_PORT", 6379)),
+			Password: utils.Getenv("REDIS_PASSWORD", ""),
+			DB:       utils.Getenv("REDIS_DEFAULT_DB", 0),
+		})
+	})
+
+	value, err := pullClient.GetDel(context.Background(), cache.Key(key)).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", ErrCacheMiss
+	}
+
+	return value, err
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// Cursor is the position of an item in a list ordered by a column then by `id`.
+// Clients receive it as an opaque string (see EncodeCursor).
+type Cursor struct {
+	OrderBy  string  `json:"o"`           // The `order_by` the cursor was created for
+	Value    *string `json:"v"`           // The value of the order column (nil for NULL)
+	ID       int     `json:"i"`           // The `id` of the item, used as tiebreaker
+	Backward bool    `json:"b,omitempty"` // Items before the position are requested
+}
+
+// ErrInvalidCursor is returned when a cursor can not be decoded
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// EncodeCursor encodes a cursor to an opaque URL-safe string
+func EncodeCursor(cursor Cursor) string {
+	data, _ := json.Marshal(cursor)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor decodes a string created by EncodeCursor
+func DecodeCursor(str string) (Cursor, error) {
+	var cursor Cursor
+
+	data, err := base64.RawURLEncoding.DecodeString(str)
+	if err != nil {
+		return cursor, ErrInvalidCursor
+	}
+
+	if err = json.Unmarshal(data, &cursor); err != nil || cursor.ID < 1 {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
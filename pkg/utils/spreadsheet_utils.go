@@ -0,0 +1,241 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// maxSpreadsheetPartSize limits the uncompressed size of a part of an XLSX file (zip bomb).
+const maxSpreadsheetPartSize = 64 << 20
+
+// ErrUnsupportedSpreadsheet is returned for a file which is neither CSV nor XLSX
+var ErrUnsupportedSpreadsheet = errors.New("unsupported file type, expected .csv or .xlsx")
+
+// errMissingPart is returned when a part of an XLSX file does not exist
+var errMissingPart = errors.New("invalid XLSX file: missing part")
+
+// ReadSpreadsheet reads the rows of a CSV file or of the first sheet of an XLSX file.
+// The type is detected from the extension of the file name. Empty rows are kept as
+// empty slices, so the index of a row is its line number minus one.
+func ReadSpreadsheet(fileName string, data []byte) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		return readCSV(data)
+	case ".xlsx":
+		return readXLSX(data)
+	}
+
+	return nil, ErrUnsupportedSpreadsheet
+}
+
+// readCSV reads the rows of a CSV file. A UTF-8 BOM (Excel export) is skipped.
+func readCSV(data []byte) ([][]string, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var rows [][]string
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV file: %w", err)
+		}
+
+		// Empty lines are skipped by the reader
+		line, _ := reader.FieldPos(0)
+		for len(rows)+1 < line {
+			rows = append(rows, []string{})
+		}
+
+		rows = append(rows, row)
+	}
+}
+
+// XLSX parts (Office Open XML), only what is needed to read cell values.
+type (
+	xlsxWorkbook struct {
+		Sheets []struct {
+			RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+
+	xlsxRelationships struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+
+	xlsxSharedStrings struct {
+		Items []xlsxText `xml:"si"`
+	}
+
+	xlsxText struct {
+		Text string `xml:"t"`
+		Runs []struct {
+			Text string `xml:"t"`
+		} `xml:"r"`
+	}
+
+	xlsxSheet struct {
+		Rows []struct {
+			Index int `xml:"r,attr"`
+			Cells []struct {
+				Ref    string   `xml:"r,attr"`
+				Type   string   `xml:"t,attr"`
+				Value  string   `xml:"v"`
+				Inline xlsxText `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+)
+
+// String returns the plain text of a shared or inline string (rich text runs are joined).
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+
+	var text strings.Builder
+	for _, run := range t.Runs {
+		text.WriteString(run.Text)
+	}
+
+	return text.String()
+}
+
+// readXLSX reads the rows of the first sheet of an XLSX file.
+func readXLSX(data []byte) ([][]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid XLSX file: %w", err)
+	}
+
+	sheetPath, err := firstSheetPath(archive)
+	if err != nil {
+		return nil, err
+	}
+
+	var sharedStrings xlsxSharedStrings
+	if err = readXLSXPart(archive, "xl/sharedStrings.xml", &sharedStrings); err != nil && !errors.Is(err, errMissingPart) {
+		return nil, err
+	}
+
+	var sheet xlsxSheet
+	if err = readXLSXPart(archive, sheetPath, &sheet); err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for _, sheetRow := range sheet.Rows {
+		// Missing rows are empty rows
+		for sheetRow.Index > len(rows)+1 {
+			rows = append(rows, []string{})
+		}
+
+		var row []string
+		for _, cell := range sheetRow.Cells {
+			column := columnIndex(cell.Ref)
+			if column < 0 {
+				column = len(row)
+			}
+
+			// Missing cells are empty cells
+			for len(row) <= column {
+				row = append(row, "")
+			}
+
+			switch cell.Type {
+			case "s":
+				idx, err := strconv.Atoi(cell.Value)
+				if err != nil || idx < 0 || idx >= len(sharedStrings.Items) {
+					return nil, errors.New("invalid XLSX file: unknown shared string")
+				}
+				row[column] = sharedStrings.Items[idx].String()
+			case "inlineStr":
+				row[column] = cell.Inline.String()
+			default:
+				row[column] = cell.Value
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// firstSheetPath resolves the path of the first sheet from the workbook relationships.
+func firstSheetPath(archive *zip.Reader) (string, error) {
+	var workbook xlsxWorkbook
+	if err := readXLSXPart(archive, "xl/workbook.xml", &workbook); err != nil {
+		return "", err
+	}
+
+	var relationships xlsxRelationships
+	if err := readXLSXPart(archive, "xl/_rels/workbook.xml.rels", &relationships); err != nil {
+		return "", err
+	}
+
+	if len(workbook.Sheets) == 0 {
+		return "", errors.New("invalid XLSX file: no sheet")
+	}
+
+	for _, relationship := range relationships.Relationships {
+		if relationship.ID != workbook.Sheets[0].RelID {
+			continue
+		}
+
+		// Targets are relative to `xl/`, or absolute from the root of the package
+		if strings.HasPrefix(relationship.Target, "/") {
+			return strings.TrimPrefix(relationship.Target, "/"), nil
+		}
+
+		return path.Join("xl", relationship.Target), nil
+	}
+
+	return "", errors.New("invalid XLSX file: no sheet")
+}
+
+// readXLSXPart decodes an XML part of an XLSX file.
+func readXLSXPart(archive *zip.Reader, name string, value any) error {
+	file, err := archive.Open(name)
+	if err != nil {
+		return errMissingPart
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	if err = xml.NewDecoder(io.LimitReader(file, maxSpreadsheetPartSize)).Decode(value); err != nil {
+		return fmt.Errorf("invalid XLSX file: %w", err)
+	}
+
+	return nil
+}
+
+// columnIndex converts the column of a cell reference (e.g. "AB12") to a zero-based index.
+// It returns -1 when the reference has no column.
+func columnIndex(ref string) int {
+	column := 0
+	for _, char := range ref {
+		if char < 'A' || char > 'Z' {
+			break
+		}
+		column = column*26 + int(char-'A') + 1
+	}
+
+	return column - 1
+}
@@ -48,6 +48,13 @@
                             </a>
                         </div>
 
+                        <!-- Passwordless Sign-in -->
+                        <div class="mb-6 text-center">
+                            <a href="#" id="magic-link" class="text-sm text-indigo-500 hover:text-indigo-700">
+                                Email me a sign-in link
+                            </a>
+                        </div>
+
                         <!-- Submit Button -->
                         <div class="flex items-center justify-between">
                             <button class="w-full bg-indigo-600 hover:bg-indigo-700 text-white font-bold py-2 px-4 rounded focus:outline-none focus:shadow-outline transition duration-150 ease-in-out" type="submit">
@@ -55,6 +62,17 @@
                             </button>
                         </div>
 
+                        {% if oauth_providers %}
+                        <!-- Identity Providers -->
+                        <div class="mt-4">
+                            {% for provider in oauth_providers %}
+                            <a href="/api/v1/frontend/auth/oauth/{{ provider }}" class="block w-full text-center border border-indigo-600 text-indigo-600 hover:bg-indigo-50 dark:hover:bg-gray-700 font-bold py-2 px-4 rounded mt-2">
+                                Sign in with {{ provider|upper }}
+                            </a>
+                            {% endfor %}
+                        </div>
+                        {% endif %}
+
                         <!-- Register Link -->
                         <div class="text-center mt-6">
                             <p class="text-sm text-gray-600 dark:text-gray-400">
@@ -70,6 +88,71 @@
                         document.addEventListener('DOMContentLoaded', function() {
                             const form = document.getElementById('login-form');
 
+                            function redirectAfterLogin() {
+                                const urlParams = new URLSearchParams(window.location.search);
+                                window.location.href = urlParams.get('redirect_url') || '/profile';
+                            }
+
+                            function verifyTwoFactor(challenge) {
+                                const code = prompt('Enter the code of your authenticator app (or a recovery code)');
+                                if (!code) {
+                                    return;
+                                }
+
+                                return fetch('/api/v1/frontend/auth/2fa/verify', {
+                                    method: 'POST',
+                                    headers: {
+                                        'Content-Type': 'application/json',
+                                    },
+                                    body: JSON.stringify({
+                                        challenge: challenge,
+                                        code: code
+                                    })
+                                })
+                                .then(response => {
+                                    if (response.ok) {
+                                        redirectAfterLogin();
+                                        return;
+                                    }
+
+                                    return response.json().then(data => {
+                                        alert(data.message);
+                                    });
+                                });
+                            }
+
+                            // Redirected back from an identity provider
+                            const loginParams = new URLSearchParams(window.location.search);
+                            if (loginParams.get('error')) {
+                                alert(loginParams.get('error'));
+                            } else if (loginParams.get('challenge')) {
+                                verifyTwoFactor(loginParams.get('challenge'));
+                            }
+
+                            document.getElementById('magic-link').addEventListener('click', function(e) {
+                                e.preventDefault();
+
+                                fetch('/api/v1/auth/magic-link', {
+                                    method: 'POST',
+                                    headers: {
+                                        'Content-Type': 'application/json',
+                                    },
+                                    body: JSON.stringify({
+                                        email: document.getElementById('username').value
+                                    })
+                                })
+                                .then(response => {
+                                    if (response.ok) {
+                                        alert('Check your inbox, a sign-in link is on its way.');
+                                        return;
+                                    }
+
+                                    return response.json().then(data => {
+                                        alert(data.message);
+                                    });
+                                });
+                            });
+
                             form.addEventListener('submit', function(e) {
                                 e.preventDefault();
 
@@ -88,9 +171,13 @@
                                     })
                                 })
                                 .then(response => {
+                                    // Two-factor authentication: ask the code of the authenticator app
+                                    if (response.status === 202) {
+                                        return response.json().then(data => verifyTwoFactor(data.challenge));
+                                    }
+
                                     if (response.ok) {
-                                        const urlParams = new URLSearchParams(window.location.search);
-                                        window.location.href = urlParams.get('redirect_url') || '/profile';
+                                        redirectAfterLogin();
                                         return;
                                     }
 
@@ -0,0 +1,15 @@
+{% extends "master.tpl" %}
+    {% block body %}
+    <p style="font-family: Helvetica, sans-serif; font-size: 16px; font-weight: normal; margin: 0; margin-bottom: 16px;">
+        Hi {{ user_name }}
+    </p>
+    <p style="font-family: Helvetica, sans-serif; font-size: 16px; font-weight: normal; margin: 0; margin-bottom: 16px;">
+        Your account was locked after too many failed sign-in attempts.
+    </p>
+    <p style="font-family: Helvetica, sans-serif; font-size: 16px; font-weight: normal; margin: 0; margin-bottom: 16px;">
+        It will be unlocked automatically at <b>{{ unlock_at }}</b>.
+    </p>
+    <p style="font-family: Helvetica, sans-serif; font-size: 16px; font-weight: normal; margin: 0; margin-bottom: 16px;">
+        If these attempts were not made by you, please change your password and contact admin.
+    </p>
+    {% endblock %}
@@ -0,0 +1,119 @@
+{% extends "master.tpl" %}
+    {% block body %}
+    <!-- =========={ Reset password }==========  -->
+<div id="hero" class="relative z-0 pt-36 lg:pt-44 xl:pt-48 pb-20 lg:pb-32 text-gray-300 bg-indigo-600 bg-gradient-to-r from-indigo-600 via-indigo-500 to-teal-500 dark:from-gray-800 dark:via-gray-700 dark:to-green-700 overflow-hidden h-screen">
+    <div class="container xl:max-w-6xl mx-auto px-4">
+        <div class="flex flex-wrap flex-row -mx-4 justify-center">
+            <!-- Reset Password Form Card -->
+            <div class="w-full max-w-md">
+                <div class="bg-white dark:bg-gray-800 shadow-md rounded-lg px-8 py-6">
+                    <h2 class="text-2xl font-bold text-gray-800 dark:text-white mb-6 text-center">Reset password</h2>
+                    <form id="reset-password-form" action="/reset-password" method="post">
+                        <!-- Secret Code Input -->
+                        <div class="mb-4">
+                            <label class="block text-gray-700 dark:text-gray-300 text-sm font-bold mb-2" for="token">
+                                Secret code
+                            </label>
+                            <input class="shadow appearance-none border rounded w-full py-2 px-3 text-gray-700 leading-tight focus:outline-none focus:shadow-outline dark:bg-gray-700 dark:border-gray-600 dark:text-white"
+                                   id="token"
+                                   name="token"
+                                   type="text"
+                                   value="{{ token }}"
+                                   required="required" />
+                        </div>
+
+                        <!-- Password Input -->
+                        <div class="mb-4">
+                            <label class="block text-gray-700 dark:text-gray-300 text-sm font-bold mb-2" for="password">
+                                New password
+                            </label>
+                            <input class="shadow appearance-none border rounded w-full py-2 px-3 text-gray-700 leading-tight focus:outline-none focus:shadow-outline dark:bg-gray-700 dark:border-gray-600 dark:text-white"
+                                   id="password"
+                                   name="password"
+                                   type="password"
+                                   placeholder="••••••••"
+                                   required="required" />
+                        </div>
+
+                        <!-- Confirm Password Input -->
+                        <div class="mb-6">
+                            <label class="block text-gray-700 dark:text-gray-300 text-sm font-bold mb-2" for="password_confirmation">
+                                Confirm new password
+                            </label>
+                            <input class="shadow appearance-none border rounded w-full py-2 px-3 text-gray-700 leading-tight focus:outline-none focus:shadow-outline dark:bg-gray-700 dark:border-gray-600 dark:text-white"
+                                   id="password_confirmation"
+                                   name="password_confirmation"
+                                   type="password"
+                                   placeholder="••••••••"
+                                   required="required" />
+                        </div>
+
+                        <!-- Submit Button -->
+                        <div class="flex items-center justify-between">
+                            <button class="w-full bg-indigo-600 hover:bg-indigo-700 text-white font-bold py-2 px-4 rounded focus:outline-none focus:shadow-outline transition duration-150 ease-in-out" type="submit">
+                                Reset password
+                            </button>
+                        </div>
+
+                        <!-- Login Link -->
+                        <div class="text-center mt-6">
+                            <p class="text-sm text-gray-600 dark:text-gray-400">
+                                Remember your password?
+                                <a href="/login" class="font-medium text-indigo-500 hover:text-indigo-700">
+                                    Login
+                                </a>
+                            </p>
+                        </div>
+                    </form>
+
+                    <script>
+                        document.addEventListener('DOMContentLoaded', function() {
+                            const form = document.getElementById('reset-password-form');
+
+                            form.addEventListener('submit', function(e) {
+                                e.preventDefault();
+
+                                const token = document.getElementById('token').value;
+                                const password = document.getElementById('password').value;
+                                const confirmation = document.getElementById('password_confirmation').value;
+
+                                if (password !== confirmation) {
+                                    alert('The passwords do not match.');
+                                    return;
+                                }
+
+                                // Make AJAX request to API
+                                fetch('/api/v1/password/reset', {
+                                    method: 'POST',
+                                    headers: {
+                                        'Content-Type': 'application/json',
+                                    },
+                                    body: JSON.stringify({
+                                        token: token,
+                                        password: password
+                                    })
+                                })
+                                .then(response => {
+                                    if (response.ok) {
+                                        alert('Your password was changed. Please login again.');
+                                        window.location.href = '/login';
+                                        return;
+                                    }
+
+                                    return response.json().then(data => {
+                                        alert(data.message);
+                                    });
+                                })
+                                .catch(error => {
+                                    console.error('Error:', error);
+                                    alert('Reset password failed. Please try again.');
+                                });
+                            });
+                        });
+                    </script>
+                </div>
+            </div>
+        </div>
+    </div>
+</div><!-- end reset password -->
+    {% endblock %}
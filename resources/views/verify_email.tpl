@@ -0,0 +1,53 @@
+{% extends "master.tpl" %}
+    {% block body %}
+    <!-- =========={ Verify email }==========  -->
+<div id="hero" class="relative z-0 pt-36 lg:pt-44 xl:pt-48 pb-20 lg:pb-32 text-gray-300 bg-indigo-600 bg-gradient-to-r from-indigo-600 via-indigo-500 to-teal-500 dark:from-gray-800 dark:via-gray-700 dark:to-green-700 overflow-hidden h-screen">
+    <div class="container xl:max-w-6xl mx-auto px-4">
+        <div class="flex flex-wrap flex-row -mx-4 justify-center">
+            <!-- Verify Email Card -->
+            <div class="w-full max-w-md">
+                <div class="bg-white dark:bg-gray-800 shadow-md rounded-lg px-8 py-6 text-center">
+                    <h2 class="text-2xl font-bold text-gray-800 dark:text-white mb-6">Verify email</h2>
+                    <p id="verify-message" class="text-gray-700 dark:text-gray-300 mb-6">Verifying your email address...</p>
+                    <a href="/login" class="bg-indigo-600 hover:bg-indigo-700 text-white font-bold py-2 px-4 rounded focus:outline-none focus:shadow-outline">
+                        Login
+                    </a>
+
+                    <script>
+                        document.addEventListener('DOMContentLoaded', function() {
+                            const message = document.getElementById('verify-message');
+
+                            // Make AJAX request to API
+                            fetch('/api/v1/auth/verify-email', {
+                                method: 'POST',
+                                headers: {
+                                    'Content-Type': 'application/json',
+                                },
+                                body: JSON.stringify({
+                                    id: parseInt('{{ id }}', 10) || 0,
+                                    expires: parseInt('{{ expires }}', 10) || 0,
+                                    signature: '{{ signature }}'
+                                })
+                            })
+                            .then(response => {
+                                if (response.ok) {
+                                    message.textContent = 'Your email address is verified. You can login now.';
+                                    return;
+                                }
+
+                                return response.json().then(data => {
+                                    message.textContent = data.message;
+                                });
+                            })
+                            .catch(error => {
+                                console.error('Error:', error);
+                                message.textContent = 'Verification failed. Please try again.';
+                            });
+                        });
+                    </script>
+                </div>
+            </div>
+        </div>
+    </div>
+</div><!-- end verify email -->
+    {% endblock %}
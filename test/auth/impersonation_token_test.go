@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"gfly/pkg/modules/auth/services"
+	"testing"
+	"time"
+)
+
+func TestImpersonationTokenActorClaim(t *testing.T) {
+	t.Setenv("JWT_SECRET_KEY", "test-secret")
+	t.Setenv("JWT_TTL_MINUTES", "15")
+
+	token, err := services.GenerateImpersonationToken("2", "1", "session-1", []string{"role:member"}, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := services.ExtractTokenMetadata(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if claims.UserID != 2 || claims.ActorID != 1 || claims.SessionID != "session-1" {
+		t.Errorf("Unexpected claims %+v", claims)
+	}
+
+	tokens, err := services.GenerateTokens("2", "session-2", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err = services.ExtractTokenMetadata(tokens.Access)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if claims.ActorID != 0 {
+		t.Errorf("Expected no actor in a regular token, got %d", claims.ActorID)
+	}
+}
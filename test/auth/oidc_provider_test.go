@@ -0,0 +1,237 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"gfly/pkg/modules/auth/oauth"
+	"github.com/golang-jwt/jwt/v5"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// identityProviderStub is a minimal OpenID Connect provider (authorization code + PKCE).
+type identityProviderStub struct {
+	server        *httptest.Server
+	key           *rsa.PrivateKey
+	clientID      string
+	audience      string // `aud` claim of ID tokens, the client ID by default
+	emailVerified bool
+
+	mu    sync.Mutex
+	codes map[string]url.Values // Authorization requests by code
+}
+
+func newIdentityProviderStub(t *testing.T) *identityProviderStub {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stub := &identityProviderStub{
+		key:           key,
+		clientID:      "gfly-client",
+		audience:      "gfly-client",
+		emailVerified: true,
+		codes:         map[string]url.Values{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 stub.server.URL,
+			"authorization_endpoint": stub.server.URL + "/authorize",
+			"token_endpoint":         stub.server.URL + "/token",
+			"jwks_uri":               stub.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kid": "stub-key",
+				"kty": "RSA",
+				"use": "sig",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		code := oauth.RandomString(16)
+
+		stub.mu.Lock()
+		stub.codes[code] = query
+		stub.mu.Unlock()
+
+		http.Redirect(w, r, query.Get("redirect_uri")+"?code="+code+"&state="+url.QueryEscape(query.Get("state")), http.StatusFound)
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+
+		stub.mu.Lock()
+		authRequest, ok := stub.codes[r.PostForm.Get("code")]
+		delete(stub.codes, r.PostForm.Get("code"))
+		stub.mu.Unlock()
+
+		if !ok || r.PostForm.Get("client_id") != stub.clientID ||
+			oauth.CodeChallenge(r.PostForm.Get("code_verifier")) != authRequest.Get("code_challenge") {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"iss":            stub.server.URL,
+			"aud":            stub.audience,
+			"sub":            "stub-user-1",
+			"exp":            time.Now().Add(time.Hour).Unix(),
+			"iat":            time.Now().Unix(),
+			"nonce":          authRequest.Get("nonce"),
+			"email":          "John@Example.com",
+			"email_verified": stub.emailVerified,
+			"name":           "John Doe",
+		})
+		idToken.Header["kid"] = "stub-key"
+		signed, _ := idToken.SignedString(key)
+
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "stub-access-token",
+			"token_type":   "Bearer",
+			"id_token":     signed,
+		})
+	})
+	stub.server = httptest.NewServer(mux)
+	t.Cleanup(stub.server.Close)
+
+	return stub
+}
+
+// authorize follows the authorization URL and returns the code and the state of the callback.
+func (s *identityProviderStub) authorize(t *testing.T, authURL string) (string, string) {
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	resp, err := client.Get(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return location.Query().Get("code"), location.Query().Get("state")
+}
+
+func newStubProvider(stub *identityProviderStub, clientID string) *oauth.OIDCProvider {
+	return oauth.NewOIDCProvider(oauth.OIDCConfig{
+		Name:         "stub",
+		DiscoveryURL: stub.server.URL + "/.well-known/openid-configuration",
+		ClientID:     clientID,
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost:7789/api/v1/auth/oauth/stub/callback",
+	})
+}
+
+func TestOIDCProviderSignIn(t *testing.T) {
+	stub := newIdentityProviderStub(t)
+	provider := newStubProvider(stub, stub.clientID)
+
+	verifier := oauth.NewCodeVerifier()
+	authURL, err := provider.AuthCodeURL("state-1", oauth.CodeChallenge(verifier), "nonce-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(authURL, "code_challenge_method=S256") || !strings.Contains(authURL, "scope=openid+email+profile") {
+		t.Errorf("Authorization URL misses PKCE or scopes: %s", authURL)
+	}
+
+	code, state := stub.authorize(t, authURL)
+	if state != "state-1" {
+		t.Errorf("Expected state %q, got %q", "state-1", state)
+	}
+
+	identity, err := provider.Exchange(code, verifier, "nonce-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if identity.Provider != "stub" || identity.Subject != "stub-user-1" || identity.Email != "john@example.com" ||
+		!identity.EmailVerified || identity.Name != "John Doe" {
+		t.Errorf("Unexpected identity %+v", identity)
+	}
+}
+
+func TestOIDCProviderRejects(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(stub *identityProviderStub)
+		verifier func(verifier string) string
+		nonce    string
+	}{
+		{"WrongCodeVerifier", func(*identityProviderStub) {}, func(string) string { return oauth.NewCodeVerifier() }, "nonce-1"},
+		{"WrongNonce", func(*identityProviderStub) {}, func(v string) string { return v }, "other-nonce"},
+		{"WrongAudience", func(stub *identityProviderStub) { stub.audience = "other-client" }, func(v string) string { return v }, "nonce-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := newIdentityProviderStub(t)
+			tt.setup(stub)
+			provider := newStubProvider(stub, stub.clientID)
+
+			verifier := oauth.NewCodeVerifier()
+			authURL, err := provider.AuthCodeURL("state-1", oauth.CodeChallenge(verifier), "nonce-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			code, _ := stub.authorize(t, authURL)
+
+			if _, err = provider.Exchange(code, tt.verifier(verifier), tt.nonce); err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	}
+}
+
+func TestOIDCProviderUnverifiedEmail(t *testing.T) {
+	stub := newIdentityProviderStub(t)
+	stub.emailVerified = false
+	provider := newStubProvider(stub, stub.clientID)
+
+	verifier := oauth.NewCodeVerifier()
+	authURL, _ := provider.AuthCodeURL("state-1", oauth.CodeChallenge(verifier), "nonce-1")
+	code, _ := stub.authorize(t, authURL)
+
+	identity, err := provider.Exchange(code, verifier, "nonce-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if identity.EmailVerified {
+		t.Errorf("Expected unverified email")
+	}
+}
+
+func TestCodeChallenge(t *testing.T) {
+	// BASE64URL(SHA256(verifier)) without padding
+	challenge := oauth.CodeChallenge("dBjftJeZ4CI2G2Ts6bW6BUuvZ3Fx2vvIZ8Y7CgmVE_A")
+	if challenge != "-EAZny-BgvhtXeRatxKqhtvxtpsp7-NGSdmA6-tZegE" {
+		t.Errorf("Unexpected code challenge %q", challenge)
+	}
+}
@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"gfly/pkg/modules/auth/password"
+	"testing"
+)
+
+func TestPasswordPolicyCheck(t *testing.T) {
+	policy := password.Policy{
+		MinLength:    8,
+		RequireUpper: true,
+		RequireLower: true,
+		RequireDigit: true,
+		Denylist:     true,
+	}
+
+	tests := []struct {
+		name         string
+		password     string
+		personalData []string
+		expected     string
+	}{
+		{"Valid", "M1PassW@s", []string{"john@jivecode.com", "John Doe"}, ""},
+		{"TooShort", "Ab1", nil, "must be at least 8 characters"},
+		{"MissingClasses", "abcdefgh", nil, "must contain at least one uppercase letter, digit"},
+		{"Common", "Password1", nil, "is too common, choose a less guessable password"},
+		{"ContainsEmail", "Johnny2024X", []string{"johnny@jivecode.com"}, "must not contain your email or name"},
+		{"ContainsName", "xDoeDoe2024", []string{"john@jivecode.com", "John Doe"}, "must not contain your email or name"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := policy.Check(test.password, test.personalData...)
+
+			message := ""
+			if err != nil {
+				message = err.Error()
+			}
+
+			if message != test.expected {
+				t.Errorf("Expected %q, got %q for password: %s", test.expected, message, test.password)
+			}
+		})
+	}
+}
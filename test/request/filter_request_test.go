@@ -0,0 +1,52 @@
+package request
+
+import (
+	"gfly/internal/http/request"
+	"slices"
+	"testing"
+)
+
+func TestParseFilterConditions(t *testing.T) {
+	conditions, err := request.ParseFilterConditions(map[string]string{
+		"filter[status]":          "active, pending",
+		"filter[created_at][gte]": "2026-01-01",
+		"keyword":                 "john",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(conditions) != 2 {
+		t.Fatalf("Expected 2 conditions, got %d", len(conditions))
+	}
+
+	createdAt, status := conditions[0], conditions[1]
+
+	if createdAt.Field != "created_at" || createdAt.Operator != "gte" || !slices.Equal(createdAt.Values, []string{"2026-01-01"}) {
+		t.Errorf("Unexpected condition %+v", createdAt)
+	}
+
+	if status.Field != "status" || status.Operator != "eq" || !slices.Equal(status.Values, []string{"active", "pending"}) {
+		t.Errorf("Unexpected condition %+v", status)
+	}
+}
+
+func TestParseInvalidFilterConditions(t *testing.T) {
+	tests := []struct {
+		name    string
+		queries map[string]string
+	}{
+		{"MalformedKey", map[string]string{"filter[status": "active"}},
+		{"UppercaseField", map[string]string{"filter[Status]": "active"}},
+		{"NestedOperator", map[string]string{"filter[status][eq][x]": "active"}},
+		{"EmptyValue", map[string]string{"filter[status]": " , "}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := request.ParseFilterConditions(test.queries); err == nil {
+				t.Errorf("Expected an error for %v", test.queries)
+			}
+		})
+	}
+}
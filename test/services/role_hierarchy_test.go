@@ -0,0 +1,99 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"gfly/internal/domain/models"
+	"gfly/internal/domain/models/types"
+	"gfly/internal/services"
+	"slices"
+	"testing"
+)
+
+// role creates a role which inherits the parent (0 for none).
+func role(id int, slug types.Role, parentID int) models.Role {
+	return models.Role{
+		ID:       id,
+		Slug:     slug,
+		ParentID: sql.NullInt32{Int32: int32(parentID), Valid: parentID > 0},
+	}
+}
+
+// admin > moderator > member, guest
+var roleGraph = []models.Role{
+	role(1, types.RoleAdmin, 2),
+	role(2, types.RoleModerator, 3),
+	role(3, types.RoleMember, 0),
+	role(4, types.RoleGuest, 0),
+}
+
+func TestInheritRoles(t *testing.T) {
+	roles := models.InheritRoles(roleGraph, []models.Role{roleGraph[0], roleGraph[3]})
+
+	var slugs []types.Role
+	for _, r := range roles {
+		slugs = append(slugs, r.Slug)
+	}
+
+	expected := []types.Role{types.RoleAdmin, types.RoleModerator, types.RoleMember, types.RoleGuest}
+	if !slices.Equal(slugs, expected) {
+		t.Errorf("Expected %v, got %v", expected, slugs)
+	}
+}
+
+func TestInheritRolesWithBrokenCycle(t *testing.T) {
+	graph := []models.Role{
+		role(1, "a", 2),
+		role(2, "b", 1),
+	}
+
+	if roles := models.InheritRoles(graph, graph[:1]); len(roles) != 2 {
+		t.Errorf("Expected 2 roles, got %d", len(roles))
+	}
+}
+
+func TestCheckRoleParent(t *testing.T) {
+	// member can not inherit admin: admin already inherits member
+	if err := services.CheckRoleParent(roleGraph, 3, 1); !errors.Is(err, services.ErrRoleCycle) {
+		t.Errorf("Expected cycle error, got %v", err)
+	}
+
+	// A role can not inherit itself
+	if err := services.CheckRoleParent(roleGraph, 4, 4); !errors.Is(err, services.ErrRoleCycle) {
+		t.Errorf("Expected cycle error, got %v", err)
+	}
+
+	// guest can inherit admin, a new role can inherit anything
+	if err := services.CheckRoleParent(roleGraph, 4, 1); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if err := services.CheckRoleParent(roleGraph, 0, 1); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
+
+func TestOutranksRoles(t *testing.T) {
+	admin := models.InheritRoles(roleGraph, roleGraph[:1])
+	moderator := models.InheritRoles(roleGraph, roleGraph[1:2])
+
+	if !models.OutranksRoles(admin, moderator) {
+		t.Error("Expected admin to outrank moderator")
+	}
+
+	if models.OutranksRoles(moderator, admin) {
+		t.Error("Expected moderator not to outrank admin")
+	}
+
+	if models.OutranksRoles(admin, admin) {
+		t.Error("Expected admin not to outrank admin")
+	}
+
+	// guest is out of the admin branch
+	if models.OutranksRoles(admin, roleGraph[3:]) {
+		t.Error("Expected admin not to outrank guest")
+	}
+
+	if !models.OutranksRoles(moderator, []models.Role{}) {
+		t.Error("Expected moderator to outrank a user without role")
+	}
+}
@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"gfly/pkg/utils"
+	"testing"
+)
+
+func TestEncodeDecodeCursor(t *testing.T) {
+	email := "john@example.com"
+
+	tests := []struct {
+		name   string
+		cursor utils.Cursor
+	}{
+		{"Forward", utils.Cursor{OrderBy: "email", Value: &email, ID: 12}},
+		{"Backward", utils.Cursor{OrderBy: "-email", Value: &email, ID: 12, Backward: true}},
+		{"NullValue", utils.Cursor{OrderBy: "-last_access", ID: 7}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := utils.DecodeCursor(utils.EncodeCursor(test.cursor))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if result.OrderBy != test.cursor.OrderBy || result.ID != test.cursor.ID || result.Backward != test.cursor.Backward {
+				t.Errorf("Expected %+v, got %+v", test.cursor, result)
+			}
+
+			if (result.Value == nil) != (test.cursor.Value == nil) || (result.Value != nil && *result.Value != *test.cursor.Value) {
+				t.Errorf("Expected value %v, got %v", test.cursor.Value, result.Value)
+			}
+		})
+	}
+}
+
+func TestDecodeInvalidCursor(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"NotBase64", "not a cursor!"},
+		{"NotJSON", "bm90IGpzb24"},
+		{"MissingID", utils.EncodeCursor(utils.Cursor{OrderBy: "email"})},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := utils.DecodeCursor(test.cursor); err == nil {
+				t.Errorf("Expected error for cursor: %s", test.cursor)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"gfly/pkg/utils"
+	"reflect"
+	"testing"
+)
+
+func TestReadCSVSpreadsheet(t *testing.T) {
+	data := []byte("\xef\xbb\xbfemail,fullname,roles\njohn@example.com,John Doe,\"admin,user\"\n\njane@example.com,Jane\n")
+
+	rows, err := utils.ReadSpreadsheet("users.CSV", data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := [][]string{
+		{"email", "fullname", "roles"},
+		{"john@example.com", "John Doe", "admin,user"},
+		{},
+		{"jane@example.com", "Jane"},
+	}
+
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("Expected %v, got %v", expected, rows)
+	}
+}
+
+func TestReadXLSXSpreadsheet(t *testing.T) {
+	data := buildXLSX(t, map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Users" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Target="worksheets/users.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+			`<si><t>email</t></si><si><t>phone</t></si><si><r><t>john@</t></r><r><t>example.com</t></r></si></sst>`,
+		"xl/worksheets/users.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` +
+			`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>` +
+			`<row r="3"><c r="A3" t="s"><v>2</v></c><c r="C3" t="inlineStr"><is><t>note</t></is></c></row>` +
+			`<row r="4"><c r="B4"><v>989831911</v></c></row>` +
+			`</sheetData></worksheet>`,
+	})
+
+	rows, err := utils.ReadSpreadsheet("users.xlsx", data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := [][]string{
+		{"email", "phone"},
+		{},
+		{"john@example.com", "", "note"},
+		{"", "989831911"},
+	}
+
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("Expected %v, got %v", expected, rows)
+	}
+}
+
+func TestReadInvalidSpreadsheet(t *testing.T) {
+	if _, err := utils.ReadSpreadsheet("users.txt", []byte("email")); !errors.Is(err, utils.ErrUnsupportedSpreadsheet) {
+		t.Errorf("Expected ErrUnsupportedSpreadsheet, got %v", err)
+	}
+
+	if _, err := utils.ReadSpreadsheet("users.xlsx", []byte("not a zip")); err == nil {
+		t.Error("Expected an error for an invalid XLSX file")
+	}
+
+	if _, err := utils.ReadSpreadsheet("users.csv", []byte("email\n\"john")); err == nil {
+		t.Error("Expected an error for an invalid CSV file")
+	}
+}
+
+// buildXLSX creates a zip archive with the given parts.
+func buildXLSX(t *testing.T, parts map[string]string) []byte {
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+
+	for name, content := range parts {
+		part, err := archive.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = part.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buffer.Bytes()
+}